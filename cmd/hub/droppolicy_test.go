@@ -0,0 +1,84 @@
+package main
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/lvonguyen/threat-telemetry-hub/internal/ingestion"
+)
+
+func TestDropPolicySinkBlockWaitsForRoom(t *testing.T) {
+	ch := make(chan *ingestion.RawEvent, 1)
+	sink := newDropPolicySink(ch, "block")
+
+	if err := sink.Send(context.Background(), &ingestion.RawEvent{}); err != nil {
+		t.Fatalf("first Send: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	done := make(chan error, 1)
+	go func() { done <- sink.Send(ctx, &ingestion.RawEvent{}) }()
+
+	select {
+	case err := <-done:
+		t.Fatalf("Send on a full channel returned early with %v, want it to block", err)
+	case <-time.After(20 * time.Millisecond):
+	}
+
+	<-ch // drain one slot
+	if err := <-done; err != nil {
+		t.Fatalf("Send after room freed up: %v", err)
+	}
+}
+
+func TestDropPolicySinkBlockRespectsContextCancellation(t *testing.T) {
+	ch := make(chan *ingestion.RawEvent, 1)
+	sink := newDropPolicySink(ch, "block")
+	_ = sink.Send(context.Background(), &ingestion.RawEvent{})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if err := sink.Send(ctx, &ingestion.RawEvent{}); err == nil {
+		t.Fatal("Send on a full channel with a canceled context returned nil error")
+	}
+}
+
+func TestDropPolicySinkDropNewDiscardsIncomingEvent(t *testing.T) {
+	ch := make(chan *ingestion.RawEvent, 1)
+	sink := newDropPolicySink(ch, "drop-new")
+
+	kept := &ingestion.RawEvent{}
+	dropped := &ingestion.RawEvent{}
+	if err := sink.Send(context.Background(), kept); err != nil {
+		t.Fatalf("first Send: %v", err)
+	}
+	if err := sink.Send(context.Background(), dropped); err != nil {
+		t.Fatalf("Send on a full channel under drop-new: %v", err)
+	}
+
+	if len(ch) != 1 || <-ch != kept {
+		t.Fatal("drop-new replaced the already-queued event instead of discarding the new one")
+	}
+}
+
+func TestDropPolicySinkDropOldestEvictsQueuedEvent(t *testing.T) {
+	ch := make(chan *ingestion.RawEvent, 1)
+	sink := newDropPolicySink(ch, "drop-oldest")
+
+	oldest := &ingestion.RawEvent{}
+	newest := &ingestion.RawEvent{}
+	if err := sink.Send(context.Background(), oldest); err != nil {
+		t.Fatalf("first Send: %v", err)
+	}
+	if err := sink.Send(context.Background(), newest); err != nil {
+		t.Fatalf("Send on a full channel under drop-oldest: %v", err)
+	}
+
+	if len(ch) != 1 || <-ch != newest {
+		t.Fatal("drop-oldest did not evict the already-queued event in favor of the new one")
+	}
+}
@@ -0,0 +1,62 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health/grpc_health_v1"
+
+	"github.com/lvonguyen/threat-telemetry-hub/internal/observability"
+	"github.com/lvonguyen/threat-telemetry-hub/internal/safe"
+)
+
+// newGRPCServer builds the hub's gRPC server and registers the
+// grpc.health.v1.Health service against checker, so Kubernetes gRPC
+// probes, Envoy health-check clusters, and service meshes can consume
+// component status without HTTP. Future RPC services share this same
+// server/listener.
+func newGRPCServer(checker *observability.HealthChecker, logger *zap.Logger) *grpc.Server {
+	server := grpc.NewServer(grpc.UnaryInterceptor(grpcRecovery(logger)))
+	grpc_health_v1.RegisterHealthServer(server, observability.NewGRPCHealthServer(checker))
+	return server
+}
+
+// grpcRecovery recovers a panic in a unary RPC handler, routing it through
+// the same logging/metrics as internal/safe's goroutine wrappers and
+// jsonRecovery's HTTP counterpart (middleware.go), and turns it into a failed call
+// instead of crashing the server.
+func grpcRecovery(logger *zap.Logger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp interface{}, err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				safe.RecordPanic(logger, "grpc."+info.FullMethod, r)
+				err = fmt.Errorf("internal server error")
+			}
+		}()
+		return handler(ctx, req)
+	}
+}
+
+// serveGRPC listens on addr and runs server until ctx is done, at which
+// point it stops the server gracefully. Mirrors how the HTTP server is run
+// in a goroutine in main, with ListenAndServe's error path.
+func serveGRPC(ctx context.Context, server *grpc.Server, addr string, logger *zap.Logger) {
+	lis, err := net.Listen("tcp", addr)
+	if err != nil {
+		logger.Fatal("gRPC listener failed", zap.Error(err))
+	}
+
+	go func() {
+		if err := server.Serve(lis); err != nil {
+			logger.Error("gRPC server failed", zap.Error(err))
+		}
+	}()
+
+	go func() {
+		<-ctx.Done()
+		server.GracefulStop()
+	}()
+}
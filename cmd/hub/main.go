@@ -5,66 +5,281 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"net/http/pprof"
 	"os"
 	"os/signal"
+	"sync"
 	"syscall"
 	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus"
 	"go.uber.org/zap"
 
 	"github.com/lvonguyen/threat-telemetry-hub/internal/ai"
 	"github.com/lvonguyen/threat-telemetry-hub/internal/config"
 	"github.com/lvonguyen/threat-telemetry-hub/internal/correlation"
+	"github.com/lvonguyen/threat-telemetry-hub/internal/crowdsec"
+	"github.com/lvonguyen/threat-telemetry-hub/internal/detection"
 	"github.com/lvonguyen/threat-telemetry-hub/internal/enrichment"
+	"github.com/lvonguyen/threat-telemetry-hub/internal/export"
 	"github.com/lvonguyen/threat-telemetry-hub/internal/ingestion"
 	"github.com/lvonguyen/threat-telemetry-hub/internal/normalization"
+	"github.com/lvonguyen/threat-telemetry-hub/internal/observability"
+	"github.com/lvonguyen/threat-telemetry-hub/internal/ocsf"
+	"github.com/lvonguyen/threat-telemetry-hub/internal/output/ticketing"
+	"github.com/lvonguyen/threat-telemetry-hub/internal/output/ticketing/authz"
+	pipelinedag "github.com/lvonguyen/threat-telemetry-hub/internal/pipeline"
+	"github.com/lvonguyen/threat-telemetry-hub/internal/safe"
 )
 
 func main() {
-	// Initialize logger
-	logger, err := zap.NewProduction()
+	// `tth rules ...` is a cscli-style local CLI for managing detection
+	// rules; every other invocation starts the server.
+	if len(os.Args) > 1 && os.Args[1] == "rules" {
+		os.Exit(runRulesCLI(os.Args[2:]))
+	}
+
+	// Bootstrap logger, used only until telemetry (which wraps its own
+	// zap logger) comes up.
+	bootLogger, err := zap.NewProduction()
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Failed to initialize logger: %v\n", err)
 		os.Exit(1)
 	}
-	defer logger.Sync()
 
 	// Load configuration
 	cfg, err := config.Load("configs/config.yaml")
 	if err != nil {
-		logger.Fatal("Failed to load configuration", zap.Error(err))
+		bootLogger.Fatal("Failed to load configuration", zap.Error(err))
+	}
+
+	telemetry, err := observability.New(cfg.Observability)
+	if err != nil {
+		bootLogger.Fatal("Failed to initialize telemetry", zap.Error(err))
 	}
+	defer telemetry.Shutdown(context.Background())
+
+	logger := telemetry.Logger()
 
 	logger.Info("Starting Threat Telemetry Hub",
 		zap.String("version", "1.0.0"),
 		zap.Int("port", cfg.Server.Port),
 	)
 
-	// Initialize AI analyzer
-	aiAnalyzer, err := ai.NewAnalyzer(cfg.AI, logger)
+	// Initialize AI analyzer. BatchAnalyzer runs its own anthropic ->
+	// openai -> local rule-based fallback chain with batching, caching, and global
+	// token-budget rate limiting so high-volume sources don't blow through
+	// provider rate limits one event at a time.
+	aiAnalyzer, err := ai.NewBatchAnalyzer(cfg.AI, logger)
 	if err != nil {
 		logger.Fatal("Failed to initialize AI analyzer", zap.Error(err))
 	}
 
 	// Initialize components
 	ingestionMgr := ingestion.NewManager(cfg.Ingestion, logger)
-	normalizer := normalization.NewNormalizer(cfg.Normalization, logger)
+	dlq := make(chan *ocsf.DLQEntry, 100)
+	normalizer := normalization.NewNormalizer(cfg.Normalization, logger, dlq)
 	enricher := enrichment.NewEnricher(cfg.Enrichment, logger)
 	correlator := correlation.NewCorrelator(logger)
+	// Correlator computes its metrics from the live component graph at
+	// scrape time, so it's registered directly rather than through
+	// Telemetry.initMetrics - the telemetry package is built before the
+	// correlator exists.
+	prometheus.MustRegister(correlator)
+
+	detectionEngine, err := detection.NewEngine(cfg.Detection, logger)
+	if err != nil {
+		logger.Fatal("Failed to initialize detection engine", zap.Error(err))
+	}
+
+	// Only wired up when the CrowdSec integration is both enabled and
+	// configured to push remediations, so a deployment that only wants the
+	// read-only IPS feed doesn't need LAPI write credentials at all.
+	var remediation ticketing.RemediationSink
+	if cfg.Ingestion.IPS.CrowdSec.RemediationEnabled {
+		csClient, err := crowdsec.NewClient(cfg.Ingestion.IPS.CrowdSec)
+		if err != nil {
+			logger.Warn("CrowdSec remediation disabled: failed to create LAPI client", zap.Error(err))
+		} else {
+			remediation = ticketing.NewCrowdSecRemediationSink(csClient, logger)
+		}
+	}
+
+	remediationDuration := time.Duration(cfg.Ingestion.IPS.CrowdSec.DecisionDurationMinutes) * time.Minute
 
 	// Create processing pipeline
-	pipeline := NewPipeline(ingestionMgr, normalizer, aiAnalyzer, enricher, correlator, logger)
+	pipeline := NewPipeline(cfg.Pipeline, ingestionMgr, normalizer, aiAnalyzer, enricher, correlator, detectionEngine, remediation, remediationDuration, logger)
 
 	// Start pipeline
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
 	go pipeline.Start(ctx)
+	go drainDLQ(ctx, dlq, logger)
+
+	// Pipeline.outputChan otherwise has no consumer; fan each ProcessedEvent
+	// out to whichever export sinks are configured, so downstream
+	// tracing/Application Insights/SIEM re-ingestion pipelines see what the
+	// hub produced instead of it only being logged.
+	exportFanOut := newExportFanOut(ctx, cfg.Export, logger)
+	if exportFanOut != nil {
+		exportFanOut.Start(ctx)
+		go safe.Loop(ctx, logger, "export.fanout", func(ctx context.Context) {
+			exportProcessedEvents(ctx, pipeline.outputChan, exportFanOut)
+		})
+	}
+
+	if cfg.Detection.HotReload {
+		go func() {
+			if err := detectionEngine.Watch(ctx); err != nil {
+				logger.Warn("Detection rule watcher stopped", zap.Error(err))
+			}
+		}()
+	}
+
+	// Periodically discovers correlation rule groups (Sigma-style
+	// detections and MITRE-tagged match fields) from disk and pushes them
+	// into correlator, so `git push`-ing a new rule file takes effect
+	// within one poll interval without restarting the hub.
+	ruleFinder := correlation.NewFileFinder(cfg.Correlation.RulesDir)
+	ruleNotifier := correlation.NewPeriodicRuleNotifier(ruleFinder, time.Duration(cfg.Correlation.PollIntervalSeconds)*time.Second, logger)
+	go safe.Loop(ctx, logger, "correlation.rule_notifier", ruleNotifier.Start)
+	go safe.Loop(ctx, logger, "correlation.rule_apply", func(ctx context.Context) {
+		applyRuleGroups(ctx, correlator, ruleNotifier, logger)
+	})
+
+	// Push-mode sources (webhooks, cloud audit-log forwarders) deliver
+	// CloudEvents directly over HTTP rather than being polled, so they're
+	// wired in as a Transport alongside the poll-based collectors.
+	ceReceiver, err := ingestion.NewHTTPReceiver(logger, pipeline.eventChan)
+	if err != nil {
+		logger.Fatal("Failed to initialize CloudEvents HTTP receiver", zap.Error(err))
+	}
+
+	// Only wired up when the ServiceNow integration is enabled, so a
+	// deployment without a ServiceNow instance doesn't need its
+	// credentials or run the webhook/reconciliation loop.
+	var snWebhook *ticketing.WebhookReceiver
+	var ticketingMgr *ticketing.Manager
+	if cfg.Output.Ticketing.ServiceNow.Enabled {
+		snCfg := ticketing.ServiceNowConfig{
+			InstanceURL:     cfg.Output.Ticketing.ServiceNow.InstanceURL,
+			UsernameEnv:     cfg.Output.Ticketing.ServiceNow.UsernameEnv,
+			PasswordEnv:     cfg.Output.Ticketing.ServiceNow.PasswordEnv,
+			DefaultTable:    cfg.Output.Ticketing.ServiceNow.DefaultTable,
+			AssignmentGroup: cfg.Output.Ticketing.ServiceNow.AssignmentGroup,
+			CallerID:        cfg.Output.Ticketing.ServiceNow.CallerID,
+		}
+		snProvider, err := ticketing.NewServiceNowProvider(snCfg, logger)
+		if err != nil {
+			logger.Warn("ServiceNow ticketing integration disabled: failed to create provider", zap.Error(err))
+		} else {
+			ticketEvents := make(chan ticketing.TicketEvent, 100)
+
+			snWebhook, err = ticketing.NewWebhookReceiver(cfg.Output.Ticketing.ServiceNow.WebhookSecretEnv, ticketEvents, logger)
+			if err != nil {
+				logger.Warn("ServiceNow webhook disabled: failed to create receiver", zap.Error(err))
+			}
+
+			syncInterval := time.Duration(cfg.Output.Ticketing.ServiceNow.SyncIntervalSeconds) * time.Second
+			syncMgr := ticketing.NewSyncManager(snProvider, syncInterval, ticketEvents, logger)
+			go safe.Loop(ctx, logger, "ticketing.sync", syncMgr.Start)
+
+			go safe.Loop(ctx, logger, "ticketing.webhook_sync", func(ctx context.Context) {
+				syncTicketEvents(ctx, correlator, ticketEvents, logger)
+			})
+
+			ticketingMgr = ticketing.NewManager(ticketing.ManagerConfig{DefaultProvider: snProvider.Name(), AutoCreateTicket: true}, logger)
+			ticketingMgr.RegisterProvider(snProvider)
+		}
+	}
+
+	// Gates every ticketingMgr.CreateTicketForEvent call behind JWT
+	// authentication and intention-rule policy once a ticketing manager
+	// exists to gate; with Authz.Enabled false (the default) tickets are
+	// created exactly as before authz existed.
+	var ticketingToken string
+	if ticketingMgr != nil && cfg.Output.Ticketing.Authz.Enabled {
+		verifier := authz.NewVerifier(
+			cfg.Output.Ticketing.Authz.JWKSURL,
+			time.Duration(cfg.Output.Ticketing.Authz.ClockSkewSeconds)*time.Second,
+		)
+		intentions, err := authz.LoadIntentionsFile(cfg.Output.Ticketing.Authz.IntentionRulesFile)
+		if err != nil {
+			logger.Fatal("Failed to load ticketing intention rules", zap.Error(err))
+		}
+		ticketingMgr.SetAuthorizer(authz.NewAuthorizer(verifier, intentions, logger))
+		ticketingToken = os.Getenv(cfg.Output.Ticketing.Authz.ServiceTokenEnv)
+	}
+
+	// Each entry in cfg.Pipelines is a separately declared internal/pipeline
+	// DAG (see config.PipelineDefinition) alongside the hub's fixed
+	// ingestion -> AI -> normalization -> enrichment -> detection ->
+	// correlation flow started above - e.g. a compliance-only export feed
+	// that skips AI analysis. Source/Processors/Sinks are resolved by name
+	// against internal/pipeline's own registry.
+	pipelineDeps := pipelinedag.Deps{
+		Logger:           logger,
+		Eventing:         cfg.Eventing,
+		TicketingManager: ticketingMgr,
+		TicketingToken:   ticketingToken,
+	}
+	for _, def := range cfg.Pipelines {
+		source, processors, sinks, err := pipelinedag.Resolve(def, pipelineDeps)
+		if err != nil {
+			logger.Fatal("Failed to resolve declared pipeline", zap.String("pipeline", def.Name), zap.Error(err))
+		}
+		dag, err := pipelinedag.New(def, source, processors, sinks, nil, logger)
+		if err != nil {
+			logger.Fatal("Failed to build declared pipeline", zap.String("pipeline", def.Name), zap.Error(err))
+		}
+		go safe.Loop(ctx, logger, "pipeline."+def.Name, func(ctx context.Context) {
+			if err := dag.Start(ctx); err != nil && ctx.Err() == nil {
+				logger.Error("Declared pipeline stopped with error", zap.String("pipeline", def.Name), zap.Error(err))
+			}
+		})
+	}
 
-	// Setup HTTP server
-	router := gin.Default()
-	setupRoutes(router, pipeline, logger)
+	// gRPC health server: a companion to the HTTP /health endpoint so
+	// Kubernetes gRPC probes, Envoy health-check clusters, and service
+	// meshes can consume component status without HTTP.
+	healthChecker := observability.NewHealthChecker(logger, telemetry)
+	grpcServer := newGRPCServer(healthChecker, logger)
+	serveGRPC(ctx, grpcServer, fmt.Sprintf(":%d", cfg.Server.GRPCPort), logger)
+
+	// Keeps the checker's status Aggregator fresh in the background, so
+	// ReadinessHandler/HealthHandler/PipelineStatusHandler read a
+	// pre-computed snapshot instead of each request fanning out its own
+	// synchronous probes.
+	go safe.Loop(ctx, logger, "health.poll", healthChecker.PollLoop)
+
+	// /health/cluster fans out to peer hub instances in a multi-instance
+	// deployment, so only wire it up when cluster health is configured.
+	if cfg.Observability.Cluster.Enabled {
+		clusterCfg := cfg.Observability.Cluster
+		discovery, err := observability.NewPeerDiscovery(clusterCfg)
+		if err != nil {
+			logger.Warn("Cluster health aggregation disabled: failed to build peer discovery", zap.Error(err))
+		} else {
+			token := os.Getenv(clusterCfg.TokenEnv)
+			if token == "" {
+				logger.Warn("Cluster health aggregation disabled: missing bearer token", zap.String("token_env", clusterCfg.TokenEnv))
+			} else {
+				peerTimeout := time.Duration(clusterCfg.PeerTimeoutSeconds) * time.Second
+				maxClockSkew := time.Duration(clusterCfg.MaxClockSkewSeconds) * time.Second
+				clusterAgg := observability.NewClusterAggregator(discovery, peerTimeout, maxClockSkew, logger)
+				healthChecker.EnableClusterAggregation(clusterAgg, token)
+			}
+		}
+	}
+
+	// Setup HTTP server. gin.Default()'s built-in Recovery returns a plain
+	// text 500 on panic; jsonRecovery replaces it so a handler panic still
+	// produces the JSON this API's clients expect everywhere else.
+	router := gin.New()
+	router.Use(gin.Logger(), jsonRecovery(logger))
+	setupRoutes(router, pipeline, ceReceiver, snWebhook, healthChecker, logger)
 
 	server := &http.Server{
 		Addr:    fmt.Sprintf(":%d", cfg.Server.Port),
@@ -80,8 +295,26 @@ func main() {
 
 	logger.Info("Threat Telemetry Hub started successfully",
 		zap.String("api_url", fmt.Sprintf("http://localhost:%d", cfg.Server.Port)),
+		zap.Int("grpc_port", cfg.Server.GRPCPort),
 	)
 
+	// SIGHUP re-reads configs/config.yaml and applies ingestion changes
+	// (sources enabled/disabled, credentials rotated) without a restart,
+	// via Manager.Reload.
+	reload := make(chan os.Signal, 1)
+	signal.Notify(reload, syscall.SIGHUP)
+	go func() {
+		for range reload {
+			logger.Info("Received SIGHUP, reloading ingestion config")
+			reloadedCfg, err := config.Load("configs/config.yaml")
+			if err != nil {
+				logger.Error("SIGHUP reload: failed to load config", zap.Error(err))
+				continue
+			}
+			ingestionMgr.Reload(ctx, reloadedCfg.Ingestion)
+		}
+	}()
+
 	// Wait for shutdown signal
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
@@ -98,19 +331,82 @@ func main() {
 	}
 
 	cancel() // Stop pipeline
+
+	if exportFanOut != nil {
+		if err := exportFanOut.Shutdown(shutdownCtx); err != nil {
+			logger.Warn("Error shutting down export sinks", zap.Error(err))
+		}
+	}
+
+	if err := ingestionMgr.Close(); err != nil {
+		logger.Warn("Error closing checkpoint backend", zap.Error(err))
+	}
+
 	logger.Info("Threat Telemetry Hub stopped")
 }
 
 // Pipeline orchestrates the telemetry processing flow
 type Pipeline struct {
-	ingestion   *ingestion.Manager
-	normalizer  *normalization.Normalizer
-	aiAnalyzer  *ai.Analyzer
-	enricher    *enrichment.Enricher
-	correlator  *correlation.Correlator
-	logger      *zap.Logger
-	eventChan   chan *ingestion.RawEvent
-	outputChan  chan *ProcessedEvent
+	cfg                 config.PipelineConfig
+	ingestion           *ingestion.Manager
+	normalizer          *normalization.Normalizer
+	aiAnalyzer          ai.RiskAnalyzer
+	enricher            *enrichment.Enricher
+	detectionEngine     *detection.Engine
+	correlator          *correlation.Correlator
+	remediation         ticketing.RemediationSink
+	remediationDuration time.Duration
+	logger              *zap.Logger
+	eventChan           chan *ingestion.RawEvent
+	outputChan          chan *ProcessedEvent
+
+	aiSem        chan struct{}
+	stageTimeout time.Duration
+}
+
+// dropPolicySink wraps the pipeline's event channel with the configured
+// DropPolicy, so a collector burst that fills the queue is handled the way
+// the operator chose instead of blocking every collector indefinitely.
+type dropPolicySink struct {
+	ch     chan *ingestion.RawEvent
+	policy string
+}
+
+func newDropPolicySink(ch chan *ingestion.RawEvent, policy string) *dropPolicySink {
+	return &dropPolicySink{ch: ch, policy: policy}
+}
+
+func (s *dropPolicySink) Send(ctx context.Context, event *ingestion.RawEvent) error {
+	switch s.policy {
+	case "drop-new":
+		select {
+		case s.ch <- event:
+		default:
+			eventsDroppedTotal.WithLabelValues("drop-new").Inc()
+		}
+		return nil
+	case "drop-oldest":
+		for {
+			select {
+			case s.ch <- event:
+				return nil
+			default:
+			}
+			select {
+			case <-s.ch:
+				eventsDroppedTotal.WithLabelValues("drop-oldest").Inc()
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+	default: // "block"
+		select {
+		case s.ch <- event:
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
 }
 
 // ProcessedEvent represents a fully processed telemetry event
@@ -123,6 +419,7 @@ type ProcessedEvent struct {
 	RawData         map[string]interface{} `json:"raw_data"`
 	AIAnalysis      *ai.RiskAnalysis       `json:"ai_analysis,omitempty"`
 	Enrichments     map[string]interface{} `json:"enrichments,omitempty"`
+	Detections      []detection.Detection  `json:"detections,omitempty"`
 	CorrelationID   string                 `json:"correlation_id,omitempty"`
 	RiskScore       float64                `json:"risk_score"`
 	RiskLevel       string                 `json:"risk_level"`
@@ -132,57 +429,132 @@ type ProcessedEvent struct {
 
 // NewPipeline creates a new processing pipeline
 func NewPipeline(
+	cfg config.PipelineConfig,
 	ingestionMgr *ingestion.Manager,
 	normalizer *normalization.Normalizer,
-	aiAnalyzer *ai.Analyzer,
+	aiAnalyzer ai.RiskAnalyzer,
 	enricher *enrichment.Enricher,
 	correlator *correlation.Correlator,
+	detectionEngine *detection.Engine,
+	remediation ticketing.RemediationSink,
+	remediationDuration time.Duration,
 	logger *zap.Logger,
 ) *Pipeline {
 	return &Pipeline{
-		ingestion:  ingestionMgr,
-		normalizer: normalizer,
-		aiAnalyzer: aiAnalyzer,
-		enricher:   enricher,
-		correlator: correlator,
-		logger:     logger,
-		eventChan:  make(chan *ingestion.RawEvent, 1000),
-		outputChan: make(chan *ProcessedEvent, 1000),
+		cfg:                 cfg,
+		ingestion:           ingestionMgr,
+		normalizer:          normalizer,
+		aiAnalyzer:          aiAnalyzer,
+		enricher:            enricher,
+		detectionEngine:     detectionEngine,
+		correlator:          correlator,
+		remediation:         remediation,
+		remediationDuration: remediationDuration,
+		logger:              logger,
+		eventChan:           make(chan *ingestion.RawEvent, cfg.QueueSize),
+		outputChan:          make(chan *ProcessedEvent, 1000),
+		aiSem:               make(chan struct{}, cfg.AIConcurrency),
+		stageTimeout:        time.Duration(cfg.StageTimeoutMS) * time.Millisecond,
 	}
 }
 
-// Start begins processing events
+// Start begins processing events with a bounded pool of worker goroutines,
+// so a burst from any collector is absorbed by the queue (and, once full,
+// by cfg.DropPolicy) instead of spawning unbounded goroutines that blow
+// memory and starve AI/enrichment calls.
 func (p *Pipeline) Start(ctx context.Context) {
-	p.logger.Info("Starting telemetry processing pipeline")
+	p.logger.Info("Starting telemetry processing pipeline",
+		zap.Int("workers", p.cfg.WorkerCount),
+		zap.Int("queue_size", p.cfg.QueueSize),
+		zap.String("drop_policy", p.cfg.DropPolicy),
+	)
 
-	// Start ingestion
-	go p.ingestion.Start(ctx, p.eventChan)
+	// Start ingestion, handing it a Sink that enforces our drop policy once
+	// the queue fills rather than blocking every collector forever.
+	go p.ingestion.Start(ctx, newDropPolicySink(p.eventChan, p.cfg.DropPolicy))
+
+	go p.reportQueueDepth(ctx)
+
+	var wg sync.WaitGroup
+	for i := 0; i < p.cfg.WorkerCount; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			// Loop recovers a panic from a single malformed event instead
+			// of losing this worker for good, re-entering worker's loop
+			// with exponential backoff rather than crashing the process.
+			safe.Loop(ctx, p.logger, "pipeline.worker", p.worker)
+		}()
+	}
+	wg.Wait()
+
+	p.logger.Info("Pipeline shut down")
+}
 
-	// Process events
+// worker pulls events off eventChan until ctx is done, processing them one
+// at a time so a single worker's in-flight stage timeouts bound how much
+// backlog it can accumulate.
+func (p *Pipeline) worker(ctx context.Context) {
 	for {
 		select {
 		case <-ctx.Done():
-			p.logger.Info("Pipeline shutting down")
 			return
 		case rawEvent := <-p.eventChan:
-			go p.processEvent(ctx, rawEvent)
+			workerBusy.Inc()
+			p.processEvent(ctx, rawEvent)
+			workerBusy.Dec()
+		}
+	}
+}
+
+// reportQueueDepth periodically refreshes the queue_depth gauge and warns
+// once it crosses QueueHighWatermark, so congestion is visible before
+// DropPolicy starts discarding events.
+func (p *Pipeline) reportQueueDepth(ctx context.Context) {
+	ticker := time.NewTicker(5 * time.Second)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			depth := len(p.eventChan)
+			queueDepth.Set(float64(depth))
+			if p.cfg.QueueHighWatermark > 0 && depth >= p.cfg.QueueHighWatermark {
+				p.logger.Warn("Pipeline queue above high watermark",
+					zap.Int("depth", depth),
+					zap.Int("high_watermark", p.cfg.QueueHighWatermark),
+				)
+			}
 		}
 	}
 }
 
 func (p *Pipeline) processEvent(ctx context.Context, raw *ingestion.RawEvent) {
+	// Rejoin the trace CreateRawEvent started for raw, so every stage
+	// below shares one trace_id with the ingestion.collect span that
+	// delivered it.
+	ctx = ingestion.ContextWithTrace(ctx, raw)
+
 	p.logger.Debug("Processing event",
-		zap.String("source", raw.Source),
-		zap.String("id", raw.ID),
+		zap.String("source", ingestion.SourceName(raw)),
+		zap.String("id", raw.ID()),
 	)
 
 	// Step 1: AI analysis on raw data (before normalization)
-	// This catches context that might be lost in normalization
-	aiAnalysis, err := p.aiAnalyzer.AnalyzeRawEvent(ctx, raw)
+	// This catches context that might be lost in normalization. The
+	// semaphore bounds how many of these calls - the slowest stage by far -
+	// are in flight at once, independent of WorkerCount, since they share
+	// the AI providers' own rate limits.
+	aiCtx, aiCancel := context.WithTimeout(ctx, p.stageTimeout)
+	p.aiSem <- struct{}{}
+	aiAnalysis, err := p.aiAnalyzer.AnalyzeRawEvent(aiCtx, raw)
+	<-p.aiSem
+	aiCancel()
 	if err != nil {
 		p.logger.Warn("AI analysis failed, continuing without",
 			zap.Error(err),
-			zap.String("event_id", raw.ID),
+			zap.String("event_id", raw.ID()),
 		)
 	}
 
@@ -191,33 +563,39 @@ func (p *Pipeline) processEvent(ctx context.Context, raw *ingestion.RawEvent) {
 	if err != nil {
 		p.logger.Error("Normalization failed",
 			zap.Error(err),
-			zap.String("event_id", raw.ID),
+			zap.String("event_id", raw.ID()),
 		)
 		return
 	}
 
 	// Step 3: Enrich with context
-	enrichments, err := p.enricher.Enrich(ctx, normalized)
+	enrichCtx, enrichCancel := context.WithTimeout(ctx, p.stageTimeout)
+	enrichments, err := p.enricher.Enrich(enrichCtx, normalized)
+	enrichCancel()
 	if err != nil {
 		p.logger.Warn("Enrichment partially failed",
 			zap.Error(err),
-			zap.String("event_id", raw.ID),
+			zap.String("event_id", raw.ID()),
 		)
 	}
 
-	// Step 4: Correlate with other events
+	// Step 4: Evaluate detection rules against the enriched event
+	detections := p.detectionEngine.Evaluate(normalized, enrichments)
+
+	// Step 5: Correlate with other events
 	correlationID := p.correlator.Correlate(normalized)
 
 	// Build processed event
 	processed := &ProcessedEvent{
-		ID:             raw.ID,
-		Timestamp:      raw.Timestamp,
-		Source:         raw.Source,
-		SourceType:     raw.SourceType,
+		ID:             raw.ID(),
+		Timestamp:      raw.Time(),
+		Source:         ingestion.SourceName(raw),
+		SourceType:     ingestion.SourceType(raw),
 		NormalizedData: normalized.Data,
-		RawData:        raw.Data,
+		RawData:        ingestion.DataMap(raw),
 		AIAnalysis:     aiAnalysis,
 		Enrichments:    enrichments,
+		Detections:     detections,
 		CorrelationID:  correlationID,
 	}
 
@@ -237,6 +615,199 @@ func (p *Pipeline) processEvent(ctx context.Context, raw *ingestion.RawEvent) {
 		zap.Float64("risk_score", processed.RiskScore),
 		zap.String("risk_level", processed.RiskLevel),
 	)
+
+	if processed.RiskLevel == "critical" {
+		p.remediateCriticalEvent(ctx, processed)
+	}
+}
+
+// remediateCriticalEvent pushes a remediation decision for a critical-risk
+// event carrying an attacker IP observable. It runs in its own goroutine so
+// a slow or failing remediation push never delays the worker that produced
+// processed.
+func (p *Pipeline) remediateCriticalEvent(ctx context.Context, processed *ProcessedEvent) {
+	if p.remediation == nil {
+		return
+	}
+
+	ip, ok := attackerIP(processed.NormalizedData)
+	if !ok {
+		return
+	}
+
+	go func() {
+		remediateCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), p.stageTimeout)
+		defer cancel()
+
+		req := ticketing.RemediationRequest{
+			AttackerIP:      ip,
+			Duration:        p.remediationDuration,
+			Reason:          "critical-risk-detection",
+			SourceEventID:   processed.ID,
+			MITRETechniques: processed.MITRETechniques,
+		}
+
+		if err := p.remediation.PushRemediation(remediateCtx, req); err != nil {
+			p.logger.Warn("Failed to push remediation decision",
+				zap.Error(err),
+				zap.String("event_id", processed.ID),
+				zap.String("ip", ip),
+			)
+		}
+	}()
+}
+
+// attackerIP pulls the first "IP Address" observable out of an OCSF-mapped
+// event's normalized data (see ocsf.Event.Observables), which is where
+// ocsf.Map surfaces a source/attacker IP.
+func attackerIP(normalizedData map[string]interface{}) (string, bool) {
+	raw, ok := normalizedData["observables"]
+	if !ok {
+		return "", false
+	}
+	observables, ok := raw.([]interface{})
+	if !ok {
+		return "", false
+	}
+	for _, o := range observables {
+		observable, ok := o.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if observable["type"] != "IP Address" {
+			continue
+		}
+		if ip, ok := observable["value"].(string); ok && ip != "" {
+			return ip, true
+		}
+	}
+	return "", false
+}
+
+// syncTicketEvents drains ticketEvents - populated by the ServiceNow
+// webhook and SyncManager's reconciliation - and applies each one to
+// correlator, so an analyst's state change or assignment inside ServiceNow
+// is reflected back onto the correlation group it was raised from.
+func syncTicketEvents(ctx context.Context, correlator *correlation.Correlator, events <-chan ticketing.TicketEvent, logger *zap.Logger) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case event := <-events:
+			groupID, ok := correlator.ResolveByEventID(event.SourceEventID, event.TicketID, event.Status)
+			if !ok {
+				logger.Debug("Ticket event did not match any correlation group",
+					zap.String("source_event_id", event.SourceEventID),
+					zap.String("ticket_id", event.TicketID),
+				)
+				continue
+			}
+			logger.Info("Correlation group updated from ticket event",
+				zap.String("correlation_id", groupID),
+				zap.String("ticket_id", event.TicketID),
+				zap.String("status", event.Status),
+			)
+		}
+	}
+}
+
+// applyRuleGroups drains ruleNotifier's NotifyC and applies each batch to
+// correlator until ctx is done. A batch that fails validation is logged
+// and discarded rather than retried, since ReplaceRules already rejected
+// it atomically - the rule set in effect is whatever last validated
+// successfully.
+func applyRuleGroups(ctx context.Context, correlator *correlation.Correlator, ruleNotifier *correlation.PeriodicRuleNotifier, logger *zap.Logger) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case groups := <-ruleNotifier.NotifyC():
+			if err := correlator.ReplaceRules(groups); err != nil {
+				logger.Warn("Correlation rule hot-reload rejected", zap.Error(err))
+			}
+		}
+	}
+}
+
+// newExportFanOut builds an export.FanOut over whichever sinks cfg enables,
+// or returns nil if none are. A sink that fails to initialize is skipped
+// with a warning rather than failing startup, matching how the ServiceNow
+// ticketing integration degrades.
+func newExportFanOut(ctx context.Context, cfg config.ExportConfig, logger *zap.Logger) *export.FanOut {
+	var sinks []export.Sink
+
+	if cfg.OTLP.Enabled {
+		otlpSink, err := export.NewOTLPSink(ctx, cfg.OTLP)
+		if err != nil {
+			logger.Warn("OTLP export sink disabled: failed to initialize", zap.Error(err))
+		} else {
+			sinks = append(sinks, otlpSink)
+		}
+	}
+
+	if cfg.AppInsights.Enabled {
+		aiSink, err := export.NewAppInsightsSink(cfg.AppInsights)
+		if err != nil {
+			logger.Warn("Application Insights export sink disabled: failed to initialize", zap.Error(err))
+		} else {
+			sinks = append(sinks, aiSink)
+		}
+	}
+
+	if cfg.Kafka.Enabled {
+		sinks = append(sinks, export.NewKafkaSink(cfg.Kafka, logger))
+	}
+
+	if len(sinks) == 0 {
+		return nil
+	}
+	return export.NewFanOut(sinks, cfg.QueueSize, logger)
+}
+
+// exportProcessedEvents drains output - Pipeline.outputChan - and fans each
+// ProcessedEvent out to every sink in fanOut until ctx is done.
+func exportProcessedEvents(ctx context.Context, output <-chan *ProcessedEvent, fanOut *export.FanOut) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case processed := <-output:
+			fanOut.Emit(toExportEvent(processed))
+		}
+	}
+}
+
+// toExportEvent maps a ProcessedEvent onto the shape export.Sink works with.
+func toExportEvent(p *ProcessedEvent) *export.Event {
+	return &export.Event{
+		ID:              p.ID,
+		Timestamp:       p.Timestamp,
+		Source:          p.Source,
+		SourceType:      p.SourceType,
+		CorrelationID:   p.CorrelationID,
+		RiskScore:       p.RiskScore,
+		RiskLevel:       p.RiskLevel,
+		MITRETactics:    p.MITRETactics,
+		MITRETechniques: p.MITRETechniques,
+	}
+}
+
+// drainDLQ logs events the normalizer couldn't map or validate into OCSF.
+// TODO: persist these somewhere queryable instead of just logging, so
+// operators can diagnose mapping gaps and reprocess once fixed.
+func drainDLQ(ctx context.Context, dlq <-chan *ocsf.DLQEntry, logger *zap.Logger) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case entry := <-dlq:
+			logger.Warn("Event rejected from OCSF normalization",
+				zap.String("source", ingestion.SourceName(entry.Raw)),
+				zap.String("event_id", entry.Raw.ID()),
+				zap.Error(entry.Err),
+			)
+		}
+	}
 }
 
 func (p *Pipeline) calculateRisk(aiAnalysis *ai.RiskAnalysis, _ *normalization.NormalizedEvent) (float64, string) {
@@ -264,15 +835,55 @@ func (p *Pipeline) calculateRisk(aiAnalysis *ai.RiskAnalysis, _ *normalization.N
 	return score, level
 }
 
-func setupRoutes(router *gin.Engine, pipeline *Pipeline, logger *zap.Logger) {
-	// Health check
+func setupRoutes(router *gin.Engine, pipeline *Pipeline, ceReceiver *ingestion.HTTPReceiver, snWebhook *ticketing.WebhookReceiver, healthChecker *observability.HealthChecker, logger *zap.Logger) {
+	// Health check. server_time lets a peer's ClusterAggregator detect
+	// clock skew when aggregating this instance's health at /health/cluster.
 	router.GET("/health", func(c *gin.Context) {
-		c.JSON(http.StatusOK, gin.H{"status": "healthy"})
+		c.JSON(http.StatusOK, gin.H{"status": "healthy", "server_time": time.Now()})
 	})
 
+	// etcd-style liveness/readiness probes: ?exclude=<check_name> (repeatable)
+	// and ?verbose=true are handled inside the wrapped handlers themselves.
+	router.GET("/livez", gin.WrapF(healthChecker.LivenessHandler()))
+	router.GET("/readyz", gin.WrapF(healthChecker.ReadinessHandler()))
+
+	// Cluster-wide health aggregation: fans out to peer instances and
+	// requires a bearer token since it reveals deployment topology.
+	router.GET("/health/cluster", gin.WrapF(healthChecker.ClusterHandler()))
+
+	// Pipeline-scoped status, e.g. GET /status/pipeline/ingestion, read
+	// from the HealthChecker's Aggregator in O(1).
+	router.GET("/status/pipeline/:name", gin.WrapF(healthChecker.PipelineStatusHandler()))
+
+	// Diagnostics: a pre-packaged bundle of DiagnoseHealthStatus findings,
+	// pipeline-metric inspection, and recent per-component logs, plus the
+	// remediation catalog those findings draw from. Both support
+	// "Accept: text/markdown" for pasting straight into an incident ticket.
+	router.GET("/diagnostics", gin.WrapF(healthChecker.DiagnosticsHandler()))
+	router.GET("/diagnostics/kb", gin.WrapF(healthChecker.DiagnosticsKBHandler()))
+
+	// Runtime profiling, so "why did this alert take 40s" can be answered
+	// with a CPU/heap profile alongside the trace, not just logs.
+	debug := router.Group("/debug/pprof")
+	{
+		debug.GET("/", gin.WrapF(pprof.Index))
+		debug.GET("/cmdline", gin.WrapF(pprof.Cmdline))
+		debug.GET("/profile", gin.WrapF(pprof.Profile))
+		debug.GET("/symbol", gin.WrapF(pprof.Symbol))
+		debug.POST("/symbol", gin.WrapF(pprof.Symbol))
+		debug.GET("/trace", gin.WrapF(pprof.Trace))
+		debug.GET("/:name", func(c *gin.Context) {
+			pprof.Handler(c.Param("name")).ServeHTTP(c.Writer, c.Request)
+		})
+	}
+
 	// API v1
 	v1 := router.Group("/api/v1")
 	{
+		// Push-mode ingestion: vendors/forwarders POST a CloudEvents v1.0
+		// message (structured or binary content mode) here.
+		v1.POST("/events", gin.WrapH(ceReceiver.Handler()))
+
 		// Events
 		v1.GET("/events", func(c *gin.Context) {
 			// TODO: Implement event listing
@@ -305,6 +916,46 @@ func setupRoutes(router *gin.Engine, pipeline *Pipeline, logger *zap.Logger) {
 			// TODO: Implement source status
 			c.JSON(http.StatusNotFound, gin.H{"error": "not implemented"})
 		})
+
+		v1.POST("/sources/:name/rewind", func(c *gin.Context) {
+			var req struct {
+				To time.Time `json:"to" binding:"required"`
+			}
+			if err := c.ShouldBindJSON(&req); err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+				return
+			}
+
+			if err := pipeline.ingestion.Rewind(c.Request.Context(), c.Param("name"), req.To); err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+				return
+			}
+
+			c.JSON(http.StatusOK, gin.H{"status": "rewound"})
+		})
+
+		// Inbound ServiceNow Business Rule POSTs reporting a ticket
+		// change, only mounted when the ServiceNow integration is enabled.
+		if snWebhook != nil {
+			v1.POST("/ticketing/servicenow/webhook", gin.WrapH(snWebhook.Handler()))
+		}
+
+		// Correlation rule groups, as currently hot-reloaded from
+		// cfg.Correlation.RulesDir.
+		v1.GET("/rules", func(c *gin.Context) {
+			groups := pipeline.correlator.LoadedRuleGroups()
+			resp := make([]gin.H, 0, len(groups))
+			for _, g := range groups {
+				resp = append(resp, gin.H{
+					"name":      g.Name,
+					"source":    g.Source,
+					"hash":      g.Hash,
+					"rules":     len(g.Rules),
+					"loaded_at": g.LoadedAt,
+				})
+			}
+			c.JSON(http.StatusOK, gin.H{"rule_groups": resp})
+		})
 	}
 
 	logger.Info("API routes configured")
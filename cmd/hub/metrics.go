@@ -0,0 +1,36 @@
+package main
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	eventsDroppedTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "threat_telemetry_hub",
+			Subsystem: "pipeline",
+			Name:      "events_dropped_total",
+			Help:      "Total events discarded by the pipeline's queue drop policy, by policy.",
+		},
+		[]string{"policy"}, // drop-oldest, drop-new
+	)
+
+	queueDepth = promauto.NewGauge(
+		prometheus.GaugeOpts{
+			Namespace: "threat_telemetry_hub",
+			Subsystem: "pipeline",
+			Name:      "queue_depth",
+			Help:      "Number of events currently buffered between ingestion and the worker pool.",
+		},
+	)
+
+	workerBusy = promauto.NewGauge(
+		prometheus.GaugeOpts{
+			Namespace: "threat_telemetry_hub",
+			Subsystem: "pipeline",
+			Name:      "worker_busy",
+			Help:      "Number of worker goroutines currently processing an event.",
+		},
+	)
+)
@@ -0,0 +1,26 @@
+package main
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	"github.com/lvonguyen/threat-telemetry-hub/internal/safe"
+)
+
+// jsonRecovery replaces gin.Recovery(): instead of gin's default plain-text
+// panic response, it returns JSON consistent with every other handler's
+// error responses, and routes the recovered panic through the same
+// logging/metrics as internal/safe's goroutine wrappers.
+func jsonRecovery(logger *zap.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		defer func() {
+			if r := recover(); r != nil {
+				safe.RecordPanic(logger, "http."+c.FullPath(), r)
+				c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+			}
+		}()
+		c.Next()
+	}
+}
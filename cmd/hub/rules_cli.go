@@ -0,0 +1,168 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/lvonguyen/threat-telemetry-hub/internal/config"
+	"github.com/lvonguyen/threat-telemetry-hub/internal/detection"
+	"github.com/lvonguyen/threat-telemetry-hub/internal/normalization"
+)
+
+// runRulesCLI implements `tth rules <subcommand>`, a cscli-style local tool
+// for managing detection rules: list what's loaded, validate a rule file
+// compiles, dry-run a rule against a sample event, install a rule pack from
+// a git URL, or disable a rule without deleting it.
+func runRulesCLI(args []string) int {
+	if len(args) == 0 {
+		fmt.Fprintln(os.Stderr, "usage: tth rules <list|validate|test|install|disable> [args...]")
+		return 2
+	}
+
+	cfg, err := config.Load("configs/config.yaml")
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "loading config: %v\n", err)
+		return 1
+	}
+
+	switch args[0] {
+	case "list":
+		return rulesList(cfg.Detection)
+	case "validate":
+		return rulesValidate(args[1:])
+	case "test":
+		return rulesTest(args[1:])
+	case "install":
+		return rulesInstall(cfg.Detection, args[1:])
+	case "disable":
+		return rulesDisable(cfg.Detection, args[1:])
+	default:
+		fmt.Fprintf(os.Stderr, "unknown rules subcommand %q\n", args[0])
+		return 2
+	}
+}
+
+func rulesList(cfg config.DetectionConfig) int {
+	for _, dir := range []string{cfg.SigmaRulesDir, cfg.CELRulesDir} {
+		entries, _ := os.ReadDir(dir)
+		for _, entry := range entries {
+			if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".yml") && !strings.HasSuffix(entry.Name(), ".yaml") {
+				continue
+			}
+			fmt.Println(filepath.Join(dir, entry.Name()))
+		}
+	}
+	return 0
+}
+
+func rulesValidate(args []string) int {
+	if len(args) != 1 {
+		fmt.Fprintln(os.Stderr, "usage: tth rules validate <rule-file>")
+		return 2
+	}
+	data, err := os.ReadFile(args[0])
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "reading rule file: %v\n", err)
+		return 1
+	}
+
+	var compileErr error
+	if strings.Contains(string(data), "detection:") {
+		_, compileErr = detection.CompileSigmaRule(data)
+	} else {
+		_, compileErr = detection.CompileCELRule(data)
+	}
+	if compileErr != nil {
+		fmt.Fprintf(os.Stderr, "invalid: %v\n", compileErr)
+		return 1
+	}
+	fmt.Println("OK")
+	return 0
+}
+
+// rulesTest dry-runs a single rule against a sample NormalizedEvent JSON
+// file, printing whether it matched and which fields drove the match.
+func rulesTest(args []string) int {
+	if len(args) != 2 {
+		fmt.Fprintln(os.Stderr, "usage: tth rules test <rule-file> <sample-event.json>")
+		return 2
+	}
+
+	ruleData, err := os.ReadFile(args[0])
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "reading rule file: %v\n", err)
+		return 1
+	}
+	eventData, err := os.ReadFile(args[1])
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "reading sample event: %v\n", err)
+		return 1
+	}
+
+	var event normalization.NormalizedEvent
+	if err := json.Unmarshal(eventData, &event); err != nil {
+		fmt.Fprintf(os.Stderr, "parsing sample event: %v\n", err)
+		return 1
+	}
+
+	var rule detection.Rule
+	if strings.Contains(string(ruleData), "detection:") {
+		rule, err = detection.CompileSigmaRule(ruleData)
+	} else {
+		rule, err = detection.CompileCELRule(ruleData)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "compiling rule: %v\n", err)
+		return 1
+	}
+
+	matched, fields := rule.Evaluate(&event, nil)
+	if !matched {
+		fmt.Println("no match")
+		return 0
+	}
+	fmt.Printf("match: %s (%s)\n", rule.Name(), rule.ID())
+	for k, v := range fields {
+		fmt.Printf("  %s = %v\n", k, v)
+	}
+	return 0
+}
+
+// rulesInstall clones a git-hosted rule pack into the configured Sigma
+// rules directory, mirroring CrowdSec's hub-install ergonomics.
+func rulesInstall(cfg config.DetectionConfig, args []string) int {
+	if len(args) != 1 {
+		fmt.Fprintln(os.Stderr, "usage: tth rules install <git-url>")
+		return 2
+	}
+	dest := filepath.Join(cfg.SigmaRulesDir, strings.TrimSuffix(filepath.Base(args[0]), ".git"))
+	cmd := exec.Command("git", "clone", "--depth", "1", args[0], dest)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		fmt.Fprintf(os.Stderr, "cloning rule pack: %v\n", err)
+		return 1
+	}
+	fmt.Printf("installed to %s\n", dest)
+	return 0
+}
+
+// rulesDisable marks a rule inactive without deleting it, by appending a
+// ".disabled" suffix so the engine's loader skips it. Pass the path to the
+// rule file (not just its ID) since the engine doesn't keep a path index.
+func rulesDisable(cfg config.DetectionConfig, args []string) int {
+	if len(args) != 1 {
+		fmt.Fprintln(os.Stderr, "usage: tth rules disable <rule-file>")
+		return 2
+	}
+	if err := os.Rename(args[0], args[0]+".disabled"); err != nil {
+		fmt.Fprintf(os.Stderr, "disabling rule: %v\n", err)
+		return 1
+	}
+	fmt.Printf("disabled %s\n", args[0])
+	return 0
+}
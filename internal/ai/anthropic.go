@@ -2,12 +2,14 @@
 package ai
 
 import (
+	"bufio"
 	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
 	"os"
+	"strings"
 	"time"
 
 	"go.uber.org/zap"
@@ -50,6 +52,7 @@ func (p *AnthropicProvider) Analyze(ctx context.Context, prompt string) (*Respon
 	requestBody := map[string]interface{}{
 		"model":      p.model,
 		"max_tokens": 4096,
+		"system":     systemPrompt,
 		"messages": []map[string]string{
 			{
 				"role":    "user",
@@ -111,3 +114,117 @@ func (p *AnthropicProvider) Analyze(ctx context.Context, prompt string) (*Respon
 	}, nil
 }
 
+// AnalyzeStream performs AI analysis using Claude's streaming API, emitting
+// each text delta as it arrives so a caller can start parsing the JSON
+// payload before the response is complete. It implements StreamingProvider.
+func (p *AnthropicProvider) AnalyzeStream(ctx context.Context, prompt string) (<-chan ResponseChunk, error) {
+	requestBody := map[string]interface{}{
+		"model":      p.model,
+		"max_tokens": 4096,
+		"stream":     true,
+		"messages": []map[string]string{
+			{
+				"role":    "user",
+				"content": prompt,
+			},
+		},
+	}
+
+	jsonBody, err := json.Marshal(requestBody)
+	if err != nil {
+		return nil, fmt.Errorf("marshaling request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", "https://api.anthropic.com/v1/messages", bytes.NewBuffer(jsonBody))
+	if err != nil {
+		return nil, fmt.Errorf("creating request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("x-api-key", p.apiKey)
+	req.Header.Set("anthropic-version", "2023-06-01")
+	req.Header.Set("Accept", "text/event-stream")
+
+	chunks := make(chan ResponseChunk)
+
+	go func() {
+		defer close(chunks)
+
+		resp, err := p.httpClient.Do(req)
+		if err != nil {
+			sendChunk(ctx, chunks, ResponseChunk{Done: true, Err: fmt.Errorf("making request: %w", err)})
+			return
+		}
+		defer resp.Body.Close()
+
+		if resp.StatusCode != http.StatusOK {
+			sendChunk(ctx, chunks, ResponseChunk{Done: true, Err: fmt.Errorf("API returned status %d", resp.StatusCode)})
+			return
+		}
+
+		var usage Usage
+		start := time.Now()
+		lastTokenAt := start
+		firstToken := true
+
+		scanner := bufio.NewScanner(resp.Body)
+		for scanner.Scan() {
+			line := strings.TrimSpace(scanner.Text())
+			data, ok := strings.CutPrefix(line, "data: ")
+			if !ok {
+				continue
+			}
+
+			var event struct {
+				Type  string `json:"type"`
+				Delta struct {
+					Text         string `json:"text"`
+					OutputTokens int    `json:"output_tokens"`
+				} `json:"delta"`
+				Message struct {
+					Usage struct {
+						InputTokens int `json:"input_tokens"`
+					} `json:"usage"`
+				} `json:"message"`
+				Usage struct {
+					OutputTokens int `json:"output_tokens"`
+				} `json:"usage"`
+			}
+			if err := json.Unmarshal([]byte(data), &event); err != nil {
+				continue
+			}
+
+			switch event.Type {
+			case "message_start":
+				usage.InputTokens = event.Message.Usage.InputTokens
+			case "message_delta":
+				usage.OutputTokens = event.Usage.OutputTokens
+			case "content_block_delta":
+				if event.Delta.Text == "" {
+					continue
+				}
+
+				now := time.Now()
+				if firstToken {
+					timeToFirstTokenSeconds.WithLabelValues(p.Name()).Observe(now.Sub(start).Seconds())
+					firstToken = false
+				} else {
+					interTokenLatencySeconds.WithLabelValues(p.Name()).Observe(now.Sub(lastTokenAt).Seconds())
+				}
+				lastTokenAt = now
+
+				if !sendChunk(ctx, chunks, ResponseChunk{Text: event.Delta.Text}) {
+					return
+				}
+			}
+		}
+		if err := scanner.Err(); err != nil {
+			sendChunk(ctx, chunks, ResponseChunk{Done: true, Err: fmt.Errorf("reading stream: %w", err)})
+			return
+		}
+
+		sendChunk(ctx, chunks, ResponseChunk{Done: true, InputTokens: usage.InputTokens, OutputTokens: usage.OutputTokens})
+	}()
+
+	return chunks, nil
+}
+
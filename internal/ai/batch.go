@@ -0,0 +1,332 @@
+package ai
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+
+	"github.com/lvonguyen/threat-telemetry-hub/internal/config"
+	"github.com/lvonguyen/threat-telemetry-hub/internal/ingestion"
+	"github.com/lvonguyen/threat-telemetry-hub/internal/observability"
+	"github.com/lvonguyen/threat-telemetry-hub/internal/ocsf"
+)
+
+const (
+	circuitBreakerThreshold = 3
+	circuitBreakerCooldown  = 30 * time.Second
+	avgCharsPerToken        = 4 // rough estimate, good enough for batching/budgeting
+	responseTokenAllowance  = 512
+)
+
+// BatchAnalyzer wraps a fallback chain of Providers (anthropic, then
+// openai, then a local rule-based analysis) with what a high-volume
+// telemetry pipeline needs on top of a single blocking request/response
+// call: clustering similarly-shaped events into one provider call, a
+// global tokens-per-minute budget, an LRU cache of recent results, and a
+// circuit breaker per provider so a struggling one is skipped instead of
+// stalling every analysis behind its timeout. It implements RiskAnalyzer.
+type BatchAnalyzer struct {
+	cfg    config.AIBatchConfig
+	logger *zap.Logger
+
+	providers []Provider
+	breakers  []*circuitBreaker
+
+	budget   *tokenBucket
+	cache    *lruCache
+	redactor *Redactor
+
+	mu       sync.Mutex
+	clusters map[string]*batchCluster
+}
+
+// batchCluster accumulates events that share a cluster key until
+// MaxLatency elapses or the estimated token count reaches MaxTokens,
+// whichever comes first, then a single analysis is run on the cluster's
+// representative event on behalf of every waiter.
+type batchCluster struct {
+	representative *ingestion.RawEvent
+	estTokens      int
+	waiters        []chan batchResult
+	timer          *time.Timer
+}
+
+type batchResult struct {
+	analysis *RiskAnalysis
+	err      error
+}
+
+// NewBatchAnalyzer builds the fallback chain (anthropic, then openai,
+// whichever are configured) plus the batching/caching/rate-limiting layer
+// in front of it.
+func NewBatchAnalyzer(cfg config.AIConfig, logger *zap.Logger) (*BatchAnalyzer, error) {
+	var providers []Provider
+
+	if p, err := NewAnthropicProvider(cfg.Anthropic, logger); err == nil {
+		providers = append(providers, p)
+	} else {
+		logger.Warn("Anthropic provider unavailable, excluding from fallback chain", zap.Error(err))
+	}
+	if p, err := NewOpenAIProvider(cfg.OpenAI, logger); err == nil {
+		providers = append(providers, p)
+	} else {
+		logger.Warn("OpenAI provider unavailable, excluding from fallback chain", zap.Error(err))
+	}
+
+	breakers := make([]*circuitBreaker, len(providers))
+	for i := range providers {
+		breakers[i] = newCircuitBreaker(circuitBreakerThreshold, circuitBreakerCooldown)
+	}
+
+	logger.Info("AI batch analyzer initialized",
+		zap.Int("providers", len(providers)),
+		zap.Int("max_latency_ms", cfg.Batch.MaxLatencyMS),
+		zap.Int("max_tokens", cfg.Batch.MaxTokens),
+		zap.Int("tokens_per_minute", cfg.Batch.TokensPerMinute),
+	)
+
+	var redactor *Redactor
+	if cfg.Redaction.Enabled {
+		redactor = NewRedactor()
+	}
+
+	return &BatchAnalyzer{
+		cfg:       cfg.Batch,
+		logger:    logger,
+		providers: providers,
+		breakers:  breakers,
+		budget:    newTokenBucket(cfg.Batch.TokensPerMinute),
+		cache:     newLRUCache(cfg.Batch.CacheSize),
+		redactor:  redactor,
+		clusters:  make(map[string]*batchCluster),
+	}, nil
+}
+
+// AnalyzeRawEvent implements RiskAnalyzer. It joins event onto the batch
+// for its cluster key (starting a new one if needed), waits for that
+// cluster to flush, and returns the resulting analysis. A cache hit on the
+// event's own prompt skips batching entirely.
+func (b *BatchAnalyzer) AnalyzeRawEvent(ctx context.Context, event *ingestion.RawEvent) (*RiskAnalysis, error) {
+	ctx, span := startAnalyzeSpan(ctx, event, "batch")
+	defer span.End()
+
+	prompt := buildPrompt(event, 1)
+	promptHash := hashPrompt(prompt)
+
+	if cached, ok := b.cache.Get(promptHash); ok {
+		cacheLookupsTotal.WithLabelValues("batch", "hit").Inc()
+		return cached, nil
+	}
+	cacheLookupsTotal.WithLabelValues("batch", "miss").Inc()
+
+	key := clusterKey(event)
+	estTokens := len(prompt) / avgCharsPerToken
+	wait := make(chan batchResult, 1)
+
+	b.mu.Lock()
+	cluster, ok := b.clusters[key]
+	if !ok {
+		cluster = &batchCluster{representative: event}
+		b.clusters[key] = cluster
+		cluster.timer = time.AfterFunc(time.Duration(b.cfg.MaxLatencyMS)*time.Millisecond, func() {
+			b.flush(key)
+		})
+	}
+	cluster.waiters = append(cluster.waiters, wait)
+	cluster.estTokens += estTokens
+	flushNow := cluster.estTokens >= b.cfg.MaxTokens
+	b.mu.Unlock()
+
+	if flushNow {
+		cluster.timer.Stop()
+		b.flush(key)
+	}
+
+	select {
+	case result := <-wait:
+		if result.err != nil {
+			span.RecordError(result.err)
+			return nil, result.err
+		}
+		b.cache.Put(promptHash, result.analysis)
+		return result.analysis, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// flush runs one analysis on behalf of every waiter queued under key. A
+// cluster can only flush once - whichever trigger (timer or MaxTokens)
+// gets there first removes it, so the other is a no-op.
+func (b *BatchAnalyzer) flush(key string) {
+	b.mu.Lock()
+	cluster, ok := b.clusters[key]
+	if ok {
+		delete(b.clusters, key)
+	}
+	b.mu.Unlock()
+	if !ok {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+	defer cancel()
+
+	analysis, err := b.analyzeWithFallback(ctx, cluster.representative, len(cluster.waiters))
+
+	result := batchResult{analysis: analysis, err: err}
+	for _, waiter := range cluster.waiters {
+		waiter <- result
+	}
+}
+
+// analyzeWithFallback tries each provider in order, skipping any whose
+// circuit breaker is currently open, and falls back to a local rule-based
+// analysis if every provider fails or none are configured.
+func (b *BatchAnalyzer) analyzeWithFallback(ctx context.Context, event *ingestion.RawEvent, batchSize int) (*RiskAnalysis, error) {
+	// flush runs on a detached context shared by every waiter in the
+	// cluster, so rejoin the representative event's own trace (the one
+	// CreateRawEvent started for it) rather than inheriting whichever
+	// caller happened to trigger this flush.
+	ctx = ingestion.ContextWithTrace(ctx, event)
+
+	prompt := buildPrompt(event, batchSize)
+	if b.redactor != nil {
+		prompt = b.redactor.Redact(prompt)
+	}
+	estTokens := len(prompt)/avgCharsPerToken + responseTokenAllowance
+
+	if err := b.budget.Wait(ctx, estTokens); err != nil {
+		return nil, fmt.Errorf("waiting for AI token budget: %w", err)
+	}
+
+	var lastErr error
+	for i, provider := range b.providers {
+		breaker := b.breakers[i]
+		if !breaker.Allow() {
+			continue
+		}
+
+		attemptCtx, span := otel.Tracer(tracerName).Start(ctx, "ai.provider_attempt", trace.WithAttributes(
+			attribute.String(observability.KeyEventID, event.ID()),
+			attribute.String(observability.KeyAIProvider, provider.Name()),
+		))
+
+		start := time.Now()
+		response, err := provider.Analyze(attemptCtx, prompt)
+		providerLatencySeconds.WithLabelValues(provider.Name()).Observe(time.Since(start).Seconds())
+
+		if err != nil {
+			breaker.RecordFailure()
+			span.RecordError(err)
+			span.End()
+			lastErr = err
+			b.logger.Warn("AI provider failed, trying next in fallback chain",
+				zap.String("provider", provider.Name()),
+				zap.Error(err),
+			)
+			continue
+		}
+		breaker.RecordSuccess()
+
+		span.SetAttributes(
+			attribute.Int(observability.KeyAITokensInput, response.Usage.InputTokens),
+			attribute.Int(observability.KeyAITokensOutput, response.Usage.OutputTokens),
+		)
+		span.End()
+
+		tokensConsumedTotal.WithLabelValues(provider.Name(), "input").Add(float64(response.Usage.InputTokens))
+		tokensConsumedTotal.WithLabelValues(provider.Name(), "output").Add(float64(response.Usage.OutputTokens))
+
+		var analysis RiskAnalysis
+		if err := json.Unmarshal([]byte(response.Content), &analysis); err != nil {
+			lastErr = fmt.Errorf("parsing response from %s: %w", provider.Name(), err)
+			b.logger.Warn("Failed to parse AI response, trying next in fallback chain",
+				zap.String("provider", provider.Name()),
+				zap.Error(err),
+			)
+			continue
+		}
+		return &analysis, nil
+	}
+
+	b.logger.Warn("All AI providers unavailable, using local rule-based fallback",
+		zap.Error(lastErr),
+		zap.String("event_id", event.ID()),
+	)
+	return localRuleBasedAnalysis(event), nil
+}
+
+// localRuleBasedAnalysis produces a risk assessment without calling any AI
+// provider, scoring from the event's OCSF severity when it can be mapped.
+// It's the last link in the fallback chain, keeping the pipeline moving
+// when every configured provider is down.
+func localRuleBasedAnalysis(event *ingestion.RawEvent) *RiskAnalysis {
+	oe, err := ocsf.Map(event)
+	if err != nil {
+		return &RiskAnalysis{
+			RiskScore:       0.5,
+			RiskLevel:       "medium",
+			Summary:         fmt.Sprintf("Event from %s requires manual review (AI providers unavailable)", ingestion.SourceName(event)),
+			Recommendations: []string{"Review event manually", "Check for related events"},
+			RawContext:      "AI analysis unavailable - local rule-based fallback applied",
+		}
+	}
+
+	score, level := riskFromSeverity(oe.SeverityID)
+	return &RiskAnalysis{
+		RiskScore:       score,
+		RiskLevel:       level,
+		Summary:         fmt.Sprintf("%s event from %s (OCSF severity %d), AI providers unavailable", oe.Metadata.Product.Name, ingestion.SourceName(event), oe.SeverityID),
+		Recommendations: []string{"Review event manually", "Check for related events"},
+		RawContext:      "AI analysis unavailable - local rule-based fallback applied",
+	}
+}
+
+// riskFromSeverity maps an OCSF severity_id (0-6) onto the same RiskScore/
+// RiskLevel scale the AI providers return.
+func riskFromSeverity(severityID int) (float64, string) {
+	switch {
+	case severityID >= 6:
+		return 0.95, "critical"
+	case severityID == 5:
+		return 0.8, "high"
+	case severityID == 4, severityID == 3:
+		return 0.5, "medium"
+	case severityID == 1, severityID == 2:
+		return 0.2, "low"
+	default:
+		return 0.5, "medium"
+	}
+}
+
+// clusterKey groups events likely to produce the same analysis: same
+// source, same source type, and the same set of data field names (a cheap
+// stand-in for "same shape" that ignores field values).
+func clusterKey(event *ingestion.RawEvent) string {
+	data := ingestion.DataMap(event)
+	fieldNames := make([]string, 0, len(data))
+	for k := range data {
+		fieldNames = append(fieldNames, k)
+	}
+	sort.Strings(fieldNames)
+
+	h := sha256.Sum256([]byte(ingestion.SourceName(event) + "|" + ingestion.SourceType(event) + "|" + strings.Join(fieldNames, ",")))
+	return hex.EncodeToString(h[:])
+}
+
+func hashPrompt(prompt string) string {
+	h := sha256.Sum256([]byte(prompt))
+	return hex.EncodeToString(h[:])
+}
@@ -0,0 +1,52 @@
+package ai
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/lvonguyen/threat-telemetry-hub/internal/config"
+)
+
+// Cache memoizes provider Responses, so the same (or near-identical)
+// telemetry re-analyzed within its TTL is served without spending another
+// round-trip and another set of tokens on it. Unlike BatchAnalyzer's
+// internal lruCache - which caches a parsed RiskAnalysis keyed by the hash
+// of an event's own prompt - Cache sits in front of Analyzer's single-event path
+// and is keyed to one exact (model, sanitized prompt) pair.
+type Cache interface {
+	// Get returns the cached Response for key, if present and unexpired.
+	Get(ctx context.Context, key string) (*Response, bool)
+	// Set stores resp under key for ttl.
+	Set(ctx context.Context, key string, resp *Response, ttl time.Duration)
+	// Close releases any resources held by the backend.
+	Close() error
+}
+
+// CacheKey returns the cache key for a (provider model, sanitized prompt)
+// pair: a SHA-256 hash, so the key itself never carries the prompt's
+// (potentially sensitive) telemetry content.
+func CacheKey(model, sanitizedPrompt string) string {
+	h := sha256.Sum256([]byte(model + "\x00" + sanitizedPrompt))
+	return hex.EncodeToString(h[:])
+}
+
+// NewCache builds the Cache backend selected by cfg.Backend.
+func NewCache(cfg config.AICacheConfig, logger *zap.Logger) (Cache, error) {
+	switch cfg.Backend {
+	case "", "memory":
+		maxEntries := cfg.MaxEntries
+		if maxEntries <= 0 {
+			maxEntries = 1000
+		}
+		return newMemoryCache(maxEntries), nil
+	case "redis":
+		return nil, fmt.Errorf("ai: redis cache backend not yet implemented")
+	default:
+		return nil, fmt.Errorf("ai: unsupported cache backend %q", cfg.Backend)
+	}
+}
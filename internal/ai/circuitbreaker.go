@@ -0,0 +1,57 @@
+package ai
+
+import (
+	"sync"
+	"time"
+)
+
+// circuitBreaker trips after a run of consecutive provider failures and
+// stays open for cooldown before letting a single trial request through,
+// so a struggling provider is skipped in the fallback chain instead of
+// adding its full timeout to every analysis.
+type circuitBreaker struct {
+	threshold int
+	cooldown  time.Duration
+
+	mu       sync.Mutex
+	failures int
+	open     bool
+	openedAt time.Time
+}
+
+func newCircuitBreaker(threshold int, cooldown time.Duration) *circuitBreaker {
+	return &circuitBreaker{threshold: threshold, cooldown: cooldown}
+}
+
+// Allow reports whether a call should be attempted right now.
+func (b *circuitBreaker) Allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if !b.open {
+		return true
+	}
+	if time.Since(b.openedAt) < b.cooldown {
+		return false
+	}
+	// half-open: let this one trial call through and hold every other
+	// caller off for another cooldown unless it reports success.
+	b.openedAt = time.Now()
+	return true
+}
+
+func (b *circuitBreaker) RecordSuccess() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.failures = 0
+	b.open = false
+}
+
+func (b *circuitBreaker) RecordFailure() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.failures++
+	if b.failures >= b.threshold {
+		b.open = true
+		b.openedAt = time.Now()
+	}
+}
@@ -0,0 +1,83 @@
+package guard
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+)
+
+// validRiskLevels are the only risk_level values an analysis response may
+// use, matching the strings internal/ai.RiskAnalysis.RiskLevel expects.
+var validRiskLevels = map[string]bool{
+	"critical": true,
+	"high":     true,
+	"medium":   true,
+	"low":      true,
+	"info":     true,
+}
+
+// mitreTechniquePattern matches a MITRE ATT&CK technique ID, optionally
+// with a sub-technique suffix, e.g. "T1059" or "T1059.001".
+var mitreTechniquePattern = regexp.MustCompile(`^T\d{4}(\.\d{3})?$`)
+
+const (
+	maxTextFieldLen = 2000 // summary, raw_context
+	maxListItemLen  = 500  // each indicator/recommendation entry
+)
+
+// schemaResponse is the subset of an AI analysis response ValidateResponse
+// checks. It deliberately mirrors ai.RiskAnalysis's JSON shape rather than
+// importing it, so this package stays a leaf internal/ai can depend on
+// without a cycle.
+type schemaResponse struct {
+	RiskScore       float64  `json:"risk_score"`
+	RiskLevel       string   `json:"risk_level"`
+	Summary         string   `json:"summary"`
+	Indicators      []string `json:"indicators"`
+	MITRETechniques []string `json:"mitre_techniques"`
+	Recommendations []string `json:"recommendations"`
+	RawContext      string   `json:"raw_context"`
+}
+
+// ValidateResponse parses content and rejects it unless it satisfies the
+// schema an AI analysis response must meet: risk_score in [0,1] (this
+// repo's RiskAnalysis scale), a recognized risk_level, every
+// mitre_techniques entry matching T####(.###), and every string field
+// within a sane bound. A non-nil error means content should be discarded
+// - and, while attempts remain, the provider re-prompted - rather than
+// parsed and trusted.
+func ValidateResponse(content string) error {
+	var resp schemaResponse
+	if err := json.Unmarshal([]byte(content), &resp); err != nil {
+		return fmt.Errorf("guard: response is not valid JSON: %w", err)
+	}
+
+	if resp.RiskScore < 0 || resp.RiskScore > 1 {
+		return fmt.Errorf("guard: risk_score %v out of range [0,1]", resp.RiskScore)
+	}
+	if !validRiskLevels[resp.RiskLevel] {
+		return fmt.Errorf("guard: risk_level %q is not a recognized level", resp.RiskLevel)
+	}
+	if len(resp.Summary) > maxTextFieldLen {
+		return fmt.Errorf("guard: summary exceeds %d characters", maxTextFieldLen)
+	}
+	if len(resp.RawContext) > maxTextFieldLen {
+		return fmt.Errorf("guard: raw_context exceeds %d characters", maxTextFieldLen)
+	}
+	for _, t := range resp.MITRETechniques {
+		if !mitreTechniquePattern.MatchString(t) {
+			return fmt.Errorf("guard: mitre_techniques value %q does not match T####(.###)", t)
+		}
+	}
+	for _, indicator := range resp.Indicators {
+		if len(indicator) > maxListItemLen {
+			return fmt.Errorf("guard: indicator exceeds %d characters", maxListItemLen)
+		}
+	}
+	for _, rec := range resp.Recommendations {
+		if len(rec) > maxListItemLen {
+			return fmt.Errorf("guard: recommendation exceeds %d characters", maxListItemLen)
+		}
+	}
+	return nil
+}
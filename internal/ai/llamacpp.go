@@ -0,0 +1,203 @@
+// Package ai provides AI-powered analysis for security telemetry
+package ai
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/lvonguyen/threat-telemetry-hub/internal/config"
+)
+
+// llamaCppMaxTokens bounds a single completion, same allowance the hosted
+// providers use via their own max_tokens parameter.
+const llamaCppMaxTokens = 4096
+
+// LlamaCppProvider implements the Provider interface against a local
+// llama.cpp server (llama-server), using its native /completion endpoint
+// rather than its OpenAI-compatibility layer, so it keeps working against
+// older server builds that predate that layer.
+type LlamaCppProvider struct {
+	baseURL    string
+	model      string
+	httpClient *http.Client
+	logger     *zap.Logger
+}
+
+// NewLlamaCppProvider creates a new llama.cpp server provider.
+func NewLlamaCppProvider(cfg config.LlamaCppConfig, logger *zap.Logger) (*LlamaCppProvider, error) {
+	if cfg.BaseURL == "" {
+		return nil, fmt.Errorf("missing required llama.cpp configuration")
+	}
+
+	return &LlamaCppProvider{
+		baseURL: cfg.BaseURL,
+		model:   cfg.Model,
+		httpClient: &http.Client{
+			Timeout: 120 * time.Second,
+		},
+		logger: logger,
+	}, nil
+}
+
+// Name returns the provider name
+func (p *LlamaCppProvider) Name() string { return "llamacpp" }
+
+// llamaCppPrompt wraps prompt in a minimal instruction template; the
+// /completion endpoint takes raw text rather than a chat messages array,
+// so systemPrompt has to lead the whole text instead of riding in its own
+// system-role message the way it does for every other provider.
+func llamaCppPrompt(prompt string) string {
+	return fmt.Sprintf("%s\n\n%s\n\nRespond with JSON only.", systemPrompt, prompt)
+}
+
+func (p *LlamaCppProvider) completionRequestBody(prompt string, stream bool) ([]byte, error) {
+	return json.Marshal(map[string]interface{}{
+		"prompt":    llamaCppPrompt(prompt),
+		"n_predict": llamaCppMaxTokens,
+		"stream":    stream,
+	})
+}
+
+// Analyze performs AI analysis using a local llama.cpp server's native
+// /completion endpoint.
+func (p *LlamaCppProvider) Analyze(ctx context.Context, prompt string) (*Response, error) {
+	jsonBody, err := p.completionRequestBody(prompt, false)
+	if err != nil {
+		return nil, fmt.Errorf("marshaling request: %w", err)
+	}
+
+	resp, err := withRetry(ctx, defaultRetry, func() (*http.Response, error) {
+		req, err := http.NewRequestWithContext(ctx, "POST", p.baseURL+"/completion", bytes.NewReader(jsonBody))
+		if err != nil {
+			return nil, fmt.Errorf("creating request: %w", err)
+		}
+		req.Header.Set("Content-Type", "application/json")
+		return p.httpClient.Do(req)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("making request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("API returned status %d", resp.StatusCode)
+	}
+
+	var result struct {
+		Content         string `json:"content"`
+		TokensEvaluated int    `json:"tokens_evaluated"`
+		TokensPredicted int    `json:"tokens_predicted"`
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("decoding response: %w", err)
+	}
+
+	return &Response{
+		Content: result.Content,
+		Model:   p.model,
+		Usage: Usage{
+			InputTokens:  result.TokensEvaluated,
+			OutputTokens: result.TokensPredicted,
+		},
+	}, nil
+}
+
+// AnalyzeStream performs AI analysis using llama.cpp's streaming
+// /completion endpoint, which sends server-sent events carrying llama.cpp's
+// own {content, stop} objects (not OpenAI's choices/delta shape). It
+// implements StreamingProvider.
+func (p *LlamaCppProvider) AnalyzeStream(ctx context.Context, prompt string) (<-chan ResponseChunk, error) {
+	jsonBody, err := p.completionRequestBody(prompt, true)
+	if err != nil {
+		return nil, fmt.Errorf("marshaling request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", p.baseURL+"/completion", bytes.NewReader(jsonBody))
+	if err != nil {
+		return nil, fmt.Errorf("creating request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "text/event-stream")
+
+	chunks := make(chan ResponseChunk)
+
+	go func() {
+		defer close(chunks)
+
+		resp, err := p.httpClient.Do(req)
+		if err != nil {
+			sendChunk(ctx, chunks, ResponseChunk{Done: true, Err: fmt.Errorf("making request: %w", err)})
+			return
+		}
+		defer resp.Body.Close()
+
+		if resp.StatusCode != http.StatusOK {
+			sendChunk(ctx, chunks, ResponseChunk{Done: true, Err: fmt.Errorf("API returned status %d", resp.StatusCode)})
+			return
+		}
+
+		var usage Usage
+		start := time.Now()
+		lastTokenAt := start
+		firstToken := true
+
+		scanner := bufio.NewScanner(resp.Body)
+		for scanner.Scan() {
+			line := strings.TrimSpace(scanner.Text())
+			data, ok := strings.CutPrefix(line, "data: ")
+			if !ok {
+				continue
+			}
+
+			var event struct {
+				Content         string `json:"content"`
+				Stop            bool   `json:"stop"`
+				TokensEvaluated int    `json:"tokens_evaluated"`
+				TokensPredicted int    `json:"tokens_predicted"`
+			}
+			if err := json.Unmarshal([]byte(data), &event); err != nil {
+				continue
+			}
+
+			if event.Stop {
+				usage.InputTokens = event.TokensEvaluated
+				usage.OutputTokens = event.TokensPredicted
+				break
+			}
+
+			if event.Content == "" {
+				continue
+			}
+
+			now := time.Now()
+			if firstToken {
+				timeToFirstTokenSeconds.WithLabelValues(p.Name()).Observe(now.Sub(start).Seconds())
+				firstToken = false
+			} else {
+				interTokenLatencySeconds.WithLabelValues(p.Name()).Observe(now.Sub(lastTokenAt).Seconds())
+			}
+			lastTokenAt = now
+
+			if !sendChunk(ctx, chunks, ResponseChunk{Text: event.Content}) {
+				return
+			}
+		}
+		if err := scanner.Err(); err != nil {
+			sendChunk(ctx, chunks, ResponseChunk{Done: true, Err: fmt.Errorf("reading stream: %w", err)})
+			return
+		}
+
+		sendChunk(ctx, chunks, ResponseChunk{Done: true, InputTokens: usage.InputTokens, OutputTokens: usage.OutputTokens})
+	}()
+
+	return chunks, nil
+}
@@ -0,0 +1,62 @@
+package ai
+
+import (
+	"container/list"
+	"sync"
+)
+
+// lruCache is a fixed-capacity cache from prompt hash to RiskAnalysis, so
+// repeated or near-identical events (e.g. a noisy scheduled task) skip the
+// model entirely instead of paying for a fresh analysis every time.
+type lruCache struct {
+	capacity int
+
+	mu    sync.Mutex
+	items map[string]*list.Element
+	order *list.List
+}
+
+type lruEntry struct {
+	key   string
+	value *RiskAnalysis
+}
+
+func newLRUCache(capacity int) *lruCache {
+	return &lruCache{
+		capacity: capacity,
+		items:    make(map[string]*list.Element),
+		order:    list.New(),
+	}
+}
+
+func (c *lruCache) Get(key string) (*RiskAnalysis, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	el, ok := c.items[key]
+	if !ok {
+		return nil, false
+	}
+	c.order.MoveToFront(el)
+	return el.Value.(*lruEntry).value, true
+}
+
+func (c *lruCache) Put(key string, value *RiskAnalysis) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if el, ok := c.items[key]; ok {
+		el.Value.(*lruEntry).value = value
+		c.order.MoveToFront(el)
+		return
+	}
+
+	c.items[key] = c.order.PushFront(&lruEntry{key: key, value: value})
+	if c.order.Len() > c.capacity {
+		oldest := c.order.Back()
+		if oldest != nil {
+			c.order.Remove(oldest)
+			delete(c.items, oldest.Value.(*lruEntry).key)
+		}
+	}
+}
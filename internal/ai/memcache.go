@@ -0,0 +1,79 @@
+package ai
+
+import (
+	"container/list"
+	"context"
+	"sync"
+	"time"
+)
+
+// memoryCache is an in-process, fixed-capacity Cache with per-entry TTL.
+// It does not survive a restart and isn't shared across instances - an
+// operator running more than one replica who wants cache hits across them
+// needs the redis backend once it exists.
+type memoryCache struct {
+	capacity int
+
+	mu    sync.Mutex
+	items map[string]*list.Element
+	order *list.List
+}
+
+type memoryCacheEntry struct {
+	key       string
+	response  *Response
+	expiresAt time.Time
+}
+
+func newMemoryCache(capacity int) *memoryCache {
+	return &memoryCache{
+		capacity: capacity,
+		items:    make(map[string]*list.Element),
+		order:    list.New(),
+	}
+}
+
+func (c *memoryCache) Get(_ context.Context, key string) (*Response, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	el, ok := c.items[key]
+	if !ok {
+		return nil, false
+	}
+	entry := el.Value.(*memoryCacheEntry)
+	if time.Now().After(entry.expiresAt) {
+		c.order.Remove(el)
+		delete(c.items, key)
+		return nil, false
+	}
+	c.order.MoveToFront(el)
+	return entry.response, true
+}
+
+func (c *memoryCache) Set(_ context.Context, key string, resp *Response, ttl time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	expiresAt := time.Now().Add(ttl)
+	if el, ok := c.items[key]; ok {
+		entry := el.Value.(*memoryCacheEntry)
+		entry.response = resp
+		entry.expiresAt = expiresAt
+		c.order.MoveToFront(el)
+		return
+	}
+
+	c.items[key] = c.order.PushFront(&memoryCacheEntry{key: key, response: resp, expiresAt: expiresAt})
+	if c.order.Len() > c.capacity {
+		oldest := c.order.Back()
+		if oldest != nil {
+			c.order.Remove(oldest)
+			delete(c.items, oldest.Value.(*memoryCacheEntry).key)
+		}
+	}
+}
+
+func (c *memoryCache) Close() error {
+	return nil
+}
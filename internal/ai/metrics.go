@@ -0,0 +1,61 @@
+package ai
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	tokensConsumedTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "threat_telemetry_hub",
+			Subsystem: "ai",
+			Name:      "tokens_consumed_total",
+			Help:      "Total AI provider tokens consumed, by provider and direction.",
+		},
+		[]string{"provider", "direction"}, // direction: input, output
+	)
+
+	cacheLookupsTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "threat_telemetry_hub",
+			Subsystem: "ai",
+			Name:      "cache_lookups_total",
+			Help:      "Total AI response cache lookups, by cache layer and result.",
+		},
+		[]string{"cache", "result"}, // cache: batch, analyzer; result: hit, miss
+	)
+
+	providerLatencySeconds = promauto.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: "threat_telemetry_hub",
+			Subsystem: "ai",
+			Name:      "provider_latency_seconds",
+			Help:      "Latency of AI provider calls, by provider.",
+			Buckets:   prometheus.ExponentialBuckets(0.25, 2, 10),
+		},
+		[]string{"provider"},
+	)
+
+	timeToFirstTokenSeconds = promauto.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: "threat_telemetry_hub",
+			Subsystem: "ai",
+			Name:      "time_to_first_token_seconds",
+			Help:      "Time from a streaming AI request starting to its first content delta, by provider.",
+			Buckets:   prometheus.ExponentialBuckets(0.05, 2, 10),
+		},
+		[]string{"provider"},
+	)
+
+	interTokenLatencySeconds = promauto.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: "threat_telemetry_hub",
+			Subsystem: "ai",
+			Name:      "inter_token_latency_seconds",
+			Help:      "Time between consecutive content deltas of a streaming AI response, by provider.",
+			Buckets:   prometheus.ExponentialBuckets(0.01, 2, 10),
+		},
+		[]string{"provider"},
+	)
+)
@@ -0,0 +1,200 @@
+// Package ai provides AI-powered analysis for security telemetry
+package ai
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/lvonguyen/threat-telemetry-hub/internal/config"
+)
+
+// OllamaProvider implements the Provider interface against a local Ollama
+// server, so analysis can run fully offline on sensitive telemetry that
+// must never leave the host.
+type OllamaProvider struct {
+	baseURL    string
+	model      string
+	httpClient *http.Client
+	logger     *zap.Logger
+}
+
+// NewOllamaProvider creates a new Ollama provider.
+func NewOllamaProvider(cfg config.OllamaConfig, logger *zap.Logger) (*OllamaProvider, error) {
+	if cfg.BaseURL == "" || cfg.Model == "" {
+		return nil, fmt.Errorf("missing required Ollama configuration")
+	}
+
+	return &OllamaProvider{
+		baseURL: cfg.BaseURL,
+		model:   cfg.Model,
+		httpClient: &http.Client{
+			Timeout: 120 * time.Second,
+		},
+		logger: logger,
+	}, nil
+}
+
+// Name returns the provider name
+func (p *OllamaProvider) Name() string { return "ollama" }
+
+func (p *OllamaProvider) chatRequestBody(prompt string, stream bool) ([]byte, error) {
+	return json.Marshal(map[string]interface{}{
+		"model":  p.model,
+		"stream": stream,
+		"format": "json",
+		"messages": []map[string]string{
+			{
+				"role":    "system",
+				"content": systemPrompt,
+			},
+			{
+				"role":    "user",
+				"content": prompt,
+			},
+		},
+	})
+}
+
+// Analyze performs AI analysis using a local Ollama server's /api/chat
+// endpoint.
+func (p *OllamaProvider) Analyze(ctx context.Context, prompt string) (*Response, error) {
+	jsonBody, err := p.chatRequestBody(prompt, false)
+	if err != nil {
+		return nil, fmt.Errorf("marshaling request: %w", err)
+	}
+
+	resp, err := withRetry(ctx, defaultRetry, func() (*http.Response, error) {
+		req, err := http.NewRequestWithContext(ctx, "POST", p.baseURL+"/api/chat", bytes.NewReader(jsonBody))
+		if err != nil {
+			return nil, fmt.Errorf("creating request: %w", err)
+		}
+		req.Header.Set("Content-Type", "application/json")
+		return p.httpClient.Do(req)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("making request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("API returned status %d", resp.StatusCode)
+	}
+
+	var result struct {
+		Message struct {
+			Content string `json:"content"`
+		} `json:"message"`
+		PromptEvalCount int `json:"prompt_eval_count"`
+		EvalCount       int `json:"eval_count"`
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("decoding response: %w", err)
+	}
+
+	return &Response{
+		Content: result.Message.Content,
+		Model:   p.model,
+		Usage: Usage{
+			InputTokens:  result.PromptEvalCount,
+			OutputTokens: result.EvalCount,
+		},
+	}, nil
+}
+
+// AnalyzeStream performs AI analysis using Ollama's streaming /api/chat
+// endpoint, which sends one JSON object per line (NDJSON) rather than
+// server-sent events. It implements StreamingProvider.
+func (p *OllamaProvider) AnalyzeStream(ctx context.Context, prompt string) (<-chan ResponseChunk, error) {
+	jsonBody, err := p.chatRequestBody(prompt, true)
+	if err != nil {
+		return nil, fmt.Errorf("marshaling request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", p.baseURL+"/api/chat", bytes.NewReader(jsonBody))
+	if err != nil {
+		return nil, fmt.Errorf("creating request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	chunks := make(chan ResponseChunk)
+
+	go func() {
+		defer close(chunks)
+
+		resp, err := p.httpClient.Do(req)
+		if err != nil {
+			sendChunk(ctx, chunks, ResponseChunk{Done: true, Err: fmt.Errorf("making request: %w", err)})
+			return
+		}
+		defer resp.Body.Close()
+
+		if resp.StatusCode != http.StatusOK {
+			sendChunk(ctx, chunks, ResponseChunk{Done: true, Err: fmt.Errorf("API returned status %d", resp.StatusCode)})
+			return
+		}
+
+		var usage Usage
+		start := time.Now()
+		lastTokenAt := start
+		firstToken := true
+
+		scanner := bufio.NewScanner(resp.Body)
+		for scanner.Scan() {
+			line := scanner.Bytes()
+			if len(line) == 0 {
+				continue
+			}
+
+			var event struct {
+				Message struct {
+					Content string `json:"content"`
+				} `json:"message"`
+				Done            bool `json:"done"`
+				PromptEvalCount int  `json:"prompt_eval_count"`
+				EvalCount       int  `json:"eval_count"`
+			}
+			if err := json.Unmarshal(line, &event); err != nil {
+				continue
+			}
+
+			if event.Done {
+				usage.InputTokens = event.PromptEvalCount
+				usage.OutputTokens = event.EvalCount
+				break
+			}
+
+			if event.Message.Content == "" {
+				continue
+			}
+
+			now := time.Now()
+			if firstToken {
+				timeToFirstTokenSeconds.WithLabelValues(p.Name()).Observe(now.Sub(start).Seconds())
+				firstToken = false
+			} else {
+				interTokenLatencySeconds.WithLabelValues(p.Name()).Observe(now.Sub(lastTokenAt).Seconds())
+			}
+			lastTokenAt = now
+
+			if !sendChunk(ctx, chunks, ResponseChunk{Text: event.Message.Content}) {
+				return
+			}
+		}
+		if err := scanner.Err(); err != nil {
+			sendChunk(ctx, chunks, ResponseChunk{Done: true, Err: fmt.Errorf("reading stream: %w", err)})
+			return
+		}
+
+		sendChunk(ctx, chunks, ResponseChunk{Done: true, InputTokens: usage.InputTokens, OutputTokens: usage.OutputTokens})
+	}()
+
+	return chunks, nil
+}
@@ -2,12 +2,14 @@
 package ai
 
 import (
+	"bufio"
 	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
 	"os"
+	"strings"
 	"time"
 
 	"go.uber.org/zap"
@@ -53,7 +55,7 @@ func (p *OpenAIProvider) Analyze(ctx context.Context, prompt string) (*Response,
 		"messages": []map[string]string{
 			{
 				"role":    "system",
-				"content": "You are a security analyst AI. Analyze security telemetry events and provide risk assessments in JSON format.",
+				"content": systemPrompt,
 			},
 			{
 				"role":    "user",
@@ -118,3 +120,276 @@ func (p *OpenAIProvider) Analyze(ctx context.Context, prompt string) (*Response,
 	}, nil
 }
 
+// AnalyzeStream performs AI analysis using OpenAI's streaming API, emitting
+// each content delta as it arrives so a caller can start parsing the JSON
+// payload before the response is complete. It implements StreamingProvider.
+func (p *OpenAIProvider) AnalyzeStream(ctx context.Context, prompt string) (<-chan ResponseChunk, error) {
+	requestBody := map[string]interface{}{
+		"model":      p.model,
+		"max_tokens": 4096,
+		"stream":     true,
+		"stream_options": map[string]bool{
+			"include_usage": true,
+		},
+		"messages": []map[string]string{
+			{
+				"role":    "system",
+				"content": systemPrompt,
+			},
+			{
+				"role":    "user",
+				"content": prompt,
+			},
+		},
+	}
+
+	jsonBody, err := json.Marshal(requestBody)
+	if err != nil {
+		return nil, fmt.Errorf("marshaling request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", "https://api.openai.com/v1/chat/completions", bytes.NewBuffer(jsonBody))
+	if err != nil {
+		return nil, fmt.Errorf("creating request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+p.apiKey)
+	req.Header.Set("Accept", "text/event-stream")
+
+	chunks := make(chan ResponseChunk)
+
+	go func() {
+		defer close(chunks)
+
+		resp, err := p.httpClient.Do(req)
+		if err != nil {
+			sendChunk(ctx, chunks, ResponseChunk{Done: true, Err: fmt.Errorf("making request: %w", err)})
+			return
+		}
+		defer resp.Body.Close()
+
+		if resp.StatusCode != http.StatusOK {
+			sendChunk(ctx, chunks, ResponseChunk{Done: true, Err: fmt.Errorf("API returned status %d", resp.StatusCode)})
+			return
+		}
+
+		var usage Usage
+		start := time.Now()
+		lastTokenAt := start
+		firstToken := true
+
+		scanner := bufio.NewScanner(resp.Body)
+		for scanner.Scan() {
+			line := strings.TrimSpace(scanner.Text())
+			data, ok := strings.CutPrefix(line, "data: ")
+			if !ok {
+				continue
+			}
+			if data == "[DONE]" {
+				break
+			}
+
+			var event struct {
+				Choices []struct {
+					Delta struct {
+						Content string `json:"content"`
+					} `json:"delta"`
+				} `json:"choices"`
+				Usage struct {
+					PromptTokens     int `json:"prompt_tokens"`
+					CompletionTokens int `json:"completion_tokens"`
+				} `json:"usage"`
+			}
+			if err := json.Unmarshal([]byte(data), &event); err != nil {
+				continue
+			}
+
+			if event.Usage.PromptTokens > 0 || event.Usage.CompletionTokens > 0 {
+				usage.InputTokens = event.Usage.PromptTokens
+				usage.OutputTokens = event.Usage.CompletionTokens
+			}
+
+			if len(event.Choices) == 0 || event.Choices[0].Delta.Content == "" {
+				continue
+			}
+
+			now := time.Now()
+			if firstToken {
+				timeToFirstTokenSeconds.WithLabelValues(p.Name()).Observe(now.Sub(start).Seconds())
+				firstToken = false
+			} else {
+				interTokenLatencySeconds.WithLabelValues(p.Name()).Observe(now.Sub(lastTokenAt).Seconds())
+			}
+			lastTokenAt = now
+
+			if !sendChunk(ctx, chunks, ResponseChunk{Text: event.Choices[0].Delta.Content}) {
+				return
+			}
+		}
+		if err := scanner.Err(); err != nil {
+			sendChunk(ctx, chunks, ResponseChunk{Done: true, Err: fmt.Errorf("reading stream: %w", err)})
+			return
+		}
+
+		sendChunk(ctx, chunks, ResponseChunk{Done: true, InputTokens: usage.InputTokens, OutputTokens: usage.OutputTokens})
+	}()
+
+	return chunks, nil
+}
+
+// maxToolCallRounds bounds AnalyzeWithTools' tool-call loop so a provider
+// that keeps requesting tools (e.g. because a tool's result doesn't satisfy
+// it) can't keep the analysis running indefinitely.
+const maxToolCallRounds = 5
+
+// openAIToolCall mirrors the subset of OpenAI's tool_calls message shape
+// AnalyzeWithTools needs to round-trip a call and its result.
+type openAIToolCall struct {
+	ID       string `json:"id"`
+	Type     string `json:"type"`
+	Function struct {
+		Name      string `json:"name"`
+		Arguments string `json:"arguments"`
+	} `json:"function"`
+}
+
+// AnalyzeWithTools performs AI analysis on prompt, offering tools as
+// OpenAI-style callable functions. Whenever the model requests a tool call,
+// handler runs it and the result is fed back as a "tool" role message for
+// another round, up to maxToolCallRounds, before a final Response is
+// returned. It implements ToolCallingProvider.
+func (p *OpenAIProvider) AnalyzeWithTools(ctx context.Context, prompt string, tools []ToolDef, handler ToolHandler) (*Response, error) {
+	toolSpecs := make([]map[string]interface{}, len(tools))
+	for i, tool := range tools {
+		toolSpecs[i] = map[string]interface{}{
+			"type": "function",
+			"function": map[string]interface{}{
+				"name":        tool.Name,
+				"description": tool.Description,
+				"parameters":  tool.Parameters,
+			},
+		}
+	}
+
+	messages := []map[string]interface{}{
+		{
+			"role":    "system",
+			"content": systemPrompt,
+		},
+		{
+			"role":    "user",
+			"content": prompt,
+		},
+	}
+
+	var totalUsage Usage
+
+	for round := 0; round < maxToolCallRounds; round++ {
+		requestBody := map[string]interface{}{
+			"model":      p.model,
+			"max_tokens": 4096,
+			"messages":   messages,
+		}
+		if len(toolSpecs) > 0 {
+			requestBody["tools"] = toolSpecs
+		}
+
+		jsonBody, err := json.Marshal(requestBody)
+		if err != nil {
+			return nil, fmt.Errorf("marshaling request: %w", err)
+		}
+
+		req, err := http.NewRequestWithContext(ctx, "POST", "https://api.openai.com/v1/chat/completions", bytes.NewBuffer(jsonBody))
+		if err != nil {
+			return nil, fmt.Errorf("creating request: %w", err)
+		}
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "Bearer "+p.apiKey)
+
+		resp, err := p.httpClient.Do(req)
+		if err != nil {
+			return nil, fmt.Errorf("making request: %w", err)
+		}
+
+		if resp.StatusCode != http.StatusOK {
+			resp.Body.Close()
+			return nil, fmt.Errorf("API returned status %d", resp.StatusCode)
+		}
+
+		var result struct {
+			Choices []struct {
+				Message struct {
+					Content   string           `json:"content"`
+					ToolCalls []openAIToolCall `json:"tool_calls"`
+				} `json:"message"`
+			} `json:"choices"`
+			Usage struct {
+				PromptTokens     int `json:"prompt_tokens"`
+				CompletionTokens int `json:"completion_tokens"`
+			} `json:"usage"`
+		}
+		decodeErr := json.NewDecoder(resp.Body).Decode(&result)
+		resp.Body.Close()
+		if decodeErr != nil {
+			return nil, fmt.Errorf("decoding response: %w", decodeErr)
+		}
+
+		if len(result.Choices) == 0 {
+			return nil, fmt.Errorf("empty response from API")
+		}
+
+		totalUsage.InputTokens += result.Usage.PromptTokens
+		totalUsage.OutputTokens += result.Usage.CompletionTokens
+
+		message := result.Choices[0].Message
+		if len(message.ToolCalls) == 0 {
+			return &Response{
+				Content: message.Content,
+				Model:   p.model,
+				Usage:   totalUsage,
+			}, nil
+		}
+
+		assistantToolCalls := make([]map[string]interface{}, len(message.ToolCalls))
+		for i, tc := range message.ToolCalls {
+			assistantToolCalls[i] = map[string]interface{}{
+				"id":   tc.ID,
+				"type": "function",
+				"function": map[string]string{
+					"name":      tc.Function.Name,
+					"arguments": tc.Function.Arguments,
+				},
+			}
+		}
+		messages = append(messages, map[string]interface{}{
+			"role":       "assistant",
+			"tool_calls": assistantToolCalls,
+		})
+
+		for _, tc := range message.ToolCalls {
+			toolResult, toolErr := handler(ctx, ToolCall{
+				ID:        tc.ID,
+				Name:      tc.Function.Name,
+				Arguments: json.RawMessage(tc.Function.Arguments),
+			})
+
+			var content string
+			if toolErr != nil {
+				content = fmt.Sprintf(`{"error":%q}`, toolErr.Error())
+			} else if encoded, marshalErr := json.Marshal(toolResult); marshalErr == nil {
+				content = string(encoded)
+			} else {
+				content = fmt.Sprintf(`{"error":%q}`, marshalErr.Error())
+			}
+
+			messages = append(messages, map[string]interface{}{
+				"role":         "tool",
+				"tool_call_id": tc.ID,
+				"content":      content,
+			})
+		}
+	}
+
+	return nil, fmt.Errorf("exceeded %d tool-call rounds without a final response", maxToolCallRounds)
+}
+
@@ -5,13 +5,40 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"log/slog"
+	"time"
 
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
 
+	"github.com/lvonguyen/threat-telemetry-hub/internal/ai/guard"
 	"github.com/lvonguyen/threat-telemetry-hub/internal/config"
 	"github.com/lvonguyen/threat-telemetry-hub/internal/ingestion"
+	"github.com/lvonguyen/threat-telemetry-hub/internal/observability"
+	"github.com/lvonguyen/threat-telemetry-hub/internal/ocsf"
 )
 
+// defaultCacheTTL is used when cfg.Cache.TTLSeconds is unset.
+const defaultCacheTTL = 10 * time.Minute
+
+// tracerName identifies spans started by this package in OpenTelemetry
+// backends; by convention it's the package's own import path.
+const tracerName = "github.com/lvonguyen/threat-telemetry-hub/internal/ai"
+
+// startAnalyzeSpan starts the "ai.analyze" span shared by the Analyzer and
+// BatchAnalyzer RiskAnalyzer implementations, tagged with the standardized
+// event/provider keys so it lines up with the ingestion.collect and
+// enrichment.enrich spans for the same event.
+func startAnalyzeSpan(ctx context.Context, event *ingestion.RawEvent, provider string) (context.Context, trace.Span) {
+	return otel.Tracer(tracerName).Start(ctx, "ai.analyze", trace.WithAttributes(
+		attribute.String(observability.KeyEventID, event.ID()),
+		attribute.String(observability.KeyEventSource, ingestion.SourceName(event)),
+		attribute.String(observability.KeyAIProvider, provider),
+	))
+}
+
 // Provider defines the interface for AI providers
 type Provider interface {
 	// Analyze performs AI analysis on the given prompt
@@ -20,6 +47,88 @@ type Provider interface {
 	Name() string
 }
 
+// StreamingProvider is implemented by providers that can stream a response
+// as it's generated, so a caller can start parsing the JSON payload before
+// the full response has arrived instead of waiting on the whole request.
+type StreamingProvider interface {
+	// AnalyzeStream performs AI analysis on the given prompt, sending
+	// ResponseChunk values on the returned channel as they arrive. The
+	// channel is unbuffered, so a slow consumer applies backpressure all
+	// the way back to the upstream read instead of chunks piling up in
+	// memory. It's closed after a chunk with Done set (success) or Err set
+	// (failure). Canceling ctx stops the upstream connection immediately.
+	// The returned error is only for failures that happen before the
+	// stream starts (e.g. building the request).
+	AnalyzeStream(ctx context.Context, prompt string) (<-chan ResponseChunk, error)
+}
+
+// ToolDef describes a callback function a provider may invoke mid-analysis,
+// in OpenAI's "function calling" shape: Parameters is a JSON Schema object
+// describing the arguments the named function accepts.
+type ToolDef struct {
+	Name        string                 `json:"name"`
+	Description string                 `json:"description"`
+	Parameters  map[string]interface{} `json:"parameters"`
+}
+
+// ToolCall is one invocation a provider requested mid-analysis. Arguments is
+// left as raw JSON so the caller can unmarshal it into whatever shape the
+// named tool expects.
+type ToolCall struct {
+	ID        string          `json:"id"`
+	Name      string          `json:"name"`
+	Arguments json.RawMessage `json:"arguments"`
+}
+
+// ToolHandler executes a single ToolCall - typically by calling back into
+// one of the hub's own enrichment functions (e.g. "lookup_ioc", "get_asset")
+// - and returns its result as a JSON-encodable value to hand back to the
+// provider for another round.
+type ToolHandler func(ctx context.Context, call ToolCall) (interface{}, error)
+
+// ToolCallingProvider is implemented by providers that can call back into
+// enrichment functions mid-analysis instead of only producing a final
+// answer in one shot.
+type ToolCallingProvider interface {
+	// AnalyzeWithTools performs AI analysis on prompt, offering tools as
+	// callable functions. Whenever the provider requests a tool call,
+	// handler runs it and the result is fed back for another round, up to
+	// the provider's own tool-call loop limit, before a final Response is
+	// returned.
+	AnalyzeWithTools(ctx context.Context, prompt string, tools []ToolDef, handler ToolHandler) (*Response, error)
+}
+
+// ResponseChunk is one piece of a streamed AI response. Text carries the
+// next delta of generated content; InputTokens/OutputTokens are only
+// populated on the final chunk (Done true), once the provider has reported
+// usage for the completed response.
+type ResponseChunk struct {
+	Text         string
+	InputTokens  int
+	OutputTokens int
+	Done         bool
+	Err          error
+}
+
+// RiskAnalyzer is implemented by anything that can turn a raw event into a
+// RiskAnalysis: the single-provider Analyzer, or the BatchAnalyzer with its
+// own multi-provider fallback chain.
+type RiskAnalyzer interface {
+	AnalyzeRawEvent(ctx context.Context, event *ingestion.RawEvent) (*RiskAnalysis, error)
+}
+
+// sendChunk delivers chunk on ch, returning false without sending if ctx is
+// canceled first - the signal a streaming provider's read loop uses to stop
+// pulling from the upstream connection once the consumer has gone away.
+func sendChunk(ctx context.Context, ch chan<- ResponseChunk, chunk ResponseChunk) bool {
+	select {
+	case ch <- chunk:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
 // Response represents an AI analysis response
 type Response struct {
 	Content string `json:"content"`
@@ -50,22 +159,19 @@ type RiskAnalysis struct {
 type Analyzer struct {
 	provider Provider
 	logger   *zap.Logger
+	slog     *slog.Logger
+	redactor *Redactor
+	cache    Cache
+	cacheTTL time.Duration
 }
 
-// NewAnalyzer creates a new AI analyzer based on configuration
+// NewAnalyzer creates a new AI analyzer based on configuration. The
+// provider is built from DefaultRegistry, so any provider registered there
+// - including third-party ones registered from an init() in a side-package
+// the operator links in - is selectable via cfg.Provider without this
+// function needing to know about it.
 func NewAnalyzer(cfg config.AIConfig, logger *zap.Logger) (*Analyzer, error) {
-	var provider Provider
-	var err error
-
-	switch cfg.Provider {
-	case "anthropic":
-		provider, err = NewAnthropicProvider(cfg.Anthropic, logger)
-	case "openai":
-		provider, err = NewOpenAIProvider(cfg.OpenAI, logger)
-	default:
-		return nil, fmt.Errorf("unsupported AI provider: %s", cfg.Provider)
-	}
-
+	provider, err := DefaultRegistry.New(cfg.Provider, cfg, logger)
 	if err != nil {
 		return nil, fmt.Errorf("initializing AI provider %s: %w", cfg.Provider, err)
 	}
@@ -74,22 +180,74 @@ func NewAnalyzer(cfg config.AIConfig, logger *zap.Logger) (*Analyzer, error) {
 		zap.String("provider", cfg.Provider),
 	)
 
+	var redactor *Redactor
+	if cfg.Redaction.Enabled {
+		redactor = NewRedactor()
+	}
+
+	cache, err := NewCache(cfg.Cache, logger)
+	if err != nil {
+		return nil, fmt.Errorf("initializing AI response cache: %w", err)
+	}
+	cacheTTL := time.Duration(cfg.Cache.TTLSeconds) * time.Second
+	if cacheTTL <= 0 {
+		cacheTTL = defaultCacheTTL
+	}
+
 	return &Analyzer{
 		provider: provider,
 		logger:   logger,
+		slog:     observability.NewSlogLogger(logger),
+		redactor: redactor,
+		cache:    cache,
+		cacheTTL: cacheTTL,
 	}, nil
 }
 
 // AnalyzeRawEvent performs AI analysis on raw event data before normalization
 // This captures context that might be lost during schema normalization
 func (a *Analyzer) AnalyzeRawEvent(ctx context.Context, event *ingestion.RawEvent) (*RiskAnalysis, error) {
+	ctx, span := startAnalyzeSpan(ctx, event, a.provider.Name())
+	defer span.End()
+
 	// Build prompt for raw event analysis
 	prompt := a.buildRawEventPrompt(event)
+	if a.redactor != nil {
+		prompt = a.redactor.Redact(prompt)
+	}
 
-	// Call AI provider
-	response, err := a.provider.Analyze(ctx, prompt)
-	if err != nil {
-		return nil, fmt.Errorf("AI analysis failed: %w", err)
+	cacheKey := CacheKey(a.provider.Name(), prompt)
+	response, cached := a.cache.Get(ctx, cacheKey)
+	validated := cached
+	if cached {
+		cacheLookupsTotal.WithLabelValues("analyzer", "hit").Inc()
+	} else {
+		cacheLookupsTotal.WithLabelValues("analyzer", "miss").Inc()
+
+		var err error
+		response, validated, err = a.analyzeWithRetries(ctx, prompt)
+		if err != nil {
+			span.RecordError(err)
+			return nil, fmt.Errorf("AI analysis failed: %w", err)
+		}
+		if validated {
+			a.cache.Set(ctx, cacheKey, response, a.cacheTTL)
+		}
+	}
+
+	span.SetAttributes(
+		attribute.Int(observability.KeyAITokensInput, response.Usage.InputTokens),
+		attribute.Int(observability.KeyAITokensOutput, response.Usage.OutputTokens),
+	)
+	a.slog.InfoContext(ctx, "AI analysis completed",
+		observability.KeyEventID, event.ID(),
+		observability.KeyAIProvider, a.provider.Name(),
+		observability.KeyAITokensInput, response.Usage.InputTokens,
+		observability.KeyAITokensOutput, response.Usage.OutputTokens,
+	)
+
+	if !validated {
+		return a.fallbackAnalysis(event), nil
 	}
 
 	// Parse response into RiskAnalysis
@@ -97,7 +255,7 @@ func (a *Analyzer) AnalyzeRawEvent(ctx context.Context, event *ingestion.RawEven
 	if err != nil {
 		a.logger.Warn("Failed to parse AI response, using fallback",
 			zap.Error(err),
-			zap.String("event_id", event.ID),
+			zap.String("event_id", event.ID()),
 		)
 		return a.fallbackAnalysis(event), nil
 	}
@@ -105,16 +263,81 @@ func (a *Analyzer) AnalyzeRawEvent(ctx context.Context, event *ingestion.RawEven
 	return analysis, nil
 }
 
+// analyzeWithRetries calls the provider with prompt, re-prompting up to
+// guard.MaxRetries times if the response fails guard.ValidateResponse -
+// the provider can't be trusted to always honor the requested JSON shape,
+// and parsing an out-of-schema response straight into a security decision
+// is the failure mode guard exists to prevent. A provider error (network,
+// API) is returned as-is; exhausting retries against a schema-invalid
+// response instead returns that response with validated set to false, so
+// the caller falls back the same way it would for an unparseable one.
+func (a *Analyzer) analyzeWithRetries(ctx context.Context, prompt string) (response *Response, validated bool, err error) {
+	for attempt := 0; ; attempt++ {
+		response, err = a.provider.Analyze(ctx, prompt)
+		if err != nil {
+			return nil, false, err
+		}
+
+		if verr := guard.ValidateResponse(response.Content); verr == nil {
+			return response, true, nil
+		} else if attempt >= guard.MaxRetries {
+			a.logger.Warn("AI response failed schema validation after retries, falling back",
+				zap.Error(verr),
+				zap.Int("attempts", attempt+1),
+			)
+			return response, false, nil
+		} else {
+			a.logger.Warn("AI response failed schema validation, re-prompting",
+				zap.Error(verr),
+				zap.Int("attempt", attempt+1),
+			)
+		}
+	}
+}
+
+// systemPrompt is the instructions every provider sends in its system
+// role (or closest equivalent) rather than folded into the user-supplied
+// prompt, so guard.SystemPromptAddendum's untrusted-data boundary carries
+// the privilege a system message has and a crafted event body can't talk
+// over it.
+const systemPrompt = "You are a security analyst AI. Analyze security telemetry events and provide risk assessments in JSON format." +
+	guard.SystemPromptAddendum
+
+// buildRawEventPrompt prefers the OCSF-mapped view of the event: it's far
+// smaller and more consistent than vendor JSON, which keeps prompts cheap
+// and comparable across sources. Unmapped vendor fields ride along in the
+// OCSF event's own "unmapped" key, so context isn't lost. If the event
+// can't be mapped (e.g. an unrecognized source), fall back to the raw
+// vendor payload so analysis still proceeds.
 func (a *Analyzer) buildRawEventPrompt(event *ingestion.RawEvent) string {
-	eventJSON, _ := json.MarshalIndent(event.Data, "", "  ")
+	return buildPrompt(event, 1)
+}
+
+// buildPrompt builds the analysis prompt for event. count is the number of
+// events this single prompt stands in for when it represents a batched
+// cluster of similarly-shaped events rather than one event on its own.
+func buildPrompt(event *ingestion.RawEvent, count int) string {
+	var eventJSON []byte
+	if oe, err := ocsf.Map(event); err == nil {
+		eventJSON, _ = json.MarshalIndent(oe, "", "  ")
+	} else {
+		eventJSON, _ = json.MarshalIndent(ingestion.DataMap(event), "", "  ")
+	}
+
+	batchNote := ""
+	if count > 1 {
+		batchNote = fmt.Sprintf("\nThis event is representative of a batch of %d similarly-shaped events received in the same window; the assessment will be applied to all of them.\n", count)
+	}
 
-	return fmt.Sprintf(`Analyze this raw security telemetry event and provide a risk assessment.
+	sanitized := guard.Sanitize(string(eventJSON))
+
+	return fmt.Sprintf(`Analyze this security telemetry event and provide a risk assessment.
 
 Source: %s
 Source Type: %s
 Timestamp: %s
-
-Raw Event Data:
+%s
+Event Data (OCSF where available, otherwise raw vendor payload):
 %s
 
 Provide your analysis as JSON with the following structure:
@@ -135,10 +358,11 @@ Focus on:
 2. Mapping to MITRE ATT&CK framework
 3. Contextual information that might be lost during schema normalization
 4. Actionable recommendations for security analysts`,
-		event.Source,
-		event.SourceType,
-		event.Timestamp.Format("2006-01-02T15:04:05Z"),
-		string(eventJSON),
+		ingestion.SourceName(event),
+		ingestion.SourceType(event),
+		event.Time().Format("2006-01-02T15:04:05Z"),
+		batchNote,
+		guard.Wrap(sanitized),
 	)
 }
 
@@ -154,7 +378,7 @@ func (a *Analyzer) fallbackAnalysis(event *ingestion.RawEvent) *RiskAnalysis {
 	return &RiskAnalysis{
 		RiskScore:       0.5,
 		RiskLevel:       "medium",
-		Summary:         fmt.Sprintf("Event from %s requires manual review", event.Source),
+		Summary:         fmt.Sprintf("Event from %s requires manual review", ingestion.SourceName(event)),
 		Indicators:      []string{},
 		MITRETactics:    []string{},
 		MITRETechniques: []string{},
@@ -0,0 +1,41 @@
+package ai
+
+import "regexp"
+
+// redactionRule pairs a regexp with the label substituted into its matches,
+// so the redacted prompt still says what kind of value was there.
+type redactionRule struct {
+	name    string
+	pattern *regexp.Regexp
+}
+
+// defaultRedactionRules catches the PII/secret shapes most likely to show up
+// in security telemetry prompts: email addresses, IPv4 addresses, and
+// API-key-shaped tokens. Operators who need more should pre-process events
+// before they reach Analyzer.
+var defaultRedactionRules = []redactionRule{
+	{name: "email", pattern: regexp.MustCompile(`[a-zA-Z0-9._%+\-]+@[a-zA-Z0-9.\-]+\.[a-zA-Z]{2,}`)},
+	{name: "ipv4", pattern: regexp.MustCompile(`\b(?:\d{1,3}\.){3}\d{1,3}\b`)},
+	{name: "api_key", pattern: regexp.MustCompile(`\b(?:sk|pk|ghp|xox[a-z])-[A-Za-z0-9_\-]{10,}\b`)},
+}
+
+// Redactor scrubs a prompt of values an operator doesn't want leaving the
+// local network before it reaches a third-party AI provider. Enabled via
+// config.RedactionConfig.Enabled.
+type Redactor struct {
+	rules []redactionRule
+}
+
+// NewRedactor builds a Redactor using the built-in rule set.
+func NewRedactor() *Redactor {
+	return &Redactor{rules: defaultRedactionRules}
+}
+
+// Redact replaces every match of every rule in prompt with
+// "[REDACTED:<rule name>]".
+func (r *Redactor) Redact(prompt string) string {
+	for _, rule := range r.rules {
+		prompt = rule.pattern.ReplaceAllString(prompt, "[REDACTED:"+rule.name+"]")
+	}
+	return prompt
+}
@@ -0,0 +1,84 @@
+package ai
+
+import (
+	"fmt"
+	"sync"
+
+	"go.uber.org/zap"
+
+	"github.com/lvonguyen/threat-telemetry-hub/internal/config"
+)
+
+// ProviderFactory builds a Provider from AIConfig. Implementations typically
+// read one sub-struct of cfg (e.g. cfg.Ollama) and ignore the rest.
+type ProviderFactory func(cfg config.AIConfig, logger *zap.Logger) (Provider, error)
+
+// Registry maps AIConfig.Provider names to the factories that construct
+// them, so NewAnalyzer can select a provider by name without a hardcoded
+// switch statement. (NewBatchAnalyzer builds its anthropic/openai chain
+// directly and does not consult it.) Mirrors ticketing.Registry.
+type Registry struct {
+	mu        sync.RWMutex
+	factories map[string]ProviderFactory
+}
+
+// DefaultRegistry is the process-wide registry the providers in this
+// package register themselves into at init time.
+var DefaultRegistry = NewRegistry()
+
+// NewRegistry creates an empty Registry.
+func NewRegistry() *Registry {
+	return &Registry{factories: make(map[string]ProviderFactory)}
+}
+
+// Register adds a provider factory under name, overwriting any existing
+// registration for that name.
+func (r *Registry) Register(name string, factory ProviderFactory) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.factories[name] = factory
+}
+
+// New constructs the named provider from cfg. It returns an error if no
+// factory was ever registered under name.
+func (r *Registry) New(name string, cfg config.AIConfig, logger *zap.Logger) (Provider, error) {
+	r.mu.RLock()
+	factory, ok := r.factories[name]
+	r.mu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("ai: no provider registered for %q", name)
+	}
+	return factory(cfg, logger)
+}
+
+// Names returns the currently registered provider names.
+func (r *Registry) Names() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	names := make([]string, 0, len(r.factories))
+	for name := range r.factories {
+		names = append(names, name)
+	}
+	return names
+}
+
+func init() {
+	DefaultRegistry.Register("anthropic", func(cfg config.AIConfig, logger *zap.Logger) (Provider, error) {
+		return NewAnthropicProvider(cfg.Anthropic, logger)
+	})
+	DefaultRegistry.Register("openai", func(cfg config.AIConfig, logger *zap.Logger) (Provider, error) {
+		return NewOpenAIProvider(cfg.OpenAI, logger)
+	})
+	DefaultRegistry.Register("ollama", func(cfg config.AIConfig, logger *zap.Logger) (Provider, error) {
+		return NewOllamaProvider(cfg.Ollama, logger)
+	})
+	DefaultRegistry.Register("llamacpp", func(cfg config.AIConfig, logger *zap.Logger) (Provider, error) {
+		return NewLlamaCppProvider(cfg.LlamaCpp, logger)
+	})
+	DefaultRegistry.Register("bedrock", func(cfg config.AIConfig, logger *zap.Logger) (Provider, error) {
+		return NewBedrockProvider(cfg.Bedrock, logger)
+	})
+	DefaultRegistry.Register("vertex", func(cfg config.AIConfig, logger *zap.Logger) (Provider, error) {
+		return NewVertexProvider(cfg.Vertex, logger)
+	})
+}
@@ -0,0 +1,72 @@
+package ai
+
+import (
+	"context"
+	"math/rand"
+	"net/http"
+	"time"
+)
+
+// retryConfig bounds the backoff loop the local/cloud provider HTTP clients
+// (Ollama, llama.cpp, Bedrock, Vertex) use around their upstream call:
+// transport errors and 429/5xx responses are retried with exponential
+// backoff and jitter, up to MaxAttempts total tries.
+type retryConfig struct {
+	MaxAttempts int
+	BaseDelay   time.Duration
+	MaxDelay    time.Duration
+}
+
+// defaultRetry is a reasonable default for a single provider call: three
+// tries total, starting at half a second and capping at eight.
+var defaultRetry = retryConfig{MaxAttempts: 3, BaseDelay: 500 * time.Millisecond, MaxDelay: 8 * time.Second}
+
+// withRetry calls attempt up to cfg.MaxAttempts times, retrying only on a
+// transport error or a 429/5xx response, with exponential backoff and
+// jitter between tries. It returns whichever attempt stopped the loop,
+// whether that's a success, a non-retryable error response, or the last
+// retryable failure once attempts are exhausted.
+func withRetry(ctx context.Context, cfg retryConfig, attempt func() (*http.Response, error)) (*http.Response, error) {
+	var resp *http.Response
+	var err error
+
+	for i := 0; i < cfg.MaxAttempts; i++ {
+		resp, err = attempt()
+		if !shouldRetry(resp, err) {
+			return resp, err
+		}
+		if i == cfg.MaxAttempts-1 {
+			break
+		}
+		if resp != nil {
+			resp.Body.Close()
+		}
+
+		select {
+		case <-time.After(backoffDelay(cfg, i)):
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+
+	return resp, err
+}
+
+func shouldRetry(resp *http.Response, err error) bool {
+	if err != nil {
+		return true
+	}
+	return resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500
+}
+
+// backoffDelay returns the delay before retry attempt (0-indexed), doubling
+// BaseDelay each attempt up to MaxDelay, with up to 50% jitter so many
+// concurrent callers retrying the same outage don't all land at once.
+func backoffDelay(cfg retryConfig, attempt int) time.Duration {
+	delay := cfg.BaseDelay * time.Duration(1<<attempt)
+	if delay > cfg.MaxDelay {
+		delay = cfg.MaxDelay
+	}
+	jitter := time.Duration(rand.Int63n(int64(delay)/2 + 1))
+	return delay/2 + jitter
+}
@@ -0,0 +1,62 @@
+package ai
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// tokenBucket enforces a global tokens-per-minute budget across every
+// provider call, back-pressuring callers instead of letting a burst blow
+// through a provider's own rate limit.
+type tokenBucket struct {
+	capacity   float64
+	refillRate float64 // tokens per second
+
+	mu         sync.Mutex
+	tokens     float64
+	lastRefill time.Time
+}
+
+func newTokenBucket(tokensPerMinute int) *tokenBucket {
+	capacity := float64(tokensPerMinute)
+	return &tokenBucket{
+		capacity:   capacity,
+		refillRate: capacity / 60.0,
+		tokens:     capacity,
+		lastRefill: time.Now(),
+	}
+}
+
+func (b *tokenBucket) refill() {
+	now := time.Now()
+	b.tokens += now.Sub(b.lastRefill).Seconds() * b.refillRate
+	if b.tokens > b.capacity {
+		b.tokens = b.capacity
+	}
+	b.lastRefill = now
+}
+
+// Wait blocks until n tokens are available, or returns ctx.Err() if ctx is
+// canceled first.
+func (b *tokenBucket) Wait(ctx context.Context, n int) error {
+	for {
+		b.mu.Lock()
+		b.refill()
+		if b.tokens >= float64(n) {
+			b.tokens -= float64(n)
+			b.mu.Unlock()
+			return nil
+		}
+		wait := time.Duration((float64(n)-b.tokens)/b.refillRate*1000) * time.Millisecond
+		b.mu.Unlock()
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
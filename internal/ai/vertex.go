@@ -0,0 +1,141 @@
+// Package ai provides AI-powered analysis for security telemetry
+package ai
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/lvonguyen/threat-telemetry-hub/internal/config"
+)
+
+const vertexMaxOutputTokens = 4096
+
+// VertexProvider implements the Provider interface for GCP Vertex AI's
+// generateContent API. It authenticates with a bearer access token read
+// from the environment rather than performing the OAuth2 service-account
+// exchange itself - refreshing that token (e.g. via `gcloud auth
+// print-access-token` on a cron) is the operator's responsibility, same as
+// every other provider's API-key env var.
+//
+// AnalyzeStream isn't implemented: Vertex's streaming endpoint
+// (streamGenerateContent) returns a JSON array of partial candidates rather
+// than SSE deltas, and no caller currently needs it. Analyze covers the
+// non-streaming case.
+type VertexProvider struct {
+	projectID   string
+	location    string
+	model       string
+	accessToken string
+	httpClient  *http.Client
+	logger      *zap.Logger
+}
+
+// NewVertexProvider creates a new GCP Vertex AI provider.
+func NewVertexProvider(cfg config.VertexConfig, logger *zap.Logger) (*VertexProvider, error) {
+	accessToken := os.Getenv(cfg.AccessTokenEnv)
+	if cfg.ProjectID == "" || cfg.Location == "" || cfg.Model == "" || accessToken == "" {
+		return nil, fmt.Errorf("missing required Vertex AI configuration")
+	}
+
+	return &VertexProvider{
+		projectID:   cfg.ProjectID,
+		location:    cfg.Location,
+		model:       cfg.Model,
+		accessToken: accessToken,
+		httpClient: &http.Client{
+			Timeout: 120 * time.Second,
+		},
+		logger: logger,
+	}, nil
+}
+
+// Name returns the provider name
+func (p *VertexProvider) Name() string { return "vertex" }
+
+// Analyze performs AI analysis using the configured Vertex AI model.
+func (p *VertexProvider) Analyze(ctx context.Context, prompt string) (*Response, error) {
+	requestBody := map[string]interface{}{
+		"systemInstruction": map[string]interface{}{
+			"parts": []map[string]string{
+				{"text": systemPrompt},
+			},
+		},
+		"contents": []map[string]interface{}{
+			{
+				"role": "user",
+				"parts": []map[string]string{
+					{"text": prompt},
+				},
+			},
+		},
+		"generationConfig": map[string]interface{}{
+			"maxOutputTokens": vertexMaxOutputTokens,
+		},
+	}
+
+	jsonBody, err := json.Marshal(requestBody)
+	if err != nil {
+		return nil, fmt.Errorf("marshaling request: %w", err)
+	}
+
+	url := fmt.Sprintf(
+		"https://%s-aiplatform.googleapis.com/v1/projects/%s/locations/%s/publishers/google/models/%s:generateContent",
+		p.location, p.projectID, p.location, p.model,
+	)
+
+	resp, err := withRetry(ctx, defaultRetry, func() (*http.Response, error) {
+		req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewReader(jsonBody))
+		if err != nil {
+			return nil, fmt.Errorf("creating request: %w", err)
+		}
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "Bearer "+p.accessToken)
+		return p.httpClient.Do(req)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("making request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("API returned status %d", resp.StatusCode)
+	}
+
+	var result struct {
+		Candidates []struct {
+			Content struct {
+				Parts []struct {
+					Text string `json:"text"`
+				} `json:"parts"`
+			} `json:"content"`
+		} `json:"candidates"`
+		UsageMetadata struct {
+			PromptTokenCount     int `json:"promptTokenCount"`
+			CandidatesTokenCount int `json:"candidatesTokenCount"`
+		} `json:"usageMetadata"`
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("decoding response: %w", err)
+	}
+
+	if len(result.Candidates) == 0 || len(result.Candidates[0].Content.Parts) == 0 {
+		return nil, fmt.Errorf("empty response from API")
+	}
+
+	return &Response{
+		Content: result.Candidates[0].Content.Parts[0].Text,
+		Model:   p.model,
+		Usage: Usage{
+			InputTokens:  result.UsageMetadata.PromptTokenCount,
+			OutputTokens: result.UsageMetadata.CandidatesTokenCount,
+		},
+	}, nil
+}
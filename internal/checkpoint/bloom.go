@@ -0,0 +1,86 @@
+package checkpoint
+
+import (
+	"hash/fnv"
+	"math"
+	"sync"
+)
+
+// BloomFilter is a small thread-safe bloom filter used by collectors to
+// de-duplicate recently-seen event IDs when their upstream API can
+// redeliver the same event - CloudTrail S3 object replay, Netskope's
+// overlapping poll windows - and a cursor alone can't guarantee
+// exactly-once delivery. It never forgets a seen ID (there is no reset or
+// expiry), so it's a
+// "seen since the collector started" filter rather than a time-windowed
+// one: that tradeoff favors never double-processing an event over
+// eventually allowing a long-since-seen ID to repeat.
+type BloomFilter struct {
+	mu   sync.Mutex
+	bits []uint64
+	m    uint64
+	k    int
+}
+
+// NewBloomFilter sizes a filter for approximately n expected items at
+// false-positive rate p (e.g. NewBloomFilter(100_000, 0.01)).
+func NewBloomFilter(n int, p float64) *BloomFilter {
+	if n <= 0 {
+		n = 1
+	}
+	if p <= 0 || p >= 1 {
+		p = 0.01
+	}
+
+	m := uint64(math.Ceil(-1 * float64(n) * math.Log(p) / (math.Ln2 * math.Ln2)))
+	if m == 0 {
+		m = 1
+	}
+	k := int(math.Round(float64(m) / float64(n) * math.Ln2))
+	if k < 1 {
+		k = 1
+	}
+
+	return &BloomFilter{
+		bits: make([]uint64, (m/64)+1),
+		m:    m,
+		k:    k,
+	}
+}
+
+// Add records id as seen.
+func (f *BloomFilter) Add(id string) {
+	h1, h2 := f.hash(id)
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for i := 0; i < f.k; i++ {
+		bit := (h1 + uint64(i)*h2) % f.m
+		f.bits[bit/64] |= 1 << (bit % 64)
+	}
+}
+
+// Test reports whether id has probably been seen before. False positives
+// are possible (by design, bounded by the rate passed to NewBloomFilter);
+// false negatives are not.
+func (f *BloomFilter) Test(id string) bool {
+	h1, h2 := f.hash(id)
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for i := 0; i < f.k; i++ {
+		bit := (h1 + uint64(i)*h2) % f.m
+		if f.bits[bit/64]&(1<<(bit%64)) == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// hash derives two independent hashes of id, combined via double hashing
+// (Kirsch-Mitzenmacher) to simulate f.k hash functions from just two.
+func (f *BloomFilter) hash(id string) (uint64, uint64) {
+	h1 := fnv.New64a()
+	h1.Write([]byte(id))
+	h2 := fnv.New64()
+	h2.Write([]byte(id))
+	return h1.Sum64(), h2.Sum64()
+}
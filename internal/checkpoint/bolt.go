@@ -0,0 +1,70 @@
+package checkpoint
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+var checkpointBucket = []byte("checkpoints")
+
+// BoltCheckpointer persists cursors to a local BoltDB file. It's durable
+// across restarts without requiring any external service, making it the
+// right default for a single-node deployment. BoltDB holds an exclusive
+// lock on its file, so HA deployments running more than one instance of a
+// collector need a shared backend (Postgres, Redis) instead.
+type BoltCheckpointer struct {
+	db *bolt.DB
+}
+
+// NewBoltCheckpointer opens (creating if necessary) a BoltDB file at path
+// with the checkpoint bucket ready to use.
+func NewBoltCheckpointer(path string) (*BoltCheckpointer, error) {
+	db, err := bolt.Open(path, 0600, nil)
+	if err != nil {
+		return nil, fmt.Errorf("opening checkpoint db %s: %w", path, err)
+	}
+
+	if err := db.Update(func(tx *bolt.Tx) error {
+		_, err := tx.CreateBucketIfNotExists(checkpointBucket)
+		return err
+	}); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("creating checkpoint bucket in %s: %w", path, err)
+	}
+
+	return &BoltCheckpointer{db: db}, nil
+}
+
+func (b *BoltCheckpointer) Load(_ context.Context, collector string) ([]byte, error) {
+	var cursor []byte
+	err := b.db.View(func(tx *bolt.Tx) error {
+		if v := tx.Bucket(checkpointBucket).Get([]byte(collector)); v != nil {
+			cursor = append([]byte(nil), v...)
+		}
+		return nil
+	})
+	return cursor, err
+}
+
+func (b *BoltCheckpointer) Save(_ context.Context, collector string, cursor []byte) error {
+	return b.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(checkpointBucket).Put([]byte(collector), cursor)
+	})
+}
+
+func (b *BoltCheckpointer) CompareAndSwap(_ context.Context, collector string, expected, cursor []byte) error {
+	return b.db.Update(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(checkpointBucket)
+		if !bytes.Equal(bucket.Get([]byte(collector)), expected) {
+			return ErrCASConflict
+		}
+		return bucket.Put([]byte(collector), cursor)
+	})
+}
+
+func (b *BoltCheckpointer) Close() error {
+	return b.db.Close()
+}
@@ -0,0 +1,56 @@
+// Package checkpoint persists each ingestion collector's read position -
+// an opaque vendor-specific continuation token such as an S3 object key,
+// an API page token, or a timestamp - so a restart resumes exactly where a
+// collector left off instead of re-reading from the start (duplicating
+// everything already processed) or jumping to the latest position
+// (silently losing whatever arrived during the outage).
+package checkpoint
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"go.uber.org/zap"
+
+	"github.com/lvonguyen/threat-telemetry-hub/internal/config"
+)
+
+// ErrCASConflict is returned by CompareAndSwap when the currently stored
+// cursor does not match the caller's expected value - another writer (e.g.
+// a second instance of the same collector during a rolling deploy) has
+// already advanced it.
+var ErrCASConflict = errors.New("checkpoint: compare-and-swap conflict")
+
+// Checkpointer persists and retrieves per-collector cursors.
+type Checkpointer interface {
+	// Load returns the last saved cursor for collector, or nil with no
+	// error if none has been saved yet.
+	Load(ctx context.Context, collector string) ([]byte, error)
+	// Save unconditionally overwrites the cursor for collector.
+	Save(ctx context.Context, collector string, cursor []byte) error
+	// CompareAndSwap saves cursor only if the currently stored value
+	// equals expected (a nil expected means "only if unset"); otherwise
+	// it returns ErrCASConflict without writing anything.
+	CompareAndSwap(ctx context.Context, collector string, expected, cursor []byte) error
+	// Close releases any resources held by the backend (file handles,
+	// connections). Safe to call on a Checkpointer that was never used.
+	Close() error
+}
+
+// New builds the Checkpointer backend selected by cfg.Backend.
+func New(cfg config.CheckpointConfig, logger *zap.Logger) (Checkpointer, error) {
+	switch cfg.Backend {
+	case "", "memory":
+		logger.Warn("Checkpoint backend is in-memory; collector cursors will not survive a restart")
+		return NewMemoryCheckpointer(), nil
+	case "bolt":
+		return NewBoltCheckpointer(cfg.Bolt.Path)
+	case "postgres":
+		return nil, fmt.Errorf("checkpoint: postgres backend not yet implemented")
+	case "redis":
+		return nil, fmt.Errorf("checkpoint: redis backend not yet implemented")
+	default:
+		return nil, fmt.Errorf("checkpoint: unsupported backend %q", cfg.Backend)
+	}
+}
@@ -0,0 +1,46 @@
+package checkpoint
+
+import (
+	"bytes"
+	"context"
+	"sync"
+)
+
+// MemoryCheckpointer keeps cursors in an in-process map. Cursors are lost
+// on restart, so this backend is meant for local development and single
+// invocations, not production - see BoltCheckpointer for a durable
+// single-node alternative.
+type MemoryCheckpointer struct {
+	mu      sync.Mutex
+	cursors map[string][]byte
+}
+
+// NewMemoryCheckpointer returns a Checkpointer with no persisted cursors.
+func NewMemoryCheckpointer() *MemoryCheckpointer {
+	return &MemoryCheckpointer{cursors: make(map[string][]byte)}
+}
+
+func (m *MemoryCheckpointer) Load(_ context.Context, collector string) ([]byte, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.cursors[collector], nil
+}
+
+func (m *MemoryCheckpointer) Save(_ context.Context, collector string, cursor []byte) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.cursors[collector] = cursor
+	return nil
+}
+
+func (m *MemoryCheckpointer) CompareAndSwap(_ context.Context, collector string, expected, cursor []byte) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if !bytes.Equal(m.cursors[collector], expected) {
+		return ErrCASConflict
+	}
+	m.cursors[collector] = cursor
+	return nil
+}
+
+func (m *MemoryCheckpointer) Close() error { return nil }
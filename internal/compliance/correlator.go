@@ -0,0 +1,268 @@
+package compliance
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+var (
+	correlatorParentBucket   = []byte("correlator_parent")
+	correlatorKeyOwnerBucket = []byte("correlator_key_owner")
+	correlatorFindingBucket  = []byte("correlator_findings")
+)
+
+// Correlator clusters findings that describe the same underlying host or
+// resource even when DeduplicationKey differs — the same host reported by
+// one collector as a hostname and by another as an IP address, or a single
+// CVE that fans out into one finding per affected package. It maintains a
+// union-find over finding IDs keyed by multiple identity signals, so two
+// findings merge the moment they're shown to share one.
+//
+// Two findings merge when they share any strong identifier (serial number,
+// cloud instance ARN, MAC address) OR when they share a hostname AND either
+// an IP address within the same account/region or a resource ID prefix
+// within the same account/region. The union-find is persisted to BoltDB so
+// clusters survive restarts.
+type Correlator struct {
+	mu sync.Mutex
+	db *bolt.DB
+
+	parent   map[string]string   // finding ID -> parent finding ID
+	keyOwner map[string]string   // identity key -> a finding ID already carrying it
+	findings map[string]*Finding // finding ID -> most recently seen copy
+}
+
+// NewCorrelator opens (creating if necessary) a BoltDB file at path and
+// loads any previously persisted clusters into memory.
+func NewCorrelator(path string) (*Correlator, error) {
+	db, err := bolt.Open(path, 0600, nil)
+	if err != nil {
+		return nil, fmt.Errorf("opening correlator db %s: %w", path, err)
+	}
+
+	if err := db.Update(func(tx *bolt.Tx) error {
+		for _, bucket := range [][]byte{correlatorParentBucket, correlatorKeyOwnerBucket, correlatorFindingBucket} {
+			if _, err := tx.CreateBucketIfNotExists(bucket); err != nil {
+				return err
+			}
+		}
+		return nil
+	}); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("creating correlator buckets in %s: %w", path, err)
+	}
+
+	c := &Correlator{
+		db:       db,
+		parent:   make(map[string]string),
+		keyOwner: make(map[string]string),
+		findings: make(map[string]*Finding),
+	}
+
+	if err := c.load(); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("loading correlator state from %s: %w", path, err)
+	}
+
+	return c, nil
+}
+
+func (c *Correlator) load() error {
+	return c.db.View(func(tx *bolt.Tx) error {
+		if err := tx.Bucket(correlatorParentBucket).ForEach(func(k, v []byte) error {
+			c.parent[string(k)] = string(v)
+			return nil
+		}); err != nil {
+			return err
+		}
+
+		if err := tx.Bucket(correlatorKeyOwnerBucket).ForEach(func(k, v []byte) error {
+			c.keyOwner[string(k)] = string(v)
+			return nil
+		}); err != nil {
+			return err
+		}
+
+		return tx.Bucket(correlatorFindingBucket).ForEach(func(k, v []byte) error {
+			var f Finding
+			if err := json.Unmarshal(v, &f); err != nil {
+				return fmt.Errorf("decoding persisted finding %s: %w", k, err)
+			}
+			c.findings[string(k)] = &f
+			return nil
+		})
+	})
+}
+
+// GroupID feeds f into the correlator, merging its cluster with any other
+// finding that shares a strong or weak identifier, and returns the stable
+// cluster ID (the union-find root's finding ID). It also sets
+// f.CorrelationID to that value. Safe for concurrent use.
+func (c *Correlator) GroupID(f *Finding) (string, error) {
+	if f.ID == "" {
+		return "", fmt.Errorf("correlator: finding has no ID")
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	touched := map[string]string{f.ID: f.ID}
+	if _, ok := c.parent[f.ID]; !ok {
+		c.parent[f.ID] = f.ID
+	}
+
+	var newKeys []string
+	for _, key := range identityKeys(f) {
+		owner, ok := c.keyOwner[key]
+		if !ok {
+			c.keyOwner[key] = f.ID
+			newKeys = append(newKeys, key)
+			continue
+		}
+		root, merged := c.union(f.ID, owner)
+		touched[root] = root
+		if merged != "" {
+			touched[merged] = merged
+		}
+	}
+
+	c.findings[f.ID] = f
+	root := c.find(f.ID)
+	f.CorrelationID = root
+
+	if err := c.persist(touched, newKeys, f); err != nil {
+		return "", err
+	}
+
+	return root, nil
+}
+
+// Related returns every finding currently clustered with id, where id is
+// either a finding ID or a cluster ID previously returned by GroupID. It
+// returns an empty slice if id is unknown.
+func (c *Correlator) Related(id string) []*Finding {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, ok := c.parent[id]; !ok {
+		return nil
+	}
+	root := c.find(id)
+
+	var related []*Finding
+	for findingID, f := range c.findings {
+		if c.find(findingID) == root {
+			related = append(related, f)
+		}
+	}
+	return related
+}
+
+// find returns id's cluster root, compressing the path it walks.
+func (c *Correlator) find(id string) string {
+	root := id
+	for c.parent[root] != root {
+		root = c.parent[root]
+	}
+	for c.parent[id] != root {
+		c.parent[id], id = root, c.parent[id]
+	}
+	return root
+}
+
+// union merges the clusters containing a and b. It returns the surviving
+// root and the root that was merged into it (empty if a and b were already
+// in the same cluster). The smaller root ID wins arbitrarily but
+// deterministically, so the same two clusters always merge to the same root
+// regardless of merge order.
+func (c *Correlator) union(a, b string) (root, merged string) {
+	rootA, rootB := c.find(a), c.find(b)
+	if rootA == rootB {
+		return rootA, ""
+	}
+	if rootA > rootB {
+		rootA, rootB = rootB, rootA
+	}
+	c.parent[rootB] = rootA
+	return rootA, rootB
+}
+
+// persist writes every finding ID in touched (now pointing at a possibly new
+// parent), any newly claimed identity keys, and f itself to BoltDB. Callers
+// must hold c.mu.
+func (c *Correlator) persist(touched map[string]string, newKeys []string, f *Finding) error {
+	encoded, err := json.Marshal(f)
+	if err != nil {
+		return fmt.Errorf("encoding finding %s: %w", f.ID, err)
+	}
+
+	return c.db.Update(func(tx *bolt.Tx) error {
+		parentBucket := tx.Bucket(correlatorParentBucket)
+		for id := range touched {
+			if err := parentBucket.Put([]byte(id), []byte(c.parent[id])); err != nil {
+				return err
+			}
+		}
+
+		keyOwnerBucket := tx.Bucket(correlatorKeyOwnerBucket)
+		for _, key := range newKeys {
+			if err := keyOwnerBucket.Put([]byte(key), []byte(c.keyOwner[key])); err != nil {
+				return err
+			}
+		}
+
+		return tx.Bucket(correlatorFindingBucket).Put([]byte(f.ID), encoded)
+	})
+}
+
+// Close closes the underlying BoltDB file.
+func (c *Correlator) Close() error {
+	return c.db.Close()
+}
+
+// resourceIDPrefix heuristically strips the last "-"-delimited segment off a
+// resource ID, so sibling resources like "vm-web-01" and "vm-web-02" share a
+// prefix while unrelated resources don't.
+func resourceIDPrefix(resourceID string) string {
+	idx := strings.LastIndex(resourceID, "-")
+	if idx <= 0 {
+		return resourceID
+	}
+	return resourceID[:idx]
+}
+
+// identityKeys returns every identity signal f carries, strong and weak, as
+// namespaced union-find keys. Two findings sharing any one of these keys are
+// merged into the same cluster.
+func identityKeys(f *Finding) []string {
+	var keys []string
+
+	if f.SerialNumber != "" {
+		keys = append(keys, "serial:"+f.SerialNumber)
+	}
+	if f.InstanceARN != "" {
+		keys = append(keys, "arn:"+f.InstanceARN)
+	}
+	if f.MACAddress != "" {
+		keys = append(keys, "mac:"+f.MACAddress)
+	}
+
+	// Weak identifiers only merge when paired with a shared hostname: the
+	// key encodes both the hostname and the weak signal together, so
+	// sharing the IP or resource prefix alone (without the same hostname)
+	// never causes a merge.
+	if f.Hostname != "" {
+		if f.IPAddress != "" {
+			keys = append(keys, fmt.Sprintf("host-ip:%s|%s|%s|%s", f.Hostname, f.AccountID, f.Region, f.IPAddress))
+		}
+		if f.ResourceID != "" {
+			keys = append(keys, fmt.Sprintf("host-prefix:%s|%s|%s|%s", f.Hostname, f.AccountID, f.Region, resourceIDPrefix(f.ResourceID)))
+		}
+	}
+
+	return keys
+}
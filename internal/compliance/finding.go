@@ -117,6 +117,10 @@ type Finding struct {
 	Hostname     string `json:"hostname,omitempty"`
 	SerialNumber string `json:"serial_number,omitempty"`
 	IPAddress    string `json:"ip_address,omitempty"`
+	MACAddress   string `json:"mac_address,omitempty"`
+
+	// Cloud Identification
+	InstanceARN string `json:"instance_arn,omitempty"`
 
 	// Platform & Environment
 	Platform        Platform        `json:"platform"`
@@ -167,6 +171,13 @@ type Finding struct {
 	CanonicalRuleID  string   `json:"canonical_rule_id"`
 	RelatedRules     []string `json:"related_rules,omitempty"`
 
+	// CorrelationID is the stable cluster ID Correlator assigns to this
+	// finding, grouping it with others that describe the same underlying
+	// host or resource even when DeduplicationKey differs. Populated by
+	// Correlator.GroupID before ticketing, so one ticket is opened per
+	// cluster rather than per raw finding.
+	CorrelationID string `json:"correlation_id,omitempty"`
+
 	// Ticketing
 	TicketID     string `json:"ticket_id,omitempty"`
 	TicketURL    string `json:"ticket_url,omitempty"`
@@ -0,0 +1,342 @@
+package compliance
+
+import "fmt"
+
+// OCSF class UIDs this package knows how to serialize a Finding as. See
+// https://schema.ocsf.io/classes/security_finding and
+// https://schema.ocsf.io/classes/vulnerability_finding.
+const (
+	OCSFClassSecurityFinding      = 2001
+	OCSFClassVulnerabilityFinding = 2002
+)
+
+// ocsfCategoryUID is the OCSF "Findings" category every class ToOCSF
+// supports belongs to.
+const ocsfCategoryUID = 2
+
+// ocsfProductName/ocsfProductVersion populate metadata.product for every
+// Finding this hub emits in OCSF form.
+const (
+	ocsfProductName    = "threat-telemetry-hub"
+	ocsfProductVersion = "1.0.0"
+)
+
+// severityToOCSF maps our lowercase Severity strings onto the OCSF
+// severity_id enum (0 Unknown, 1 Informational, 2 Low, 3 Medium, 4 High,
+// 5 Critical, 6 Fatal, 99 Other).
+var severityToOCSF = map[string]int{
+	"informational": 1,
+	"info":          1,
+	"low":           2,
+	"medium":        3,
+	"high":          4,
+	"critical":      5,
+	"fatal":         6,
+}
+
+var ocsfToSeverity = map[int]string{
+	1: "informational",
+	2: "low",
+	3: "medium",
+	4: "high",
+	5: "critical",
+	6: "fatal",
+}
+
+// statusToOCSF maps our WorkflowStatus onto the OCSF finding status_id enum
+// (0 Unknown, 1 New, 2 In Progress, 3 Suppressed, 4 Resolved, 99 Other).
+var statusToOCSF = map[WorkflowStatus]int{
+	StatusNew:           1,
+	StatusTriaged:       1,
+	StatusAssigned:      2,
+	StatusInProgress:    2,
+	StatusRemediated:    4,
+	StatusClosed:        4,
+	StatusSuppressed:    3,
+	StatusFalsePositive: 99,
+}
+
+var ocsfToStatus = map[int]WorkflowStatus{
+	1:  StatusNew,
+	2:  StatusInProgress,
+	3:  StatusSuppressed,
+	4:  StatusRemediated,
+	99: StatusFalsePositive,
+}
+
+// ToOCSF serializes f as an OCSF event of the given class (OCSFClassSecurityFinding
+// or OCSFClassVulnerabilityFinding). Fields with no direct OCSF equivalent
+// are preserved under "unmapped" rather than dropped. FromOCSF does not
+// restore all of f: FirstFoundAt, LastSeenAt, AIContextualFactors, CWEs,
+// RemediationSteps, RelatedRules and Tags are not read back, and
+// MITRETactics come back only when f has at least one MITRETechnique.
+func (f *Finding) ToOCSF(classUID int) (map[string]interface{}, error) {
+	if classUID != OCSFClassSecurityFinding && classUID != OCSFClassVulnerabilityFinding {
+		return nil, fmt.Errorf("compliance: unsupported OCSF class_uid %d", classUID)
+	}
+
+	severityID, ok := severityToOCSF[f.Severity]
+	if !ok {
+		severityID = 0
+	}
+
+	event := map[string]interface{}{
+		"class_uid":    classUID,
+		"category_uid": ocsfCategoryUID,
+		"severity_id":  severityID,
+		"severity":     f.Severity,
+		"status_id":    statusToOCSF[f.WorkflowStatus],
+		"status":       string(f.WorkflowStatus),
+		"time":         f.FirstFoundAt.Unix(),
+		"metadata": map[string]interface{}{
+			"product": map[string]interface{}{
+				"name":        ocsfProductName,
+				"vendor_name": f.Source,
+			},
+			"version": ocsfProductVersion,
+		},
+		"finding_info": map[string]interface{}{
+			"uid":          f.ID,
+			"title":        f.Title,
+			"desc":         f.Description,
+			"created_time": f.FirstFoundAt.Unix(),
+		},
+		"resources": []interface{}{
+			map[string]interface{}{
+				"type":           string(f.ResourceType),
+				"uid":            f.ResourceID,
+				"name":           f.ResourceName,
+				"hostname":       f.Hostname,
+				"ip":             f.IPAddress,
+				"cloud_provider": string(f.CloudProvider),
+				"region":         f.Region,
+				"account_uid":    f.AccountID,
+			},
+		},
+	}
+
+	if len(f.CVEs) > 0 {
+		cves := make([]interface{}, 0, len(f.CVEs))
+		for _, cve := range f.CVEs {
+			cves = append(cves, map[string]interface{}{
+				"uid": cve.ID,
+				"cvss": []interface{}{
+					map[string]interface{}{
+						"vector_string": cve.CVSSVector,
+						"base_score":    cve.CVSS,
+					},
+				},
+			})
+		}
+		event["cve"] = cves
+	}
+
+	if len(f.MITRETactics) > 0 || len(f.MITRETechniques) > 0 {
+		attacks := make([]interface{}, 0, len(f.MITRETechniques))
+		for _, technique := range f.MITRETechniques {
+			attacks = append(attacks, map[string]interface{}{
+				"technique": map[string]interface{}{"uid": technique},
+				"tactics":   f.MITRETactics,
+			})
+		}
+		event["attacks"] = attacks
+	}
+
+	event["unmapped"] = f.unmappedFields()
+
+	return event, nil
+}
+
+// unmappedFields carries every Finding field with no OCSF equivalent above.
+// FromOCSF reads back the string and numeric ones; the list, map and time
+// values (ai_contextual_factors, cwes, remediation_steps, related_rules,
+// last_seen_at, tags) are not decoded.
+func (f *Finding) unmappedFields() map[string]interface{} {
+	return map[string]interface{}{
+		"source_finding_id":     f.SourceFindingID,
+		"type":                  string(f.Type),
+		"category":              string(f.Category),
+		"platform":              string(f.Platform),
+		"environment_type":      string(f.EnvironmentType),
+		"static_severity":       f.StaticSeverity,
+		"ai_risk_score":         f.AIRiskScore,
+		"ai_risk_level":         f.AIRiskLevel,
+		"ai_risk_rationale":     f.AIRiskRationale,
+		"ai_contextual_factors": f.AIContextualFactors,
+		"cwes":                  f.CWEs,
+		"remediation":           f.Remediation,
+		"remediation_steps":     f.RemediationSteps,
+		"deduplication_key":     f.DeduplicationKey,
+		"canonical_rule_id":     f.CanonicalRuleID,
+		"related_rules":         f.RelatedRules,
+		"service_name":          f.ServiceName,
+		"line_of_business":      f.LineOfBusiness,
+		"team":                  f.Team,
+		"last_seen_at":          f.LastSeenAt,
+		"ticket_id":             f.TicketID,
+		"ticket_url":            f.TicketURL,
+		"ticket_status":         f.TicketStatus,
+		"tags":                  f.Tags,
+	}
+}
+
+// FromOCSF decodes an OCSF Security Finding or Vulnerability Finding event
+// (as produced by ToOCSF, or emitted by another tool using the same
+// classes) back into a Finding.
+func FromOCSF(event map[string]interface{}) (*Finding, error) {
+	classUID, _ := toInt(event["class_uid"])
+	if classUID != OCSFClassSecurityFinding && classUID != OCSFClassVulnerabilityFinding {
+		return nil, fmt.Errorf("compliance: unsupported OCSF class_uid %v", event["class_uid"])
+	}
+
+	f := &Finding{}
+
+	if severityID, ok := toInt(event["severity_id"]); ok {
+		f.Severity = ocsfToSeverity[severityID]
+	}
+	if statusID, ok := toInt(event["status_id"]); ok {
+		f.WorkflowStatus = ocsfToStatus[statusID]
+	}
+
+	if metadata, ok := event["metadata"].(map[string]interface{}); ok {
+		if product, ok := metadata["product"].(map[string]interface{}); ok {
+			f.Source, _ = product["vendor_name"].(string)
+		}
+	}
+
+	if info, ok := event["finding_info"].(map[string]interface{}); ok {
+		f.ID, _ = info["uid"].(string)
+		f.Title, _ = info["title"].(string)
+		f.Description, _ = info["desc"].(string)
+	}
+
+	if resources, ok := event["resources"].([]interface{}); ok && len(resources) > 0 {
+		if resource, ok := resources[0].(map[string]interface{}); ok {
+			f.ResourceType, _ = resourceType(resource["type"])
+			f.ResourceID, _ = resource["uid"].(string)
+			f.ResourceName, _ = resource["name"].(string)
+			f.Hostname, _ = resource["hostname"].(string)
+			f.IPAddress, _ = resource["ip"].(string)
+			f.CloudProvider, _ = cloudProvider(resource["cloud_provider"])
+			f.Region, _ = resource["region"].(string)
+			f.AccountID, _ = resource["account_uid"].(string)
+		}
+	}
+
+	if cves, ok := event["cve"].([]interface{}); ok {
+		for _, raw := range cves {
+			cveMap, ok := raw.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			cve := CVEReference{}
+			cve.ID, _ = cveMap["uid"].(string)
+			if cvss, ok := cveMap["cvss"].([]interface{}); ok && len(cvss) > 0 {
+				if first, ok := cvss[0].(map[string]interface{}); ok {
+					cve.CVSSVector, _ = first["vector_string"].(string)
+					if score, ok := toFloat(first["base_score"]); ok {
+						cve.CVSS = score
+					}
+				}
+			}
+			cve.BuildCVEURLs()
+			f.CVEs = append(f.CVEs, cve)
+		}
+	}
+
+	if attacks, ok := event["attacks"].([]interface{}); ok {
+		for _, raw := range attacks {
+			attackMap, ok := raw.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			if technique, ok := attackMap["technique"].(map[string]interface{}); ok {
+				if uid, ok := technique["uid"].(string); ok {
+					f.MITRETechniques = append(f.MITRETechniques, uid)
+				}
+			}
+			// ToOCSF repeats the full tactic list on every attack, so
+			// collect each tactic once.
+			if tactics, ok := attackMap["tactics"].([]string); ok {
+				for _, tactic := range tactics {
+					known := false
+					for _, have := range f.MITRETactics {
+						if have == tactic {
+							known = true
+							break
+						}
+					}
+					if !known {
+						f.MITRETactics = append(f.MITRETactics, tactic)
+					}
+				}
+			}
+		}
+	}
+
+	if unmapped, ok := event["unmapped"].(map[string]interface{}); ok {
+		f.SourceFindingID, _ = unmapped["source_finding_id"].(string)
+		f.Type = FindingType(stringOr(unmapped["type"]))
+		f.Category = FindingCategory(stringOr(unmapped["category"]))
+		f.Platform = Platform(stringOr(unmapped["platform"]))
+		f.EnvironmentType = EnvironmentType(stringOr(unmapped["environment_type"]))
+		f.StaticSeverity, _ = unmapped["static_severity"].(string)
+		f.AIRiskLevel, _ = unmapped["ai_risk_level"].(string)
+		f.AIRiskRationale, _ = unmapped["ai_risk_rationale"].(string)
+		if score, ok := toFloat(unmapped["ai_risk_score"]); ok {
+			f.AIRiskScore = score
+		}
+		f.Remediation, _ = unmapped["remediation"].(string)
+		f.DeduplicationKey, _ = unmapped["deduplication_key"].(string)
+		f.CanonicalRuleID, _ = unmapped["canonical_rule_id"].(string)
+		f.ServiceName, _ = unmapped["service_name"].(string)
+		f.LineOfBusiness, _ = unmapped["line_of_business"].(string)
+		f.Team, _ = unmapped["team"].(string)
+		f.TicketID, _ = unmapped["ticket_id"].(string)
+		f.TicketURL, _ = unmapped["ticket_url"].(string)
+		f.TicketStatus, _ = unmapped["ticket_status"].(string)
+	}
+
+	return f, nil
+}
+
+func resourceType(v interface{}) (ResourceType, bool) {
+	s, ok := v.(string)
+	return ResourceType(s), ok
+}
+
+func cloudProvider(v interface{}) (CloudProvider, bool) {
+	s, ok := v.(string)
+	return CloudProvider(s), ok
+}
+
+func stringOr(v interface{}) string {
+	s, _ := v.(string)
+	return s
+}
+
+func toInt(v interface{}) (int, bool) {
+	switch n := v.(type) {
+	case int:
+		return n, true
+	case int64:
+		return int(n), true
+	case float64:
+		return int(n), true
+	default:
+		return 0, false
+	}
+}
+
+func toFloat(v interface{}) (float64, bool) {
+	switch n := v.(type) {
+	case float64:
+		return n, true
+	case int:
+		return float64(n), true
+	case int64:
+		return float64(n), true
+	default:
+		return 0, false
+	}
+}
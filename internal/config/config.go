@@ -1,286 +1,209 @@
-// Package config handles configuration loading for Threat Telemetry Hub
+// Package config handles configuration loading for Threat Telemetry Hub.
+//
+// The struct tree itself lives in config/v1beta1, the current hub schema
+// every other version converts through (see that package's doc comment
+// for the conversion-hub pattern this follows). Every exported type below
+// is a type alias for its v1beta1 counterpart, so existing code that
+// writes config.AIConfig, config.ServerConfig, etc. keeps compiling
+// unchanged as new schema versions are added.
 package config
 
 import (
+	"bytes"
 	"fmt"
 	"os"
 
 	"gopkg.in/yaml.v3"
-)
-
-// Config represents the application configuration
-type Config struct {
-	Server        ServerConfig        `yaml:"server"`
-	AI            AIConfig            `yaml:"ai"`
-	Ingestion     IngestionConfig     `yaml:"ingestion"`
-	Normalization NormalizationConfig `yaml:"normalization"`
-	Enrichment    EnrichmentConfig    `yaml:"enrichment"`
-	Output        OutputConfig        `yaml:"output"`
-	DRBC          DRBCConfig          `yaml:"dr_bc"`
-}
-
-// ServerConfig represents HTTP server configuration
-type ServerConfig struct {
-	Port         int    `yaml:"port"`
-	ReadTimeout  int    `yaml:"read_timeout"`
-	WriteTimeout int    `yaml:"write_timeout"`
-}
-
-// AIConfig represents AI provider configuration
-// Supports both Anthropic (Claude) and OpenAI for flexibility
-type AIConfig struct {
-	Provider  string          `yaml:"provider"` // "anthropic" or "openai"
-	Anthropic AnthropicConfig `yaml:"anthropic"`
-	OpenAI    OpenAIConfig    `yaml:"openai"`
-}
-
-// AnthropicConfig represents Anthropic Claude configuration
-type AnthropicConfig struct {
-	Model     string `yaml:"model"`
-	APIKeyEnv string `yaml:"api_key_env"`
-}
-
-// OpenAIConfig represents OpenAI configuration
-type OpenAIConfig struct {
-	Model     string `yaml:"model"`
-	APIKeyEnv string `yaml:"api_key_env"`
-}
-
-// IngestionConfig represents data ingestion sources
-type IngestionConfig struct {
-	EDR   EDRConfig   `yaml:"edr"`
-	SIEM  SIEMConfig  `yaml:"siem"`
-	Cloud CloudConfig `yaml:"cloud"`
-	DLP   DLPConfig   `yaml:"dlp"`
-}
-
-// EDRConfig represents EDR tool integrations
-type EDRConfig struct {
-	CrowdStrike  CrowdStrikeConfig  `yaml:"crowdstrike"`
-	SentinelOne  SentinelOneConfig  `yaml:"sentinelone"`
-	Defender     DefenderConfig     `yaml:"defender"`
-	CarbonBlack  CarbonBlackConfig  `yaml:"carbon_black"`
-}
-
-// CrowdStrikeConfig represents CrowdStrike Falcon configuration
-type CrowdStrikeConfig struct {
-	Enabled         bool   `yaml:"enabled"`
-	APIURL          string `yaml:"api_url"`
-	ClientIDEnv     string `yaml:"client_id_env"`
-	ClientSecretEnv string `yaml:"client_secret_env"`
-}
-
-// SentinelOneConfig represents SentinelOne configuration
-type SentinelOneConfig struct {
-	Enabled     bool   `yaml:"enabled"`
-	APIURL      string `yaml:"api_url"`
-	APITokenEnv string `yaml:"api_token_env"`
-}
-
-// DefenderConfig represents Microsoft Defender for Endpoint configuration
-type DefenderConfig struct {
-	Enabled     bool   `yaml:"enabled"`
-	TenantIDEnv string `yaml:"tenant_id_env"`
-	ClientIDEnv string `yaml:"client_id_env"`
-	SecretEnv   string `yaml:"secret_env"`
-}
-
-// CarbonBlackConfig represents VMware Carbon Black configuration
-type CarbonBlackConfig struct {
-	Enabled     bool   `yaml:"enabled"`
-	APIURL      string `yaml:"api_url"`
-	APIKeyEnv   string `yaml:"api_key_env"`
-	APISecretEnv string `yaml:"api_secret_env"`
-}
-
-// SIEMConfig represents SIEM integrations
-type SIEMConfig struct {
-	Splunk        SplunkConfig        `yaml:"splunk"`
-	Sentinel      SentinelConfig      `yaml:"sentinel"`
-	Elasticsearch ElasticsearchConfig `yaml:"elasticsearch"`
-}
-
-// SplunkConfig represents Splunk HEC configuration
-type SplunkConfig struct {
-	Enabled     bool   `yaml:"enabled"`
-	HECURL      string `yaml:"hec_url"`
-	HECTokenEnv string `yaml:"hec_token_env"`
-}
-
-// SentinelConfig represents Microsoft Sentinel configuration
-type SentinelConfig struct {
-	Enabled         bool   `yaml:"enabled"`
-	WorkspaceID     string `yaml:"workspace_id"`
-	SharedKeyEnv    string `yaml:"shared_key_env"`
-}
-
-// ElasticsearchConfig represents Elasticsearch configuration
-type ElasticsearchConfig struct {
-	Enabled   bool     `yaml:"enabled"`
-	Addresses []string `yaml:"addresses"`
-	Username  string   `yaml:"username"`
-	PasswordEnv string `yaml:"password_env"`
-	Index     string   `yaml:"index"`
-}
-
-// CloudConfig represents cloud audit log sources
-type CloudConfig struct {
-	AWS   AWSCloudConfig   `yaml:"aws"`
-	Azure AzureCloudConfig `yaml:"azure"`
-	GCP   GCPCloudConfig   `yaml:"gcp"`
-}
-
-// AWSCloudConfig represents AWS CloudTrail configuration
-type AWSCloudConfig struct {
-	Enabled     bool   `yaml:"enabled"`
-	Region      string `yaml:"region"`
-	RoleARN     string `yaml:"role_arn"`
-	TrailName   string `yaml:"trail_name"`
-}
-
-// AzureCloudConfig represents Azure Activity Log configuration
-type AzureCloudConfig struct {
-	Enabled        bool   `yaml:"enabled"`
-	SubscriptionID string `yaml:"subscription_id"`
-	TenantIDEnv    string `yaml:"tenant_id_env"`
-}
-
-// GCPCloudConfig represents GCP Audit Log configuration
-type GCPCloudConfig struct {
-	Enabled   bool   `yaml:"enabled"`
-	ProjectID string `yaml:"project_id"`
-}
-
-// DLPConfig represents DLP tool integrations (COTS aggregation)
-type DLPConfig struct {
-	DigitalGuardian DigitalGuardianConfig `yaml:"digital_guardian"`
-	Proofpoint      ProofpointConfig      `yaml:"proofpoint"`
-	Purview         PurviewConfig         `yaml:"purview"`
-	Netskope        NetskopeConfig        `yaml:"netskope"`
-}
-
-// DigitalGuardianConfig represents Digital Guardian DLP configuration
-type DigitalGuardianConfig struct {
-	Enabled     bool   `yaml:"enabled"`
-	APIURL      string `yaml:"api_url"`
-	APIKeyEnv   string `yaml:"api_key_env"`
-}
-
-// ProofpointConfig represents Proofpoint DLP configuration
-type ProofpointConfig struct {
-	Enabled       bool   `yaml:"enabled"`
-	APIURL        string `yaml:"api_url"`
-	APIKeyEnv     string `yaml:"api_key_env"`
-	WebhookSecret string `yaml:"webhook_secret_env"`
-}
-
-// PurviewConfig represents Microsoft Purview DLP configuration
-type PurviewConfig struct {
-	Enabled     bool   `yaml:"enabled"`
-	TenantIDEnv string `yaml:"tenant_id_env"`
-	ClientIDEnv string `yaml:"client_id_env"`
-	SecretEnv   string `yaml:"secret_env"`
-}
-
-// NetskopeConfig represents Netskope DLP configuration
-type NetskopeConfig struct {
-	Enabled     bool   `yaml:"enabled"`
-	TenantURL   string `yaml:"tenant_url"`
-	APITokenEnv string `yaml:"api_token_env"`
-}
-
-// NormalizationConfig represents schema normalization settings
-type NormalizationConfig struct {
-	DefaultSchema string `yaml:"default_schema"` // "ocsf" or "ecs"
-}
-
-// EnrichmentConfig represents enrichment sources
-type EnrichmentConfig struct {
-	ThreatForge ThreatForgeConfig `yaml:"threatforge"`
-	Identity    IdentityConfig    `yaml:"identity"`
-	Asset       AssetConfig       `yaml:"asset"`
-}
-
-// ThreatForgeConfig represents threatforge integration
-type ThreatForgeConfig struct {
-	Enabled bool   `yaml:"enabled"`
-	APIURL  string `yaml:"api_url"`
-}
-
-// IdentityConfig represents identity enrichment sources
-type IdentityConfig struct {
-	EntraID EntraIDConfig `yaml:"entra_id"`
-	Okta    OktaConfig    `yaml:"okta"`
-}
 
-// EntraIDConfig represents Microsoft Entra ID configuration
-type EntraIDConfig struct {
-	Enabled     bool   `yaml:"enabled"`
-	TenantIDEnv string `yaml:"tenant_id_env"`
-	ClientIDEnv string `yaml:"client_id_env"`
-	SecretEnv   string `yaml:"secret_env"`
-}
-
-// OktaConfig represents Okta configuration
-type OktaConfig struct {
-	Enabled     bool   `yaml:"enabled"`
-	Domain      string `yaml:"domain"`
-	APITokenEnv string `yaml:"api_token_env"`
-}
+	"github.com/lvonguyen/threat-telemetry-hub/internal/config/v1alpha1"
+	"github.com/lvonguyen/threat-telemetry-hub/internal/config/v1beta1"
+)
 
-// AssetConfig represents asset/CMDB enrichment
-type AssetConfig struct {
-	Enabled bool   `yaml:"enabled"`
-	APIURL  string `yaml:"api_url"`
-}
+type (
+	Config                      = v1beta1.Config
+	EventingConfig              = v1beta1.EventingConfig
+	HTTPTransportConfig         = v1beta1.HTTPTransportConfig
+	KafkaTransportConfig        = v1beta1.KafkaTransportConfig
+	MQTTTransportConfig         = v1beta1.MQTTTransportConfig
+	PipelineConfig              = v1beta1.PipelineConfig
+	PipelineDefinition          = v1beta1.PipelineDefinition
+	BufferConfig                = v1beta1.BufferConfig
+	ObservabilityConfig         = v1beta1.ObservabilityConfig
+	ClusterHealthConfig         = v1beta1.ClusterHealthConfig
+	ServerConfig                = v1beta1.ServerConfig
+	AIConfig                    = v1beta1.AIConfig
+	AIBatchConfig               = v1beta1.AIBatchConfig
+	AICacheConfig               = v1beta1.AICacheConfig
+	AICacheRedisConfig          = v1beta1.AICacheRedisConfig
+	AnthropicConfig             = v1beta1.AnthropicConfig
+	OpenAIConfig                = v1beta1.OpenAIConfig
+	OllamaConfig                = v1beta1.OllamaConfig
+	LlamaCppConfig              = v1beta1.LlamaCppConfig
+	BedrockConfig               = v1beta1.BedrockConfig
+	VertexConfig                = v1beta1.VertexConfig
+	RedactionConfig             = v1beta1.RedactionConfig
+	IngestionConfig             = v1beta1.IngestionConfig
+	CheckpointConfig            = v1beta1.CheckpointConfig
+	BoltCheckpointConfig        = v1beta1.BoltCheckpointConfig
+	PostgresCheckpointConfig    = v1beta1.PostgresCheckpointConfig
+	RedisCheckpointConfig       = v1beta1.RedisCheckpointConfig
+	EDRConfig                   = v1beta1.EDRConfig
+	CrowdStrikeConfig           = v1beta1.CrowdStrikeConfig
+	SentinelOneConfig           = v1beta1.SentinelOneConfig
+	DefenderConfig              = v1beta1.DefenderConfig
+	CarbonBlackConfig           = v1beta1.CarbonBlackConfig
+	SIEMConfig                  = v1beta1.SIEMConfig
+	SplunkConfig                = v1beta1.SplunkConfig
+	SentinelConfig              = v1beta1.SentinelConfig
+	ElasticsearchConfig         = v1beta1.ElasticsearchConfig
+	CloudConfig                 = v1beta1.CloudConfig
+	AWSCloudConfig              = v1beta1.AWSCloudConfig
+	AzureCloudConfig            = v1beta1.AzureCloudConfig
+	GCPCloudConfig              = v1beta1.GCPCloudConfig
+	DLPConfig                   = v1beta1.DLPConfig
+	DigitalGuardianConfig       = v1beta1.DigitalGuardianConfig
+	ProofpointConfig            = v1beta1.ProofpointConfig
+	PurviewConfig               = v1beta1.PurviewConfig
+	NetskopeConfig              = v1beta1.NetskopeConfig
+	IPSConfig                   = v1beta1.IPSConfig
+	CrowdSecConfig              = v1beta1.CrowdSecConfig
+	NormalizationConfig         = v1beta1.NormalizationConfig
+	EnrichmentConfig            = v1beta1.EnrichmentConfig
+	ThreatForgeConfig           = v1beta1.ThreatForgeConfig
+	IdentityConfig              = v1beta1.IdentityConfig
+	EntraIDConfig               = v1beta1.EntraIDConfig
+	OktaConfig                  = v1beta1.OktaConfig
+	AssetConfig                 = v1beta1.AssetConfig
+	DetectionConfig             = v1beta1.DetectionConfig
+	CorrelationConfig           = v1beta1.CorrelationConfig
+	OutputConfig                = v1beta1.OutputConfig
+	TicketingConfig             = v1beta1.TicketingConfig
+	ServiceNowIntegrationConfig = v1beta1.ServiceNowIntegrationConfig
+	TicketingAuthzConfig        = v1beta1.TicketingAuthzConfig
+	SplunkOutputConfig          = v1beta1.SplunkOutputConfig
+	ElasticsearchOutputConfig   = v1beta1.ElasticsearchOutputConfig
+	ExportConfig                = v1beta1.ExportConfig
+	OTLPExportConfig            = v1beta1.OTLPExportConfig
+	AppInsightsExportConfig     = v1beta1.AppInsightsExportConfig
+	KafkaExportConfig           = v1beta1.KafkaExportConfig
+	DRBCConfig                  = v1beta1.DRBCConfig
+)
 
-// OutputConfig represents output destinations
-type OutputConfig struct {
-	Splunk        SplunkOutputConfig        `yaml:"splunk"`
-	Elasticsearch ElasticsearchOutputConfig `yaml:"elasticsearch"`
-}
+// Hub and Convertible re-export v1beta1's conversion-hub interfaces so
+// callers outside internal/config/v1beta1 don't need to import it
+// directly just to talk about them.
+type (
+	Hub         = v1beta1.Hub
+	Convertible = v1beta1.Convertible
+)
 
-// SplunkOutputConfig represents Splunk HEC output
-type SplunkOutputConfig struct {
-	Enabled     bool   `yaml:"enabled"`
-	HECURL      string `yaml:"hec_url"`
-	HECTokenEnv string `yaml:"hec_token_env"`
-	Index       string `yaml:"index"`
-	SourceType  string `yaml:"sourcetype"`
+// LoadOptions configures LoadWithOptions.
+type LoadOptions struct {
+	// Strict rejects YAML documents containing fields this version of
+	// Config (or, for an older apiVersion, that version's schema)
+	// doesn't recognize, instead of silently dropping them the way
+	// yaml.Unmarshal does by default. A typo like "crowdstirke:" is
+	// caught at load time rather than surfacing later as a collector
+	// that never starts.
+	Strict bool
 }
 
-// ElasticsearchOutputConfig represents Elasticsearch output
-type ElasticsearchOutputConfig struct {
-	Enabled   bool     `yaml:"enabled"`
-	Addresses []string `yaml:"addresses"`
-	Index     string   `yaml:"index"`
+// versionProbe reads just enough of a YAML document to tell which config
+// API version it's written in, before committing to unmarshaling it into
+// a specific Go type.
+type versionProbe struct {
+	APIVersion string `yaml:"apiVersion"`
 }
 
-// DRBCConfig represents DR/BC configuration
-type DRBCConfig struct {
-	Enabled        bool   `yaml:"enabled"`
-	PrimaryRegion  string `yaml:"primary_region"`
-	FailoverRegion string `yaml:"failover_region"`
-	RPOMinutes     int    `yaml:"rpo_minutes"`
-	RTOMinutes     int    `yaml:"rto_minutes"`
+// Load reads configuration from a YAML file. It is equivalent to
+// LoadWithOptions(path, LoadOptions{}).
+func Load(path string) (*Config, error) {
+	return LoadWithOptions(path, LoadOptions{})
 }
 
-// Load reads configuration from a YAML file
-func Load(path string) (*Config, error) {
+// LoadWithOptions reads configuration from a YAML file, detecting its
+// apiVersion and converting up to the current hub schema (v1beta1) if
+// the file was written in an older one. A document with no apiVersion
+// key - true of every config file written before this key existed - is
+// treated as the hub schema, not as an error.
+func LoadWithOptions(path string, opts LoadOptions) (*Config, error) {
 	data, err := os.ReadFile(path)
 	if err != nil {
 		return nil, fmt.Errorf("reading config file: %w", err)
 	}
 
-	var cfg Config
-	if err := yaml.Unmarshal(data, &cfg); err != nil {
+	var probe versionProbe
+	if err := yaml.Unmarshal(data, &probe); err != nil {
 		return nil, fmt.Errorf("parsing config: %w", err)
 	}
 
-	// Set defaults
+	var cfg Config
+	switch probe.APIVersion {
+	case "", v1beta1.Version:
+		if err := unmarshalStrict(data, &cfg, opts.Strict); err != nil {
+			return nil, fmt.Errorf("parsing config: %w", err)
+		}
+	case v1alpha1.Version:
+		var spoke v1alpha1.Config
+		if err := unmarshalStrict(data, &spoke, opts.Strict); err != nil {
+			return nil, fmt.Errorf("parsing config: %w", err)
+		}
+		if err := spoke.ConvertTo(&cfg); err != nil {
+			return nil, fmt.Errorf("converting %s config to %s: %w", v1alpha1.Version, v1beta1.Version, err)
+		}
+	default:
+		return nil, fmt.Errorf("parsing config: unsupported apiVersion %q", probe.APIVersion)
+	}
+
+	applyDefaults(&cfg)
+	return &cfg, nil
+}
+
+// unmarshalStrict unmarshals data into v, rejecting unknown fields when
+// strict is true.
+func unmarshalStrict(data []byte, v interface{}, strict bool) error {
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(strict)
+	return dec.Decode(v)
+}
+
+// applyDefaults fills in the zero-value fields Load has always defaulted,
+// whether cfg came from the hub schema directly or was just converted up
+// from an older one.
+func applyDefaults(cfg *Config) {
 	if cfg.Server.Port == 0 {
 		cfg.Server.Port = 8080
 	}
+	if cfg.Server.GRPCPort == 0 {
+		cfg.Server.GRPCPort = 9090
+	}
+	if cfg.Observability.Cluster.PeerTimeoutSeconds == 0 {
+		cfg.Observability.Cluster.PeerTimeoutSeconds = 5
+	}
+	if cfg.Observability.Cluster.MaxClockSkewSeconds == 0 {
+		cfg.Observability.Cluster.MaxClockSkewSeconds = 60
+	}
+	if cfg.Observability.ServiceName == "" {
+		cfg.Observability.ServiceName = "threat-telemetry-hub"
+	}
+	if cfg.Observability.LogLevel == "" {
+		cfg.Observability.LogLevel = "info"
+	}
+	if cfg.Observability.LogFormat == "" {
+		cfg.Observability.LogFormat = "json"
+	}
+	if cfg.Observability.OTLPEndpoint == "" {
+		cfg.Observability.OTLPEndpoint = "localhost:4317"
+	}
+	if cfg.Observability.SamplingRate == 0 {
+		cfg.Observability.SamplingRate = 1.0
+	}
+	if cfg.Ingestion.Checkpoint.Backend == "" {
+		cfg.Ingestion.Checkpoint.Backend = "memory"
+	}
+	if cfg.Ingestion.Checkpoint.Bolt.Path == "" {
+		cfg.Ingestion.Checkpoint.Bolt.Path = "data/checkpoints.db"
+	}
 	if cfg.AI.Provider == "" {
 		cfg.AI.Provider = "anthropic"
 	}
@@ -290,10 +213,64 @@ func Load(path string) (*Config, error) {
 	if cfg.AI.OpenAI.Model == "" {
 		cfg.AI.OpenAI.Model = "gpt-4-turbo"
 	}
+	if cfg.AI.Batch.MaxLatencyMS == 0 {
+		cfg.AI.Batch.MaxLatencyMS = 2000
+	}
+	if cfg.AI.Batch.MaxTokens == 0 {
+		cfg.AI.Batch.MaxTokens = 8000
+	}
+	if cfg.AI.Batch.TokensPerMinute == 0 {
+		cfg.AI.Batch.TokensPerMinute = 40000
+	}
+	if cfg.AI.Batch.CacheSize == 0 {
+		cfg.AI.Batch.CacheSize = 1000
+	}
 	if cfg.Normalization.DefaultSchema == "" {
 		cfg.Normalization.DefaultSchema = "ocsf"
 	}
-
-	return &cfg, nil
+	if cfg.Detection.SigmaRulesDir == "" {
+		cfg.Detection.SigmaRulesDir = "rules/sigma"
+	}
+	if cfg.Detection.CELRulesDir == "" {
+		cfg.Detection.CELRulesDir = "rules/cel"
+	}
+	if cfg.Pipeline.WorkerCount == 0 {
+		cfg.Pipeline.WorkerCount = 16
+	}
+	if cfg.Pipeline.QueueSize == 0 {
+		cfg.Pipeline.QueueSize = 1000
+	}
+	if cfg.Pipeline.QueueHighWatermark == 0 {
+		cfg.Pipeline.QueueHighWatermark = cfg.Pipeline.QueueSize * 8 / 10
+	}
+	if cfg.Pipeline.DropPolicy == "" {
+		cfg.Pipeline.DropPolicy = "block"
+	}
+	if cfg.Pipeline.StageTimeoutMS == 0 {
+		cfg.Pipeline.StageTimeoutMS = 30000
+	}
+	if cfg.Pipeline.AIConcurrency == 0 {
+		cfg.Pipeline.AIConcurrency = 8
+	}
+	if cfg.Ingestion.IPS.CrowdSec.PollIntervalSeconds == 0 {
+		cfg.Ingestion.IPS.CrowdSec.PollIntervalSeconds = 15
+	}
+	if cfg.Ingestion.IPS.CrowdSec.DecisionDurationMinutes == 0 {
+		cfg.Ingestion.IPS.CrowdSec.DecisionDurationMinutes = 240
+	}
+	if cfg.Output.Ticketing.ServiceNow.SyncIntervalSeconds == 0 {
+		cfg.Output.Ticketing.ServiceNow.SyncIntervalSeconds = 300
+	}
+	if cfg.Output.Ticketing.Authz.ClockSkewSeconds == 0 {
+		cfg.Output.Ticketing.Authz.ClockSkewSeconds = 60
+	}
+	if cfg.Correlation.PollIntervalSeconds == 0 {
+		cfg.Correlation.PollIntervalSeconds = 30
+	}
+	if cfg.Export.QueueSize == 0 {
+		cfg.Export.QueueSize = 100
+	}
+	if cfg.Export.OTLP.Endpoint == "" {
+		cfg.Export.OTLP.Endpoint = "localhost:4317"
+	}
 }
-
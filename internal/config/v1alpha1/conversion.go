@@ -0,0 +1,49 @@
+package v1alpha1
+
+// zz_generated.conversions.go holds this package's autoConvertToHub/
+// autoConvertFromHub helpers. No conversion-gen is wired into this module,
+// so unlike a real zz_generated file it's maintained by hand - update it
+// when a field is added to a section that's identical between schemas.
+
+import (
+	"fmt"
+
+	"github.com/lvonguyen/threat-telemetry-hub/internal/config/v1beta1"
+)
+
+// ConvertTo copies c onto hub, which must be a *v1beta1.Config,
+// implementing v1beta1.Convertible. Sections that are identical between
+// the two schemas are handled by the generated autoConvertToHub; AI is
+// mapped by hand here since v1beta1.AIConfig is a superset of this
+// package's AIConfig. Fields with no v1alpha1 equivalent (EventingConfig,
+// and AIConfig's Ollama/LlamaCpp/Bedrock/Vertex/Redaction/Batch) are left
+// at their zero value, which config.applyDefaults then fills in.
+func (c *Config) ConvertTo(hub v1beta1.Hub) error {
+	out, ok := hub.(*v1beta1.Config)
+	if !ok {
+		return fmt.Errorf("v1alpha1: ConvertTo expects *v1beta1.Config, got %T", hub)
+	}
+
+	autoConvertToHub(c, out)
+	out.AI.Provider = c.AI.Provider
+	out.AI.Anthropic = c.AI.Anthropic
+	out.AI.OpenAI = c.AI.OpenAI
+	return nil
+}
+
+// ConvertFrom populates c from hub, which must be a *v1beta1.Config, for
+// writing a hub config back out in the v1alpha1 schema. Hub-only fields
+// are dropped.
+func (c *Config) ConvertFrom(hub v1beta1.Hub) error {
+	in, ok := hub.(*v1beta1.Config)
+	if !ok {
+		return fmt.Errorf("v1alpha1: ConvertFrom expects *v1beta1.Config, got %T", hub)
+	}
+
+	autoConvertFromHub(in, c)
+	c.APIVersion = Version
+	c.AI.Provider = in.AI.Provider
+	c.AI.Anthropic = in.AI.Anthropic
+	c.AI.OpenAI = in.AI.OpenAI
+	return nil
+}
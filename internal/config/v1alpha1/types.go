@@ -0,0 +1,44 @@
+// Package v1alpha1 is the original, pre-eventing config schema: the
+// shape Threat Telemetry Hub shipped before AIConfig grew local-LLM and
+// cloud-provider backends and EventingConfig was added. It is kept
+// around purely so old deployed YAML documents declaring
+// apiVersion: v1alpha1 keep loading (a document with no apiVersion at all
+// is loaded as config/v1beta1); new fields only ever get added to
+// config/v1beta1.
+//
+// Config implements v1beta1.Convertible. Sections that never changed
+// between the two schemas reuse the v1beta1 types directly rather than
+// redeclaring them here.
+package v1alpha1
+
+import "github.com/lvonguyen/threat-telemetry-hub/internal/config/v1beta1"
+
+// Version is this package's apiVersion value.
+const Version = "v1alpha1"
+
+// Config is the v1alpha1 application configuration.
+type Config struct {
+	APIVersion    string                      `yaml:"apiVersion"`
+	Server        v1beta1.ServerConfig        `yaml:"server"`
+	Observability v1beta1.ObservabilityConfig `yaml:"observability"`
+	AI            AIConfig                    `yaml:"ai"`
+	Ingestion     v1beta1.IngestionConfig     `yaml:"ingestion"`
+	Normalization v1beta1.NormalizationConfig `yaml:"normalization"`
+	Enrichment    v1beta1.EnrichmentConfig    `yaml:"enrichment"`
+	Detection     v1beta1.DetectionConfig     `yaml:"detection"`
+	Correlation   v1beta1.CorrelationConfig   `yaml:"correlation"`
+	Pipeline      v1beta1.PipelineConfig      `yaml:"pipeline"`
+	Output        v1beta1.OutputConfig        `yaml:"output"`
+	Export        v1beta1.ExportConfig        `yaml:"export"`
+	DRBC          v1beta1.DRBCConfig          `yaml:"dr_bc"`
+}
+
+// AIConfig is the v1alpha1 AI provider configuration: Anthropic and
+// OpenAI only, predating the local-LLM (Ollama, llama.cpp) and cloud
+// (Bedrock, Vertex) backends, request-level redaction, and the
+// batching/caching layer that config/v1beta1.AIConfig grew later.
+type AIConfig struct {
+	Provider  string                  `yaml:"provider"` // "anthropic" or "openai"
+	Anthropic v1beta1.AnthropicConfig `yaml:"anthropic"`
+	OpenAI    v1beta1.OpenAIConfig    `yaml:"openai"`
+}
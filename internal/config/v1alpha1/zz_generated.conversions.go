@@ -0,0 +1,41 @@
+// This file holds the mechanical, field-for-field half of the
+// v1alpha1<->v1beta1 conversion, in the shape a conversion-gen-style tool
+// would emit from a `//go:generate` directive for every section whose
+// type didn't change between schemas. No such generator is wired into
+// this module yet, so - unlike a real zz_generated file - this one is
+// maintained by hand; the AI section, whose shape did change, is mapped
+// by hand in conversion.go instead.
+package v1alpha1
+
+import "github.com/lvonguyen/threat-telemetry-hub/internal/config/v1beta1"
+
+// autoConvertToHub copies every v1alpha1.Config field whose type is
+// shared with v1beta1.Config. AI is left for the caller to map by hand.
+func autoConvertToHub(in *Config, out *v1beta1.Config) {
+	out.Server = in.Server
+	out.Observability = in.Observability
+	out.Ingestion = in.Ingestion
+	out.Normalization = in.Normalization
+	out.Enrichment = in.Enrichment
+	out.Detection = in.Detection
+	out.Correlation = in.Correlation
+	out.Pipeline = in.Pipeline
+	out.Output = in.Output
+	out.Export = in.Export
+	out.DRBC = in.DRBC
+}
+
+// autoConvertFromHub is autoConvertToHub's inverse, used by ConvertFrom.
+func autoConvertFromHub(in *v1beta1.Config, out *Config) {
+	out.Server = in.Server
+	out.Observability = in.Observability
+	out.Ingestion = in.Ingestion
+	out.Normalization = in.Normalization
+	out.Enrichment = in.Enrichment
+	out.Detection = in.Detection
+	out.Correlation = in.Correlation
+	out.Pipeline = in.Pipeline
+	out.Output = in.Output
+	out.Export = in.Export
+	out.DRBC = in.DRBC
+}
@@ -0,0 +1,27 @@
+package v1beta1
+
+// Version is this package's apiVersion value. config.LoadWithOptions
+// compares a document's apiVersion with it when deciding whether it already
+// matches the current hub schema or needs to come in through a spoke
+// version's ConvertTo.
+const Version = "v1beta1"
+
+// Hub marks Config as the conversion hub every other config API version
+// converts through, following the conversion-hub pattern from
+// upjet/controller-gen: a spoke version never converts directly to
+// another spoke, only to and from the hub.
+type Hub interface {
+	Hub()
+}
+
+// Convertible is implemented by every non-hub config API version.
+// ConvertTo copies a spoke's fields onto the hub; ConvertFrom populates a
+// spoke from the hub, for writing a hub config back out in an older
+// schema.
+type Convertible interface {
+	ConvertTo(hub Hub) error
+	ConvertFrom(hub Hub) error
+}
+
+// Hub implements Hub, marking Config as the conversion hub.
+func (c *Config) Hub() {}
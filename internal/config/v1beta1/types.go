@@ -0,0 +1,668 @@
+// Package v1beta1 is the current hub schema for Threat Telemetry Hub's
+// configuration: the full struct tree every other config API version
+// converts into (and, for round-tripping, out of). See the top-level
+// config package for apiVersion detection and the Load entrypoint, and
+// config/v1alpha1 for the previous, narrower schema this version
+// superseded.
+package v1beta1
+
+// zz_generated.deepcopy.go holds this package's DeepCopy/DeepCopyInto
+// methods. No deepcopy-gen is wired into this module, so unlike a real
+// zz_generated file it's maintained by hand - update it when adding or
+// removing a slice field below.
+
+// Config represents the application configuration
+type Config struct {
+	APIVersion    string               `yaml:"apiVersion"`
+	Server        ServerConfig         `yaml:"server"`
+	Observability ObservabilityConfig  `yaml:"observability"`
+	AI            AIConfig             `yaml:"ai"`
+	Ingestion     IngestionConfig      `yaml:"ingestion"`
+	Normalization NormalizationConfig  `yaml:"normalization"`
+	Enrichment    EnrichmentConfig     `yaml:"enrichment"`
+	Detection     DetectionConfig      `yaml:"detection"`
+	Correlation   CorrelationConfig    `yaml:"correlation"`
+	Pipeline      PipelineConfig       `yaml:"pipeline"`
+	Pipelines     []PipelineDefinition `yaml:"pipelines"`
+	Output        OutputConfig         `yaml:"output"`
+	Export        ExportConfig         `yaml:"export"`
+	Eventing      EventingConfig       `yaml:"eventing"`
+	DRBC          DRBCConfig           `yaml:"dr_bc"`
+}
+
+// PipelineDefinition declares one internal/pipeline DAG: a Source feeding
+// a chain of Processors and fanning out to one or more Sinks, each name
+// resolved against internal/pipeline's own registry (mirroring how
+// IngestionConfig's collectors resolve against internal/ingestion's).
+// This is independent of PipelineConfig, which tunes the older, fixed
+// ingestion -> AI -> normalization -> enrichment -> detection ->
+// correlation flow in cmd/hub.Pipeline.
+type PipelineDefinition struct {
+	Name       string       `yaml:"name"`
+	Source     string       `yaml:"source"`
+	Processors []string     `yaml:"processors"`
+	Sinks      []string     `yaml:"sinks"`
+	Buffer     BufferConfig `yaml:"buffer"`
+}
+
+// BufferConfig configures the persistent buffer sitting between a
+// PipelineDefinition's Source and its first Processor, so in-flight
+// events survive a restart instead of being lost - necessary for DRBCConfig's
+// RPO/RTO targets to mean anything for a pipeline still mid-flight during
+// a failover.
+type BufferConfig struct {
+	PersistentEnabled bool   `yaml:"persistent_enabled"`
+	Path              string `yaml:"path"`
+	MaxSize           int    `yaml:"max_size"`
+}
+
+// EventingConfig configures the internal/events CloudEvents transports
+// used for interchange with downstream SOAR/GRC systems, independent of
+// the export sinks in ExportConfig.
+type EventingConfig struct {
+	HTTP  HTTPTransportConfig  `yaml:"http"`
+	Kafka KafkaTransportConfig `yaml:"kafka"`
+	MQTT  MQTTTransportConfig  `yaml:"mqtt"`
+}
+
+// HTTPTransportConfig configures events.HTTPTransport.
+type HTTPTransportConfig struct {
+	Enabled   bool   `yaml:"enabled"`
+	TargetURL string `yaml:"target_url"` // where Publish sends events
+	Addr      string `yaml:"addr"`       // where Subscribe listens, e.g. ":8090"
+}
+
+// KafkaTransportConfig configures events.KafkaTransport.
+type KafkaTransportConfig struct {
+	Enabled bool     `yaml:"enabled"`
+	Brokers []string `yaml:"brokers"`
+	Topic   string   `yaml:"topic"`
+	GroupID string   `yaml:"group_id"`
+}
+
+// MQTTTransportConfig configures events.MQTTTransport.
+type MQTTTransportConfig struct {
+	Enabled   bool   `yaml:"enabled"`
+	BrokerURL string `yaml:"broker_url"`
+	ClientID  string `yaml:"client_id"`
+	Topic     string `yaml:"topic"`
+}
+
+// PipelineConfig tunes the bounded worker pool that drains collected events
+// and runs each one through AI analysis, normalization, enrichment,
+// detection, and correlation (see cmd/hub.Pipeline).
+type PipelineConfig struct {
+	// WorkerCount is how many goroutines concurrently process events off
+	// the queue. Unlike the one-goroutine-per-event it replaced, this
+	// bounds memory and keeps AI/enrichment calls from being starved by a
+	// burst from any single collector.
+	WorkerCount int `yaml:"worker_count"`
+	// QueueSize is the buffered capacity of the queue collectors feed into.
+	QueueSize int `yaml:"queue_size"`
+	// QueueHighWatermark is the queue depth, in events, at which the
+	// pipeline logs a warning so an operator notices congestion before
+	// DropPolicy starts discarding events.
+	QueueHighWatermark int `yaml:"queue_high_watermark"`
+	// DropPolicy governs what happens once the queue is full: "block"
+	// (default; apply backpressure all the way back to the collectors),
+	// "drop-oldest" (evict the longest-queued event to make room), or
+	// "drop-new" (discard the incoming event, leaving the queue as-is).
+	DropPolicy string `yaml:"drop_policy"`
+	// StageTimeoutMS bounds each per-event AI analysis and enrichment call,
+	// so one hung vendor/provider call can't tie up a worker indefinitely.
+	StageTimeoutMS int `yaml:"stage_timeout_ms"`
+	// AIConcurrency caps how many AnalyzeRawEvent calls may be in flight at
+	// once, independent of WorkerCount, since those calls dominate latency
+	// and share the AI providers' own rate limits.
+	AIConcurrency int `yaml:"ai_concurrency"`
+}
+
+// ObservabilityConfig configures structured logging, trace export, and
+// metrics for internal/observability.Telemetry.
+type ObservabilityConfig struct {
+	ServiceName    string `yaml:"service_name"`
+	ServiceVersion string `yaml:"service_version"`
+	Environment    string `yaml:"environment"`
+
+	// Logging
+	LogLevel  string `yaml:"log_level"`
+	LogFormat string `yaml:"log_format"` // json, console
+
+	// LoggingOTLPEnabled additionally ships every log record to
+	// OTLPEndpoint as OTLP logs, alongside the existing console/json
+	// output - off by default so enabling it is an explicit opt-in rather
+	// than a behavior change for deployments with no collector to send
+	// logs to.
+	LoggingOTLPEnabled bool `yaml:"logging_otlp_enabled"`
+
+	// Tracing. Exporting to OTLP is on by default - a detection's journey
+	// from vendor API call through AI analysis is otherwise unobservable
+	// end-to-end; set TracingDisabled to opt out on deployments with no
+	// collector to send spans to.
+	TracingDisabled bool    `yaml:"tracing_disabled"`
+	OTLPEndpoint    string  `yaml:"otlp_endpoint"`
+	SamplingRate    float64 `yaml:"sampling_rate"`
+
+	// Metrics
+	MetricsEnabled bool `yaml:"metrics_enabled"`
+	MetricsPort    int  `yaml:"metrics_port"`
+
+	// MetricsOTLPEnabled additionally exports every Metrics instrument to
+	// OTLPEndpoint on a periodic interval, alongside the existing
+	// Prometheus /metrics scrape endpoint - for deployments that only run
+	// an OTel Collector and never scrape the hub directly.
+	MetricsOTLPEnabled bool `yaml:"metrics_otlp_enabled"`
+
+	// Health
+	HealthPort int                 `yaml:"health_port"`
+	Cluster    ClusterHealthConfig `yaml:"cluster_health"`
+}
+
+// ClusterHealthConfig configures the /health/cluster peer-aggregation
+// endpoint, which fans out to other hub instances in a multi-instance
+// deployment and aggregates their /health responses into one document.
+type ClusterHealthConfig struct {
+	Enabled bool `yaml:"enabled"`
+
+	// DiscoveryMode selects how peers are found: "static" (Peers list),
+	// "dns" (a SRV record named DNSSRVName), or "k8s" (a headless
+	// Service's DNS A records).
+	DiscoveryMode  string   `yaml:"discovery_mode"`
+	Peers          []string `yaml:"peers"`
+	DNSSRVName     string   `yaml:"dns_srv_name"`
+	K8sServiceName string   `yaml:"k8s_service_name"`
+	K8sNamespace   string   `yaml:"k8s_namespace"`
+	K8sPeerPort    int      `yaml:"k8s_peer_port"`
+
+	PeerTimeoutSeconds  int `yaml:"peer_timeout_seconds"`
+	MaxClockSkewSeconds int `yaml:"max_clock_skew_seconds"`
+
+	// TokenEnv names the environment variable holding the bearer token
+	// callers must present to reach /health/cluster.
+	TokenEnv string `yaml:"token_env"`
+}
+
+// ServerConfig represents HTTP server configuration
+type ServerConfig struct {
+	Port         int `yaml:"port"`
+	GRPCPort     int `yaml:"grpc_port"`
+	ReadTimeout  int `yaml:"read_timeout"`
+	WriteTimeout int `yaml:"write_timeout"`
+}
+
+// AIConfig represents AI provider configuration
+// Supports both Anthropic (Claude) and OpenAI for flexibility
+type AIConfig struct {
+	Provider  string          `yaml:"provider"` // "anthropic", "openai", "ollama", "llamacpp", "bedrock", or "vertex"
+	Anthropic AnthropicConfig `yaml:"anthropic"`
+	OpenAI    OpenAIConfig    `yaml:"openai"`
+	Ollama    OllamaConfig    `yaml:"ollama"`
+	LlamaCpp  LlamaCppConfig  `yaml:"llamacpp"`
+	Bedrock   BedrockConfig   `yaml:"bedrock"`
+	Vertex    VertexConfig    `yaml:"vertex"`
+	Redaction RedactionConfig `yaml:"redaction"`
+	Batch     AIBatchConfig   `yaml:"batch"`
+	Cache     AICacheConfig   `yaml:"cache"`
+}
+
+// AIBatchConfig tunes the batching/caching/rate-limiting layer in front of
+// the AI providers (see internal/ai.BatchAnalyzer).
+type AIBatchConfig struct {
+	MaxLatencyMS    int `yaml:"max_latency_ms"`    // flush a batch after this long even if MaxTokens isn't reached
+	MaxTokens       int `yaml:"max_tokens"`        // flush a batch early once its estimated prompt tokens reach this
+	TokensPerMinute int `yaml:"tokens_per_minute"` // global budget shared across all providers
+	CacheSize       int `yaml:"cache_size"`        // number of (prompt hash -> RiskAnalysis) entries to retain
+}
+
+// AICacheConfig selects and configures the response cache in front of
+// Analyzer's single-event path (see internal/ai.Cache), keyed by a hash of
+// the (provider model, sanitized prompt) pair so the same noisy alert
+// re-analyzed within TTLSeconds is served from cache instead of billed
+// again. Independent of AIBatchConfig.CacheSize, which caches parsed
+// RiskAnalysis results inside BatchAnalyzer, keyed by each event's own
+// prompt hash.
+type AICacheConfig struct {
+	// Backend selects the Cache implementation: "" or "memory" (default;
+	// in-process, does not survive a restart and isn't shared across
+	// instances). "redis" is accepted here and configured by Redis below,
+	// but NewCache returns an error for it: this module has no Redis cache
+	// implementation.
+	Backend    string             `yaml:"backend"`
+	TTLSeconds int                `yaml:"ttl_seconds"`
+	MaxEntries int                `yaml:"max_entries"` // memory backend only
+	Redis      AICacheRedisConfig `yaml:"redis"`
+}
+
+// AICacheRedisConfig configures the shared Redis response cache backend.
+type AICacheRedisConfig struct {
+	Addr        string `yaml:"addr"`
+	PasswordEnv string `yaml:"password_env"`
+}
+
+// AnthropicConfig represents Anthropic Claude configuration
+type AnthropicConfig struct {
+	Model     string `yaml:"model"`
+	APIKeyEnv string `yaml:"api_key_env"`
+}
+
+// OpenAIConfig represents OpenAI configuration
+type OpenAIConfig struct {
+	Model     string `yaml:"model"`
+	APIKeyEnv string `yaml:"api_key_env"`
+}
+
+// OllamaConfig represents a local Ollama server configuration. Running
+// analysis against it keeps sensitive telemetry from ever leaving the host,
+// at the cost of model quality versus a hosted frontier model.
+type OllamaConfig struct {
+	BaseURL string `yaml:"base_url"` // e.g. http://localhost:11434
+	Model   string `yaml:"model"`
+}
+
+// LlamaCppConfig represents a local llama.cpp server configuration
+// (llama-server, started with --model pointed at a local gguf file).
+type LlamaCppConfig struct {
+	BaseURL string `yaml:"base_url"` // e.g. http://localhost:8080
+	Model   string `yaml:"model"`    // label only; llama.cpp server is single-model per process
+}
+
+// BedrockConfig represents AWS Bedrock configuration. Requests are signed
+// with SigV4 using credentials read from the environment variables named
+// here, rather than depending on the AWS SDK's credential chain.
+type BedrockConfig struct {
+	Region          string `yaml:"region"`
+	ModelID         string `yaml:"model_id"` // e.g. anthropic.claude-3-sonnet-20240229-v1:0, meta.llama3-70b-instruct-v1:0
+	AccessKeyIDEnv  string `yaml:"access_key_id_env"`
+	SecretKeyEnv    string `yaml:"secret_key_env"`
+	SessionTokenEnv string `yaml:"session_token_env"` // optional, for temporary/STS credentials
+}
+
+// VertexConfig represents GCP Vertex AI configuration. AccessTokenEnv names
+// an environment variable holding a short-lived OAuth2 access token (e.g.
+// from `gcloud auth print-access-token`); refreshing it is the operator's
+// responsibility, same as every other provider's API-key env var.
+type VertexConfig struct {
+	ProjectID      string `yaml:"project_id"`
+	Location       string `yaml:"location"` // e.g. us-central1
+	Model          string `yaml:"model"`    // e.g. gemini-1.5-pro
+	AccessTokenEnv string `yaml:"access_token_env"`
+}
+
+// RedactionConfig controls scrubbing of likely-sensitive values (emails, IP
+// addresses, API-key-shaped tokens) out of prompts before they're sent to
+// any AI provider.
+type RedactionConfig struct {
+	Enabled bool `yaml:"enabled"`
+}
+
+// IngestionConfig represents data ingestion sources
+type IngestionConfig struct {
+	EDR        EDRConfig        `yaml:"edr"`
+	SIEM       SIEMConfig       `yaml:"siem"`
+	Cloud      CloudConfig      `yaml:"cloud"`
+	DLP        DLPConfig        `yaml:"dlp"`
+	IPS        IPSConfig        `yaml:"ips"`
+	Checkpoint CheckpointConfig `yaml:"checkpoint"`
+}
+
+// CheckpointConfig selects and configures the Checkpointer backend every
+// collector uses to persist its read cursor across restarts - see
+// internal/checkpoint.
+type CheckpointConfig struct {
+	// Backend selects the Checkpointer implementation: "memory" (default;
+	// cursors do not survive a restart, development only) or "bolt"
+	// (single-node, durable). "postgres" and "redis" are accepted here and
+	// configured by the sections below, but checkpoint.New returns an
+	// error for both: this module has no implementation of either.
+	Backend  string                   `yaml:"backend"`
+	Bolt     BoltCheckpointConfig     `yaml:"bolt"`
+	Postgres PostgresCheckpointConfig `yaml:"postgres"`
+	Redis    RedisCheckpointConfig    `yaml:"redis"`
+}
+
+// BoltCheckpointConfig configures the single-node BoltDB checkpoint backend.
+type BoltCheckpointConfig struct {
+	Path string `yaml:"path"`
+}
+
+// PostgresCheckpointConfig configures the shared Postgres checkpoint backend.
+type PostgresCheckpointConfig struct {
+	DSNEnv string `yaml:"dsn_env"`
+	Table  string `yaml:"table"`
+}
+
+// RedisCheckpointConfig configures the shared Redis checkpoint backend.
+type RedisCheckpointConfig struct {
+	Addr        string `yaml:"addr"`
+	PasswordEnv string `yaml:"password_env"`
+}
+
+// EDRConfig represents EDR tool integrations
+type EDRConfig struct {
+	CrowdStrike  CrowdStrikeConfig  `yaml:"crowdstrike"`
+	SentinelOne  SentinelOneConfig  `yaml:"sentinelone"`
+	Defender     DefenderConfig     `yaml:"defender"`
+	CarbonBlack  CarbonBlackConfig  `yaml:"carbon_black"`
+}
+
+// CrowdStrikeConfig represents CrowdStrike Falcon configuration
+type CrowdStrikeConfig struct {
+	Enabled         bool   `yaml:"enabled"`
+	APIURL          string `yaml:"api_url"`
+	ClientIDEnv     string `yaml:"client_id_env"`
+	ClientSecretEnv string `yaml:"client_secret_env"`
+}
+
+// SentinelOneConfig represents SentinelOne configuration
+type SentinelOneConfig struct {
+	Enabled     bool   `yaml:"enabled"`
+	APIURL      string `yaml:"api_url"`
+	APITokenEnv string `yaml:"api_token_env"`
+}
+
+// DefenderConfig represents Microsoft Defender for Endpoint configuration
+type DefenderConfig struct {
+	Enabled     bool   `yaml:"enabled"`
+	TenantIDEnv string `yaml:"tenant_id_env"`
+	ClientIDEnv string `yaml:"client_id_env"`
+	SecretEnv   string `yaml:"secret_env"`
+}
+
+// CarbonBlackConfig represents VMware Carbon Black configuration
+type CarbonBlackConfig struct {
+	Enabled     bool   `yaml:"enabled"`
+	APIURL      string `yaml:"api_url"`
+	APIKeyEnv   string `yaml:"api_key_env"`
+	APISecretEnv string `yaml:"api_secret_env"`
+}
+
+// SIEMConfig represents SIEM integrations
+type SIEMConfig struct {
+	Splunk        SplunkConfig        `yaml:"splunk"`
+	Sentinel      SentinelConfig      `yaml:"sentinel"`
+	Elasticsearch ElasticsearchConfig `yaml:"elasticsearch"`
+}
+
+// SplunkConfig represents Splunk HEC configuration
+type SplunkConfig struct {
+	Enabled     bool   `yaml:"enabled"`
+	HECURL      string `yaml:"hec_url"`
+	HECTokenEnv string `yaml:"hec_token_env"`
+}
+
+// SentinelConfig represents Microsoft Sentinel configuration
+type SentinelConfig struct {
+	Enabled         bool   `yaml:"enabled"`
+	WorkspaceID     string `yaml:"workspace_id"`
+	SharedKeyEnv    string `yaml:"shared_key_env"`
+}
+
+// ElasticsearchConfig represents Elasticsearch configuration
+type ElasticsearchConfig struct {
+	Enabled   bool     `yaml:"enabled"`
+	Addresses []string `yaml:"addresses"`
+	Username  string   `yaml:"username"`
+	PasswordEnv string `yaml:"password_env"`
+	Index     string   `yaml:"index"`
+}
+
+// CloudConfig represents cloud audit log sources
+type CloudConfig struct {
+	AWS   AWSCloudConfig   `yaml:"aws"`
+	Azure AzureCloudConfig `yaml:"azure"`
+	GCP   GCPCloudConfig   `yaml:"gcp"`
+}
+
+// AWSCloudConfig represents AWS CloudTrail configuration
+type AWSCloudConfig struct {
+	Enabled     bool   `yaml:"enabled"`
+	Region      string `yaml:"region"`
+	RoleARN     string `yaml:"role_arn"`
+	TrailName   string `yaml:"trail_name"`
+}
+
+// AzureCloudConfig represents Azure Activity Log configuration
+type AzureCloudConfig struct {
+	Enabled        bool   `yaml:"enabled"`
+	SubscriptionID string `yaml:"subscription_id"`
+	TenantIDEnv    string `yaml:"tenant_id_env"`
+}
+
+// GCPCloudConfig represents GCP Audit Log configuration
+type GCPCloudConfig struct {
+	Enabled   bool   `yaml:"enabled"`
+	ProjectID string `yaml:"project_id"`
+}
+
+// DLPConfig represents DLP tool integrations (COTS aggregation)
+type DLPConfig struct {
+	DigitalGuardian DigitalGuardianConfig `yaml:"digital_guardian"`
+	Proofpoint      ProofpointConfig      `yaml:"proofpoint"`
+	Purview         PurviewConfig         `yaml:"purview"`
+	Netskope        NetskopeConfig        `yaml:"netskope"`
+}
+
+// DigitalGuardianConfig represents Digital Guardian DLP configuration
+type DigitalGuardianConfig struct {
+	Enabled     bool   `yaml:"enabled"`
+	APIURL      string `yaml:"api_url"`
+	APIKeyEnv   string `yaml:"api_key_env"`
+}
+
+// ProofpointConfig represents Proofpoint DLP configuration
+type ProofpointConfig struct {
+	Enabled       bool   `yaml:"enabled"`
+	APIURL        string `yaml:"api_url"`
+	APIKeyEnv     string `yaml:"api_key_env"`
+	WebhookSecret string `yaml:"webhook_secret_env"`
+}
+
+// PurviewConfig represents Microsoft Purview DLP configuration
+type PurviewConfig struct {
+	Enabled     bool   `yaml:"enabled"`
+	TenantIDEnv string `yaml:"tenant_id_env"`
+	ClientIDEnv string `yaml:"client_id_env"`
+	SecretEnv   string `yaml:"secret_env"`
+}
+
+// NetskopeConfig represents Netskope DLP configuration
+type NetskopeConfig struct {
+	Enabled     bool   `yaml:"enabled"`
+	TenantURL   string `yaml:"tenant_url"`
+	APITokenEnv string `yaml:"api_token_env"`
+}
+
+// IPSConfig represents intrusion-prevention/edge-blocking integrations -
+// unlike the other ingestion sources, these are bidirectional: the hub
+// pulls their current decisions and can also push new ones back.
+type IPSConfig struct {
+	CrowdSec CrowdSecConfig `yaml:"crowdsec"`
+}
+
+// CrowdSecConfig configures both the collector that polls the CrowdSec
+// Local API (LAPI) for its decisions stream and the remediation sink that
+// pushes decisions back to it (see internal/crowdsec and
+// internal/output/ticketing.CrowdSecRemediationSink).
+type CrowdSecConfig struct {
+	Enabled   bool   `yaml:"enabled"`
+	LAPIURL   string `yaml:"lapi_url"`
+	APIKeyEnv string `yaml:"api_key_env"`
+	// PollIntervalSeconds is how often the collector re-polls
+	// /v1/decisions/stream after its initial startup=true snapshot.
+	PollIntervalSeconds int `yaml:"poll_interval_seconds"`
+	// RemediationEnabled gates whether critical-risk events with an
+	// attacker IP enrichment are pushed back to CrowdSec as new decisions.
+	// It can be left off to run this purely as a read-only IPS feed.
+	RemediationEnabled bool `yaml:"remediation_enabled"`
+	// DecisionDurationMinutes is how long a pushed-back decision bans its
+	// target before CrowdSec expires it.
+	DecisionDurationMinutes int `yaml:"decision_duration_minutes"`
+}
+
+// NormalizationConfig represents schema normalization settings
+type NormalizationConfig struct {
+	DefaultSchema string `yaml:"default_schema"` // "ocsf" or "ecs"
+}
+
+// EnrichmentConfig represents enrichment sources
+type EnrichmentConfig struct {
+	ThreatForge ThreatForgeConfig `yaml:"threatforge"`
+	Identity    IdentityConfig    `yaml:"identity"`
+	Asset       AssetConfig       `yaml:"asset"`
+}
+
+// ThreatForgeConfig represents threatforge integration
+type ThreatForgeConfig struct {
+	Enabled bool   `yaml:"enabled"`
+	APIURL  string `yaml:"api_url"`
+}
+
+// IdentityConfig represents identity enrichment sources
+type IdentityConfig struct {
+	EntraID EntraIDConfig `yaml:"entra_id"`
+	Okta    OktaConfig    `yaml:"okta"`
+}
+
+// EntraIDConfig represents Microsoft Entra ID configuration
+type EntraIDConfig struct {
+	Enabled     bool   `yaml:"enabled"`
+	TenantIDEnv string `yaml:"tenant_id_env"`
+	ClientIDEnv string `yaml:"client_id_env"`
+	SecretEnv   string `yaml:"secret_env"`
+}
+
+// OktaConfig represents Okta configuration
+type OktaConfig struct {
+	Enabled     bool   `yaml:"enabled"`
+	Domain      string `yaml:"domain"`
+	APITokenEnv string `yaml:"api_token_env"`
+}
+
+// AssetConfig represents asset/CMDB enrichment
+type AssetConfig struct {
+	Enabled bool   `yaml:"enabled"`
+	APIURL  string `yaml:"api_url"`
+}
+
+// DetectionConfig represents the detection rules engine's rule sources
+type DetectionConfig struct {
+	SigmaRulesDir string `yaml:"sigma_rules_dir"`
+	CELRulesDir   string `yaml:"cel_rules_dir"`
+	HotReload     bool   `yaml:"hot_reload"`
+}
+
+// CorrelationConfig configures correlation.Correlator's hot-reloadable
+// rule groups (see correlation.PeriodicRuleNotifier).
+type CorrelationConfig struct {
+	RulesDir            string `yaml:"rules_dir"`
+	PollIntervalSeconds int    `yaml:"poll_interval_seconds"`
+}
+
+// OutputConfig represents output destinations
+type OutputConfig struct {
+	Splunk        SplunkOutputConfig        `yaml:"splunk"`
+	Elasticsearch ElasticsearchOutputConfig `yaml:"elasticsearch"`
+	Ticketing     TicketingConfig           `yaml:"ticketing"`
+}
+
+// TicketingConfig configures bidirectional integration with ticketing/GRC
+// providers (see internal/output/ticketing).
+type TicketingConfig struct {
+	ServiceNow ServiceNowIntegrationConfig `yaml:"servicenow"`
+	Authz      TicketingAuthzConfig        `yaml:"authz"`
+}
+
+// TicketingAuthzConfig configures JWT-authenticated, intention-rule-gated
+// ticket creation (see internal/output/ticketing/authz). With Enabled
+// false (the default), ticketing.Manager.CreateTicketForEvent requires no
+// token and applies no policy, matching the package's pre-authz behavior.
+type TicketingAuthzConfig struct {
+	Enabled  bool   `yaml:"enabled"`
+	JWKSURL  string `yaml:"jwks_url"`
+	// ClockSkewSeconds tolerates drift between this process and the token
+	// issuer when checking a JWT's exp/nbf, same purpose as
+	// authz.Verifier's clockSkew parameter.
+	ClockSkewSeconds int `yaml:"clock_skew_seconds"`
+	// IntentionRulesFile points at a YAML file holding an ordered list of
+	// authz.Intention rules, loaded into an authz.IntentionSet at startup.
+	IntentionRulesFile string `yaml:"intention_rules_file"`
+	// ServiceTokenEnv names the environment variable holding the JWT the
+	// hub presents as its own caller identity when a declared
+	// internal/pipeline DAG routes events to ticketing through this
+	// authorizer (see pipeline.TicketingSink). Unused when no DAG
+	// pipeline has a "ticketing" sink.
+	ServiceTokenEnv string `yaml:"service_token_env"`
+}
+
+// ServiceNowIntegrationConfig configures the ServiceNow ticketing provider
+// along with its inbound webhook and periodic reconciliation sync, which
+// together let analyst actions taken inside ServiceNow flow back into the
+// hub instead of ticket state staying write-only.
+type ServiceNowIntegrationConfig struct {
+	Enabled             bool   `yaml:"enabled"`
+	InstanceURL         string `yaml:"instance_url"`
+	UsernameEnv         string `yaml:"username_env"`
+	PasswordEnv         string `yaml:"password_env"`
+	DefaultTable        string `yaml:"default_table"`
+	AssignmentGroup     string `yaml:"assignment_group"`
+	CallerID            string `yaml:"caller_id"`
+	WebhookSecretEnv    string `yaml:"webhook_secret_env"`
+	SyncIntervalSeconds int    `yaml:"sync_interval_seconds"`
+}
+
+// SplunkOutputConfig represents Splunk HEC output
+type SplunkOutputConfig struct {
+	Enabled     bool   `yaml:"enabled"`
+	HECURL      string `yaml:"hec_url"`
+	HECTokenEnv string `yaml:"hec_token_env"`
+	Index       string `yaml:"index"`
+	SourceType  string `yaml:"sourcetype"`
+}
+
+// ElasticsearchOutputConfig represents Elasticsearch output
+type ElasticsearchOutputConfig struct {
+	Enabled   bool     `yaml:"enabled"`
+	Addresses []string `yaml:"addresses"`
+	Index     string   `yaml:"index"`
+}
+
+// ExportConfig configures the internal/export sinks a ProcessedEvent is fanned
+// out to once the pipeline has finished processing it.
+type ExportConfig struct {
+	// QueueSize bounds each sink's per-sink delivery queue; once full,
+	// FanOut drops (and counts) further events for that sink rather than
+	// blocking the pipeline.
+	QueueSize   int                     `yaml:"queue_size"`
+	OTLP        OTLPExportConfig        `yaml:"otlp"`
+	AppInsights AppInsightsExportConfig `yaml:"app_insights"`
+	Kafka       KafkaExportConfig       `yaml:"kafka"`
+}
+
+// OTLPExportConfig configures export.OTLPSink, independent of the tracer
+// ObservabilityConfig sets up for pipeline/HTTP trace propagation.
+type OTLPExportConfig struct {
+	Enabled  bool   `yaml:"enabled"`
+	Endpoint string `yaml:"endpoint"`
+}
+
+// AppInsightsExportConfig configures export.AppInsightsSink.
+type AppInsightsExportConfig struct {
+	Enabled               bool   `yaml:"enabled"`
+	InstrumentationKeyEnv string `yaml:"instrumentation_key_env"`
+}
+
+// KafkaExportConfig configures export.KafkaSink.
+type KafkaExportConfig struct {
+	Enabled bool     `yaml:"enabled"`
+	Brokers []string `yaml:"brokers"`
+	Topic   string   `yaml:"topic"`
+}
+
+// DRBCConfig represents DR/BC configuration
+type DRBCConfig struct {
+	Enabled        bool   `yaml:"enabled"`
+	PrimaryRegion  string `yaml:"primary_region"`
+	FailoverRegion string `yaml:"failover_region"`
+	RPOMinutes     int    `yaml:"rpo_minutes"`
+	RTOMinutes     int    `yaml:"rto_minutes"`
+}
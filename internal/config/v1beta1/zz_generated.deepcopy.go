@@ -0,0 +1,126 @@
+// This file holds DeepCopy/DeepCopyInto methods for the config types that
+// hold slices, in the shape a deepcopy-gen-style tool would emit from a
+// `//go:generate` directive. No such generator is wired into this module
+// yet, so - unlike a real zz_generated file - this one is maintained by
+// hand; keep it in sync with types.go when adding or removing a slice
+// field.
+package v1beta1
+
+// DeepCopy returns a deep copy of c.
+func (c *Config) DeepCopy() *Config {
+	if c == nil {
+		return nil
+	}
+	out := new(Config)
+	c.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto copies c into out, deep-copying every slice-valued field
+// reachable from it. Every other field is a plain value type, so the
+// leading shallow copy already handles it.
+func (c *Config) DeepCopyInto(out *Config) {
+	*out = *c
+	c.Observability.DeepCopyInto(&out.Observability)
+	c.Ingestion.DeepCopyInto(&out.Ingestion)
+	c.Output.DeepCopyInto(&out.Output)
+	c.Export.DeepCopyInto(&out.Export)
+	c.Eventing.DeepCopyInto(&out.Eventing)
+	if c.Pipelines != nil {
+		out.Pipelines = make([]PipelineDefinition, len(c.Pipelines))
+		for i := range c.Pipelines {
+			c.Pipelines[i].DeepCopyInto(&out.Pipelines[i])
+		}
+	}
+}
+
+// DeepCopyInto copies p into out, deep-copying Processors and Sinks.
+func (p *PipelineDefinition) DeepCopyInto(out *PipelineDefinition) {
+	*out = *p
+	if p.Processors != nil {
+		out.Processors = append([]string(nil), p.Processors...)
+	}
+	if p.Sinks != nil {
+		out.Sinks = append([]string(nil), p.Sinks...)
+	}
+}
+
+// DeepCopyInto copies o into out, deep-copying ClusterHealthConfig.Peers.
+func (o *ObservabilityConfig) DeepCopyInto(out *ObservabilityConfig) {
+	*out = *o
+	o.Cluster.DeepCopyInto(&out.Cluster)
+}
+
+// DeepCopyInto copies c into out, deep-copying Peers.
+func (c *ClusterHealthConfig) DeepCopyInto(out *ClusterHealthConfig) {
+	*out = *c
+	if c.Peers != nil {
+		out.Peers = append([]string(nil), c.Peers...)
+	}
+}
+
+// DeepCopyInto copies i into out, deep-copying the Elasticsearch
+// collector's Addresses by way of SIEMConfig.
+func (i *IngestionConfig) DeepCopyInto(out *IngestionConfig) {
+	*out = *i
+	i.SIEM.DeepCopyInto(&out.SIEM)
+}
+
+// DeepCopyInto copies s into out, deep-copying Elasticsearch.Addresses.
+func (s *SIEMConfig) DeepCopyInto(out *SIEMConfig) {
+	*out = *s
+	s.Elasticsearch.DeepCopyInto(&out.Elasticsearch)
+}
+
+// DeepCopyInto copies e into out, deep-copying Addresses.
+func (e *ElasticsearchConfig) DeepCopyInto(out *ElasticsearchConfig) {
+	*out = *e
+	if e.Addresses != nil {
+		out.Addresses = append([]string(nil), e.Addresses...)
+	}
+}
+
+// DeepCopyInto copies o into out, deep-copying the Elasticsearch output
+// sink's Addresses.
+func (o *OutputConfig) DeepCopyInto(out *OutputConfig) {
+	*out = *o
+	o.Elasticsearch.DeepCopyInto(&out.Elasticsearch)
+}
+
+// DeepCopyInto copies e into out, deep-copying Addresses.
+func (e *ElasticsearchOutputConfig) DeepCopyInto(out *ElasticsearchOutputConfig) {
+	*out = *e
+	if e.Addresses != nil {
+		out.Addresses = append([]string(nil), e.Addresses...)
+	}
+}
+
+// DeepCopyInto copies e into out, deep-copying the Kafka export sink's
+// Brokers.
+func (e *ExportConfig) DeepCopyInto(out *ExportConfig) {
+	*out = *e
+	e.Kafka.DeepCopyInto(&out.Kafka)
+}
+
+// DeepCopyInto copies k into out, deep-copying Brokers.
+func (k *KafkaExportConfig) DeepCopyInto(out *KafkaExportConfig) {
+	*out = *k
+	if k.Brokers != nil {
+		out.Brokers = append([]string(nil), k.Brokers...)
+	}
+}
+
+// DeepCopyInto copies e into out, deep-copying the Kafka transport's
+// Brokers.
+func (e *EventingConfig) DeepCopyInto(out *EventingConfig) {
+	*out = *e
+	e.Kafka.DeepCopyInto(&out.Kafka)
+}
+
+// DeepCopyInto copies k into out, deep-copying Brokers.
+func (k *KafkaTransportConfig) DeepCopyInto(out *KafkaTransportConfig) {
+	*out = *k
+	if k.Brokers != nil {
+		out.Brokers = append([]string(nil), k.Brokers...)
+	}
+}
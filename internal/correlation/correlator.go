@@ -4,6 +4,7 @@ package correlation
 import (
 	"crypto/sha256"
 	"encoding/hex"
+	"fmt"
 	"sync"
 	"time"
 
@@ -12,29 +13,64 @@ import (
 	"github.com/lvonguyen/threat-telemetry-hub/internal/normalization"
 )
 
-// Correlator correlates events across sources
+// defaultKeyTTL is the fallback per-key lifetime used for built-in keys and
+// any rule that doesn't set its own TTLSeconds.
+const defaultKeyTTL = 1 * time.Hour
+
+// Correlator correlates events across sources using a union-find over
+// correlation keys: every (kind, value) pair extracted from an event - an
+// IP, a username, a rule match - is a node, and an event unions all of its
+// keys' nodes into a single component before being attached to that
+// component's CorrelationGroup. This correctly handles an event whose keys
+// bridge two previously-separate groups (they transitively merge) and
+// resolves in amortized near-O(α(n)) per event, unlike scanning every
+// existing group for an overlapping key.
 type Correlator struct {
-	logger       *zap.Logger
-	correlations map[string]*CorrelationGroup
-	mu           sync.RWMutex
-	ttl          time.Duration
+	logger *zap.Logger
+
+	mu        sync.RWMutex
+	parent    map[string]string    // correlation key -> parent key (union-find)
+	rank      map[string]int       // key -> union-by-rank rank, meaningful only at roots
+	keyIndex  map[string]string    // correlation key -> its union-find node ID (== the key itself once seen)
+	keyExpiry map[string]time.Time // correlation key -> when it stops counting toward correlation
+	groups    map[string]*CorrelationGroup // component root -> its group
+
+	// members and liveCount track each component's bookkeeping so a
+	// component can be torn down - its parent/rank entries freed and its
+	// CorrelationGroup dropped - once every key that ever joined it has
+	// expired, instead of parent/rank/groups growing for the life of the
+	// process. members is keyed by the component's current root and holds
+	// every key ever unioned into it; liveCount is the number of those
+	// members whose TTL hasn't elapsed yet.
+	members   map[string]map[string]struct{}
+	liveCount map[string]int
+
+	rulesMu    sync.RWMutex
+	ruleGroups []RuleGroup // hot-reloaded via ReplaceRules
 }
 
 // CorrelationGroup represents a group of related events
 type CorrelationGroup struct {
-	ID        string
-	Events    []*normalization.NormalizedEvent
-	CreatedAt time.Time
-	UpdatedAt time.Time
-	Keys      []string // Correlation keys (IPs, users, hosts)
+	ID           string
+	Events       []*normalization.NormalizedEvent
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+	Keys         []string // Correlation keys currently live on this group (IPs, users, hosts, ...)
+	TicketID     string   // Set once a ticket has been raised for this group
+	TicketStatus string   // Last status reported by the ticketing provider
 }
 
 // NewCorrelator creates a new correlator
 func NewCorrelator(logger *zap.Logger) *Correlator {
 	c := &Correlator{
-		logger:       logger,
-		correlations: make(map[string]*CorrelationGroup),
-		ttl:          1 * time.Hour,
+		logger:    logger,
+		parent:    make(map[string]string),
+		rank:      make(map[string]int),
+		keyIndex:  make(map[string]string),
+		keyExpiry: make(map[string]time.Time),
+		groups:    make(map[string]*CorrelationGroup),
+		members:   make(map[string]map[string]struct{}),
+		liveCount: make(map[string]int),
 	}
 
 	// Start cleanup goroutine
@@ -43,78 +79,239 @@ func NewCorrelator(logger *zap.Logger) *Correlator {
 	return c
 }
 
-// Correlate attempts to correlate an event with existing events
+// correlationKey is one (kind, value) pair extracted from an event, along
+// with how strongly it implies correlation (weight) and how long it keeps
+// implying it (ttl).
+type correlationKey struct {
+	value  string
+	weight float64
+	ttl    time.Duration
+}
+
+// Correlate attempts to correlate an event with existing events, returning
+// the ID of the CorrelationGroup it was attached to - a new one if none of
+// its keys are already live, or an existing one (possibly newly merged
+// from several) otherwise.
 func (c *Correlator) Correlate(event *normalization.NormalizedEvent) string {
 	keys := c.extractCorrelationKeys(event)
 
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
-	// Check if event matches any existing correlation group
-	for _, group := range c.correlations {
-		if c.matchesGroup(keys, group) {
-			group.Events = append(group.Events, event)
-			group.UpdatedAt = time.Now()
-			c.logger.Debug("Event correlated with existing group",
-				zap.String("event_id", event.ID),
-				zap.String("correlation_id", group.ID),
-			)
-			return group.ID
+	now := time.Now()
+
+	if len(keys) == 0 {
+		groupID := c.generateCorrelationID(nil)
+		c.groups[groupID] = &CorrelationGroup{
+			ID:        groupID,
+			Events:    []*normalization.NormalizedEvent{event},
+			CreatedAt: now,
+			UpdatedAt: now,
 		}
+		c.logger.Debug("New correlation group created (no correlation keys)",
+			zap.String("event_id", event.ID),
+			zap.String("correlation_id", groupID),
+		)
+		return groupID
 	}
 
-	// Create new correlation group
-	groupID := c.generateCorrelationID(keys)
-	c.correlations[groupID] = &CorrelationGroup{
-		ID:        groupID,
-		Events:    []*normalization.NormalizedEvent{event},
-		CreatedAt: time.Now(),
-		UpdatedAt: time.Now(),
-		Keys:      keys,
+	// Find (or create) every key's node, unioning them all into one
+	// component and collecting every distinct root that union touched -
+	// this is the bridging case: if two of this event's keys currently
+	// root two separate, previously-unrelated groups, both groups now
+	// belong together.
+	var touchedRoots []string
+	seen := make(map[string]bool)
+	var repID string
+	for i, key := range keys {
+		if _, ok := c.keyIndex[key.value]; !ok {
+			c.keyIndex[key.value] = key.value
+			// An expired key whose node stayed behind in a still-live
+			// component is live again and counts toward that component.
+			if _, known := c.parent[key.value]; known {
+				c.liveCount[c.find(key.value)]++
+			}
+		}
+		if _, ok := c.parent[key.value]; !ok {
+			c.parent[key.value] = key.value
+			c.rank[key.value] = 0
+			c.members[key.value] = map[string]struct{}{key.value: {}}
+			c.liveCount[key.value] = 1
+		}
+
+		root := c.find(key.value)
+		if !seen[root] {
+			seen[root] = true
+			touchedRoots = append(touchedRoots, root)
+		}
+		if i == 0 {
+			repID = root
+		} else {
+			repID = c.union(repID, root)
+		}
+		c.keyExpiry[key.value] = now.Add(key.ttl)
+	}
+
+	group := c.groups[repID]
+	if group == nil {
+		group = &CorrelationGroup{ID: repID, CreatedAt: now}
+		c.groups[repID] = group
 	}
 
-	c.logger.Debug("New correlation group created",
+	for _, root := range touchedRoots {
+		if root == repID {
+			continue
+		}
+		stale, ok := c.groups[root]
+		if !ok {
+			continue
+		}
+		mergeGroup(group, stale)
+		delete(c.groups, root)
+		c.logger.Debug("Correlation groups merged",
+			zap.String("event_id", event.ID),
+			zap.String("surviving_id", repID),
+			zap.String("merged_id", root),
+		)
+	}
+
+	group.Events = append(group.Events, event)
+	group.Keys = appendUniqueStrings(group.Keys, keyValues(keys))
+	group.UpdatedAt = now
+
+	c.logger.Debug("Event correlated",
 		zap.String("event_id", event.ID),
-		zap.String("correlation_id", groupID),
+		zap.String("correlation_id", repID),
 	)
 
-	return groupID
+	return repID
+}
+
+// mergeGroup folds stale's events, keys, and ticket state into into,
+// keeping into's oldest CreatedAt and preferring its ticket if both have
+// one.
+func mergeGroup(into, stale *CorrelationGroup) {
+	into.Events = append(into.Events, stale.Events...)
+	into.Keys = appendUniqueStrings(into.Keys, stale.Keys)
+	if stale.CreatedAt.Before(into.CreatedAt) {
+		into.CreatedAt = stale.CreatedAt
+	}
+	if into.TicketID == "" {
+		into.TicketID = stale.TicketID
+		into.TicketStatus = stale.TicketStatus
+	}
+}
+
+func appendUniqueStrings(existing, additional []string) []string {
+	seen := make(map[string]bool, len(existing))
+	for _, v := range existing {
+		seen[v] = true
+	}
+	for _, v := range additional {
+		if !seen[v] {
+			seen[v] = true
+			existing = append(existing, v)
+		}
+	}
+	return existing
+}
+
+func keyValues(keys []correlationKey) []string {
+	values := make([]string, len(keys))
+	for i, k := range keys {
+		values[i] = k.value
+	}
+	return values
 }
 
 // extractCorrelationKeys extracts keys that can be used for correlation
-func (c *Correlator) extractCorrelationKeys(event *normalization.NormalizedEvent) []string {
-	keys := make([]string, 0)
+func (c *Correlator) extractCorrelationKeys(event *normalization.NormalizedEvent) []correlationKey {
+	var keys []correlationKey
 
 	// Extract common correlation keys from event data
 	if ip, ok := event.Data["source_ip"].(string); ok && ip != "" {
-		keys = append(keys, "ip:"+ip)
+		keys = append(keys, correlationKey{value: "ip:" + ip, weight: 1.0, ttl: defaultKeyTTL})
 	}
 	if user, ok := event.Data["user"].(string); ok && user != "" {
-		keys = append(keys, "user:"+user)
+		keys = append(keys, correlationKey{value: "user:" + user, weight: 1.0, ttl: defaultKeyTTL})
 	}
 	if host, ok := event.Data["hostname"].(string); ok && host != "" {
-		keys = append(keys, "host:"+host)
+		keys = append(keys, correlationKey{value: "host:" + host, weight: 1.0, ttl: defaultKeyTTL})
 	}
 	if hash, ok := event.Data["file_hash"].(string); ok && hash != "" {
-		keys = append(keys, "hash:"+hash)
+		keys = append(keys, correlationKey{value: "hash:" + hash, weight: 1.0, ttl: defaultKeyTTL})
 	}
 	if domain, ok := event.Data["domain"].(string); ok && domain != "" {
-		keys = append(keys, "domain:"+domain)
+		keys = append(keys, correlationKey{value: "domain:" + domain, weight: 1.0, ttl: defaultKeyTTL})
 	}
 
-	return keys
-}
-
-// matchesGroup checks if keys match a correlation group
-func (c *Correlator) matchesGroup(keys []string, group *CorrelationGroup) bool {
-	for _, key := range keys {
-		for _, groupKey := range group.Keys {
-			if key == groupKey {
-				return true
+	// Fold in keys from hot-reloaded correlation rule groups, on top of the
+	// built-in fields above. A rule with a negative weight still gets evaluated
+	// here (so ReplaceRules doesn't need to special-case it) but is
+	// filtered out below, so it never causes a merge.
+	c.rulesMu.RLock()
+	for _, group := range c.ruleGroups {
+		for _, rule := range group.Rules {
+			for _, field := range rule.MatchFields {
+				if v, ok := event.Data[field].(string); ok && v != "" {
+					keys = append(keys, correlationKey{
+						value:  fmt.Sprintf("rule:%s:%s:%s", rule.ID, field, v),
+						weight: rule.weight(),
+						ttl:    rule.ttl(defaultKeyTTL),
+					})
+				}
 			}
 		}
 	}
-	return false
+	c.rulesMu.RUnlock()
+
+	live := keys[:0]
+	for _, k := range keys {
+		if k.weight > 0 {
+			live = append(live, k)
+		}
+	}
+	return live
+}
+
+// find returns id's component root, compressing the path it walks.
+func (c *Correlator) find(id string) string {
+	root := id
+	for c.parent[root] != root {
+		root = c.parent[root]
+	}
+	for c.parent[id] != root {
+		c.parent[id], id = root, c.parent[id]
+	}
+	return root
+}
+
+// union merges the components containing a and b (identified by any
+// member, not necessarily a root) by rank and returns the surviving root.
+// The losing root's members/liveCount bookkeeping is folded into the
+// survivor's so teardownComponent can still find every member by the
+// component's current root after any number of unions.
+func (c *Correlator) union(a, b string) string {
+	rootA, rootB := c.find(a), c.find(b)
+	if rootA == rootB {
+		return rootA
+	}
+	if c.rank[rootA] < c.rank[rootB] {
+		rootA, rootB = rootB, rootA
+	}
+	c.parent[rootB] = rootA
+	if c.rank[rootA] == c.rank[rootB] {
+		c.rank[rootA]++
+	}
+
+	for member := range c.members[rootB] {
+		c.members[rootA][member] = struct{}{}
+	}
+	delete(c.members, rootB)
+	c.liveCount[rootA] += c.liveCount[rootB]
+	delete(c.liveCount, rootB)
+
+	return rootA
 }
 
 // generateCorrelationID generates a unique correlation ID
@@ -127,28 +324,149 @@ func (c *Correlator) generateCorrelationID(keys []string) string {
 	return hex.EncodeToString(h.Sum(nil))[:16]
 }
 
-// cleanup removes expired correlation groups
+// cleanup evicts individual correlation keys once their TTL elapses, so a
+// burst of activity that's since gone quiet stops implying correlation one
+// key at a time instead of the whole group vanishing together at one
+// global deadline. A key's union-find node is left in place as long as its
+// component still has other live keys (removing it safely would require
+// knowing whether another still-live key's path runs through it); only
+// its bookkeeping - the keyIndex/keyExpiry entries and its place in the
+// owning group's Keys - is dropped. Once a component's last live key
+// expires, teardownComponent frees the whole component's parent/rank
+// entries and its CorrelationGroup, so the same value extracted again
+// later is treated as a fresh node, same as the first time it was ever
+// seen, instead of silently rejoining a component that's otherwise long
+// dead.
 func (c *Correlator) cleanup() {
-	ticker := time.NewTicker(5 * time.Minute)
+	ticker := time.NewTicker(1 * time.Minute)
 	defer ticker.Stop()
 
 	for range ticker.C {
-		c.mu.Lock()
-		now := time.Now()
-		for id, group := range c.correlations {
-			if now.Sub(group.UpdatedAt) > c.ttl {
-				delete(c.correlations, id)
-			}
+		c.evictExpiredKeys()
+	}
+}
+
+func (c *Correlator) evictExpiredKeys() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := time.Now()
+	for key, expiry := range c.keyExpiry {
+		if now.Before(expiry) {
+			continue
+		}
+
+		root := c.find(key)
+		delete(c.keyExpiry, key)
+		delete(c.keyIndex, key)
+
+		if group, ok := c.groups[root]; ok {
+			group.Keys = removeString(group.Keys, key)
+		}
+
+		c.liveCount[root]--
+		if c.liveCount[root] <= 0 {
+			c.teardownComponent(root)
 		}
-		c.mu.Unlock()
 	}
 }
 
+// teardownComponent frees every parent/rank entry belonging to root's
+// component, along with its members/liveCount bookkeeping and its
+// CorrelationGroup, once evictExpiredKeys has determined none of the
+// component's keys are still live.
+func (c *Correlator) teardownComponent(root string) {
+	for member := range c.members[root] {
+		delete(c.parent, member)
+		delete(c.rank, member)
+	}
+	delete(c.members, root)
+	delete(c.liveCount, root)
+	delete(c.groups, root)
+}
+
+func removeString(values []string, target string) []string {
+	out := values[:0]
+	for _, v := range values {
+		if v != target {
+			out = append(out, v)
+		}
+	}
+	return out
+}
+
 // GetGroup returns a correlation group by ID
 func (c *Correlator) GetGroup(id string) *CorrelationGroup {
 	c.mu.RLock()
 	defer c.mu.RUnlock()
-	return c.correlations[id]
+	return c.groups[id]
+}
+
+// ResolveByEventID finds the correlation group containing the event
+// identified by eventID and records the ticketID/status reported for it,
+// so a ticketing provider's webhook or reconciliation sync can close the
+// loop on the detection a ticket was originally raised from. It returns
+// the group's ID and true if a matching group was found.
+func (c *Correlator) ResolveByEventID(eventID, ticketID, status string) (string, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, group := range c.groups {
+		for _, event := range group.Events {
+			if event.ID != eventID {
+				continue
+			}
+
+			group.TicketID = ticketID
+			group.TicketStatus = status
+			group.UpdatedAt = time.Now()
+
+			c.logger.Debug("Correlation group updated from ticket event",
+				zap.String("correlation_id", group.ID),
+				zap.String("ticket_id", ticketID),
+				zap.String("status", status),
+			)
+
+			return group.ID, true
+		}
+	}
+
+	return "", false
+}
+
+// ReplaceRules validates and atomically swaps in a new set of correlation
+// rule groups, as pushed by a PeriodicRuleNotifier. A rule that fails
+// validation fails the whole batch rather than being silently dropped, so
+// one bad rule in a pushed update can't quietly stop correlation on
+// everything else's match fields.
+func (c *Correlator) ReplaceRules(groups []RuleGroup) error {
+	var ruleCount int
+	for _, group := range groups {
+		for _, rule := range group.Rules {
+			if err := rule.validate(); err != nil {
+				return fmt.Errorf("rule group %s: %w", group.Name, err)
+			}
+			ruleCount++
+		}
+	}
+
+	c.rulesMu.Lock()
+	c.ruleGroups = groups
+	c.rulesMu.Unlock()
+
+	c.logger.Info("Correlation rules reloaded",
+		zap.Int("groups", len(groups)),
+		zap.Int("rules", ruleCount),
+	)
+	return nil
+}
+
+// LoadedRuleGroups returns the currently active correlation rule groups,
+// for reporting via e.g. the /api/v1/rules endpoint.
+func (c *Correlator) LoadedRuleGroups() []RuleGroup {
+	c.rulesMu.RLock()
+	defer c.rulesMu.RUnlock()
+	return c.ruleGroups
 }
 
 // GetActiveGroups returns all active correlation groups
@@ -156,9 +474,30 @@ func (c *Correlator) GetActiveGroups() []*CorrelationGroup {
 	c.mu.RLock()
 	defer c.mu.RUnlock()
 
-	groups := make([]*CorrelationGroup, 0, len(c.correlations))
-	for _, group := range c.correlations {
+	groups := make([]*CorrelationGroup, 0, len(c.groups))
+	for _, group := range c.groups {
 		groups = append(groups, group)
 	}
 	return groups
 }
+
+// CorrelatorSnapshot exposes Correlator's internal component graph
+// alongside its groups, for tests that need to assert on clustering
+// behavior (e.g. that two groups actually merged) rather than just the
+// group each event landed in.
+type CorrelatorSnapshot struct {
+	Groups []*CorrelationGroup
+	Nodes  int // live correlation keys currently tracked in the union-find graph
+}
+
+// Snapshot returns the current state of the component graph for testing.
+func (c *Correlator) Snapshot() CorrelatorSnapshot {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	groups := make([]*CorrelationGroup, 0, len(c.groups))
+	for _, group := range c.groups {
+		groups = append(groups, group)
+	}
+	return CorrelatorSnapshot{Groups: groups, Nodes: len(c.keyIndex)}
+}
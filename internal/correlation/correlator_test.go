@@ -0,0 +1,142 @@
+package correlation
+
+import (
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/lvonguyen/threat-telemetry-hub/internal/normalization"
+)
+
+func newTestEvent(id, sourceIP string) *normalization.NormalizedEvent {
+	return &normalization.NormalizedEvent{
+		ID:   id,
+		Data: map[string]interface{}{"source_ip": sourceIP},
+	}
+}
+
+// TestCorrelatorEvictionTearsDownComponent confirms that once a
+// correlation key's TTL elapses and it was the last live key in its
+// component, evictExpiredKeys frees the component's union-find and group
+// bookkeeping entirely rather than just its keyIndex/keyExpiry entries -
+// so the same key value reused later starts a fresh component instead of
+// silently rejoining the evicted one.
+func TestCorrelatorEvictionTearsDownComponent(t *testing.T) {
+	c := NewCorrelator(zap.NewNop())
+
+	firstID := c.Correlate(newTestEvent("evt-1", "203.0.113.5"))
+
+	c.mu.Lock()
+	c.keyExpiry["ip:203.0.113.5"] = time.Now().Add(-time.Second)
+	c.mu.Unlock()
+
+	c.evictExpiredKeys()
+
+	snap := c.Snapshot()
+	if snap.Nodes != 0 {
+		t.Fatalf("Nodes after last key evicted = %d, want 0", snap.Nodes)
+	}
+	if len(snap.Groups) != 0 {
+		t.Fatalf("Groups after last key evicted = %d, want 0", len(snap.Groups))
+	}
+	if c.GetGroup(firstID) != nil {
+		t.Fatalf("GetGroup(%q) still returns a group after its component was torn down", firstID)
+	}
+
+	secondID := c.Correlate(newTestEvent("evt-2", "203.0.113.5"))
+
+	// The key's component root is just the key's own string value, so
+	// reusing the same IP after teardown can legitimately produce the same
+	// ID; what matters is the group is otherwise fresh - it must not carry
+	// over evt-1, which belonged to the torn-down component.
+	group := c.GetGroup(secondID)
+	if group == nil {
+		t.Fatalf("GetGroup(%q) = nil after correlating a fresh event", secondID)
+	}
+	if len(group.Events) != 1 || group.Events[0].ID != "evt-2" {
+		t.Fatalf("group.Events = %v, want only evt-2 - evt-1 leaked across the torn-down component", group.Events)
+	}
+}
+
+// TestCorrelatorEvictionKeepsComponentAliveWithOtherLiveKeys confirms that
+// evicting one key out of a merged component doesn't tear the component
+// down while another of its keys is still live.
+func TestCorrelatorEvictionKeepsComponentAliveWithOtherLiveKeys(t *testing.T) {
+	c := NewCorrelator(zap.NewNop())
+
+	event := &normalization.NormalizedEvent{
+		ID: "evt-1",
+		Data: map[string]interface{}{
+			"source_ip": "198.51.100.9",
+			"user":      "alice",
+		},
+	}
+	groupID := c.Correlate(event)
+
+	c.mu.Lock()
+	c.keyExpiry["ip:198.51.100.9"] = time.Now().Add(-time.Second)
+	c.mu.Unlock()
+
+	c.evictExpiredKeys()
+
+	if c.GetGroup(groupID) == nil {
+		t.Fatalf("GetGroup(%q) = nil, want the group to survive while the \"user:alice\" key is still live", groupID)
+	}
+	if snap := c.Snapshot(); snap.Nodes == 0 {
+		t.Fatalf("Snapshot().Nodes = 0, want the still-live \"user:alice\" node to remain")
+	}
+
+	// Now expire the remaining key too; the component must fully tear down.
+	c.mu.Lock()
+	c.keyExpiry["user:alice"] = time.Now().Add(-time.Second)
+	c.mu.Unlock()
+
+	c.evictExpiredKeys()
+
+	if c.GetGroup(groupID) != nil {
+		t.Fatalf("GetGroup(%q) still returns a group after every key in its component expired", groupID)
+	}
+	if snap := c.Snapshot(); snap.Nodes != 0 {
+		t.Fatalf("Snapshot().Nodes = %d, want 0 once every key has expired", snap.Nodes)
+	}
+}
+
+// TestCorrelatorMergedComponentTeardown confirms that a component formed
+// by merging two previously-separate groups (via a bridging event) still
+// tears down correctly once all of its keys - from either original group -
+// have expired, exercising union's members/liveCount bookkeeping across a
+// merge.
+func TestCorrelatorMergedComponentTeardown(t *testing.T) {
+	c := NewCorrelator(zap.NewNop())
+
+	c.Correlate(newTestEvent("evt-1", "192.0.2.1"))
+	c.Correlate(&normalization.NormalizedEvent{ID: "evt-2", Data: map[string]interface{}{"user": "bob"}})
+
+	// Bridge the two components together with one event carrying both keys.
+	bridgeID := c.Correlate(&normalization.NormalizedEvent{
+		ID: "evt-3",
+		Data: map[string]interface{}{
+			"source_ip": "192.0.2.1",
+			"user":      "bob",
+		},
+	})
+
+	if snap := c.Snapshot(); len(snap.Groups) != 1 {
+		t.Fatalf("Groups after bridging event = %d, want 1 merged group", len(snap.Groups))
+	}
+
+	c.mu.Lock()
+	c.keyExpiry["ip:192.0.2.1"] = time.Now().Add(-time.Second)
+	c.keyExpiry["user:bob"] = time.Now().Add(-time.Second)
+	c.mu.Unlock()
+
+	c.evictExpiredKeys()
+
+	if snap := c.Snapshot(); len(snap.Groups) != 0 || snap.Nodes != 0 {
+		t.Fatalf("Snapshot() after evicting every key of a merged component = %+v, want empty", snap)
+	}
+	if c.GetGroup(bridgeID) != nil {
+		t.Fatalf("GetGroup(%q) still returns a group after the merged component fully expired", bridgeID)
+	}
+}
@@ -0,0 +1,105 @@
+package correlation
+
+import (
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Correlator implements prometheus.Collector, computing its metrics from
+// the live component graph at scrape time (the same node_exporter-style
+// pattern promhttp.Handler already triggers for every registered
+// collector) rather than maintaining running counters that would need
+// updating on every merge, eviction, and TTL expiry. New correlation
+// dimensions can be added here later without touching
+// observability.Metrics.
+var _ prometheus.Collector = (*Correlator)(nil)
+
+func (c *Correlator) groupsGauge() prometheus.Gauge {
+	return prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: "threat_telemetry_hub",
+		Subsystem: "correlation",
+		Name:      "groups",
+		Help:      "Current number of active correlation groups.",
+	})
+}
+
+func (c *Correlator) groupEventsHistogram() prometheus.Histogram {
+	return prometheus.NewHistogram(prometheus.HistogramOpts{
+		Namespace: "threat_telemetry_hub",
+		Subsystem: "correlation",
+		Name:      "group_events",
+		Help:      "Histogram of event counts across active correlation groups.",
+		Buckets:   prometheus.ExponentialBuckets(1, 2, 10),
+	})
+}
+
+func (c *Correlator) groupAgeHistogram() prometheus.Histogram {
+	return prometheus.NewHistogram(prometheus.HistogramOpts{
+		Namespace: "threat_telemetry_hub",
+		Subsystem: "correlation",
+		Name:      "group_age_seconds",
+		Help:      "Histogram of active correlation groups' age (time since creation), in seconds.",
+		Buckets:   prometheus.ExponentialBuckets(1, 4, 10),
+	})
+}
+
+func (c *Correlator) groupKeysGaugeVec() *prometheus.GaugeVec {
+	return prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "threat_telemetry_hub",
+		Subsystem: "correlation",
+		Name:      "group_keys",
+		Help:      "Current number of live correlation keys across all groups, by kind.",
+	}, []string{"kind"})
+}
+
+// Describe implements prometheus.Collector.
+func (c *Correlator) Describe(ch chan<- *prometheus.Desc) {
+	c.groupsGauge().Describe(ch)
+	c.groupEventsHistogram().Describe(ch)
+	c.groupAgeHistogram().Describe(ch)
+	c.groupKeysGaugeVec().Describe(ch)
+}
+
+// Collect implements prometheus.Collector, walking c.groups under the read
+// lock and emitting freshly computed metrics from it.
+func (c *Correlator) Collect(ch chan<- prometheus.Metric) {
+	c.mu.RLock()
+	groups := make([]*CorrelationGroup, 0, len(c.groups))
+	for _, group := range c.groups {
+		groups = append(groups, group)
+	}
+	c.mu.RUnlock()
+
+	groupsGauge := c.groupsGauge()
+	groupsGauge.Set(float64(len(groups)))
+	groupsGauge.Collect(ch)
+
+	eventsHist := c.groupEventsHistogram()
+	ageHist := c.groupAgeHistogram()
+	keysVec := c.groupKeysGaugeVec()
+
+	now := time.Now()
+	for _, group := range groups {
+		eventsHist.Observe(float64(len(group.Events)))
+		ageHist.Observe(now.Sub(group.CreatedAt).Seconds())
+		for _, key := range group.Keys {
+			keysVec.WithLabelValues(keyKind(key)).Inc()
+		}
+	}
+
+	eventsHist.Collect(ch)
+	ageHist.Collect(ch)
+	keysVec.Collect(ch)
+}
+
+// keyKind returns the namespace prefix of a correlation key (e.g. "ip" for
+// "ip:10.0.0.1", "rule" for "rule:t1059:cmdline:..."), for bucketing
+// group_keys by kind instead of one label value per distinct key.
+func keyKind(key string) string {
+	if idx := strings.Index(key, ":"); idx >= 0 {
+		return key[:idx]
+	}
+	return key
+}
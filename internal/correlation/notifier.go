@@ -0,0 +1,89 @@
+package correlation
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// PeriodicRuleNotifier polls a Finder on an interval and pushes the
+// discovered []RuleGroup onto NotifyC whenever the set of group hashes has
+// changed since the last poll, mirroring the rule-discovery pattern used
+// in metrics-agent projects (e.g. Prometheus file-based service
+// discovery): operators update rules at the source and have them picked
+// up within one interval, without the hub restarting or even being told
+// which groups changed.
+type PeriodicRuleNotifier struct {
+	finder   Finder
+	interval time.Duration
+	logger   *zap.Logger
+	notifyC  chan []RuleGroup
+
+	lastHashes map[string]string
+}
+
+// NewPeriodicRuleNotifier creates a notifier that polls finder every
+// interval.
+func NewPeriodicRuleNotifier(finder Finder, interval time.Duration, logger *zap.Logger) *PeriodicRuleNotifier {
+	return &PeriodicRuleNotifier{
+		finder:   finder,
+		interval: interval,
+		logger:   logger,
+		notifyC:  make(chan []RuleGroup, 1),
+	}
+}
+
+// NotifyC returns the channel PeriodicRuleNotifier delivers the current
+// []RuleGroup set on, whenever it changes.
+func (n *PeriodicRuleNotifier) NotifyC() <-chan []RuleGroup {
+	return n.notifyC
+}
+
+// Start polls n.finder every interval until ctx is done. It performs one
+// poll immediately on entry so the notifier's caller doesn't need to wait
+// a full interval for the initial rule set.
+func (n *PeriodicRuleNotifier) Start(ctx context.Context) {
+	n.poll(ctx)
+
+	ticker := time.NewTicker(n.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			n.poll(ctx)
+		}
+	}
+}
+
+func (n *PeriodicRuleNotifier) poll(ctx context.Context) {
+	groups, err := n.finder.Find(ctx)
+	if err != nil {
+		n.logger.Warn("Correlation rule discovery failed", zap.Error(err))
+		return
+	}
+
+	hashes := make(map[string]string, len(groups))
+	changed := false
+	for _, group := range groups {
+		hashes[group.Name] = group.Hash
+		if n.lastHashes[group.Name] != group.Hash {
+			changed = true
+		}
+	}
+	if len(hashes) != len(n.lastHashes) {
+		changed = true
+	}
+	if !changed {
+		return
+	}
+	n.lastHashes = hashes
+
+	select {
+	case n.notifyC <- groups:
+	case <-ctx.Done():
+	}
+}
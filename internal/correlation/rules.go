@@ -0,0 +1,177 @@
+package correlation
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Rule is a MITRE-tagged correlation rule: events that share a value for
+// any field in MatchFields (a top-level key of NormalizedEvent.Data; a
+// dotted name is looked up as one literal key, not as a path into nested
+// maps) are correlated together, on top of Correlator's built-in
+// ip/user/host/hash/domain keys.
+type Rule struct {
+	ID          string   `yaml:"id"`
+	Name        string   `yaml:"name"`
+	MITRE       []string `yaml:"mitre"`
+	MatchFields []string `yaml:"match_fields"`
+
+	// Weight scores how strongly a shared value for this rule implies two
+	// events belong together. A negative weight disables the rule's
+	// keys from ever causing a merge, while keeping them in MatchFields
+	// lets an operator stage a new rule before it affects clustering.
+	// Zero (including unset) defaults to 1.0.
+	Weight float64 `yaml:"weight"`
+
+	// TTLSeconds is how long a value extracted by this rule keeps two
+	// events correlated after the most recent one carrying it. Zero uses
+	// Correlator's default TTL, the same fallback built-in keys use.
+	TTLSeconds int `yaml:"ttl_seconds"`
+}
+
+func (r Rule) validate() error {
+	if r.ID == "" {
+		return fmt.Errorf("rule missing id")
+	}
+	if len(r.MatchFields) == 0 {
+		return fmt.Errorf("rule %s: match_fields is required", r.ID)
+	}
+	return nil
+}
+
+// weight returns r.Weight, defaulting to 1.0 when unset.
+func (r Rule) weight() float64 {
+	if r.Weight == 0 {
+		return 1.0
+	}
+	return r.Weight
+}
+
+// ttl returns r.TTLSeconds as a time.Duration, falling back to def when
+// unset.
+func (r Rule) ttl(def time.Duration) time.Duration {
+	if r.TTLSeconds <= 0 {
+		return def
+	}
+	return time.Duration(r.TTLSeconds) * time.Second
+}
+
+// RuleGroup is a named set of correlation rules loaded from a single
+// source (a file, a Git ref, an S3 prefix), along with a content hash so
+// PeriodicRuleNotifier can tell whether it's changed since the last poll.
+type RuleGroup struct {
+	Name     string
+	Source   string
+	Hash     string
+	Rules    []Rule
+	LoadedAt time.Time
+}
+
+// Finder discovers the current set of correlation RuleGroups from some
+// source. PeriodicRuleNotifier polls it on an interval and only notifies
+// when the discovered groups' hashes differ from what it last saw.
+type Finder interface {
+	Find(ctx context.Context) ([]RuleGroup, error)
+}
+
+// isRuleFile reports whether name is a rule group file a Finder loads.
+func isRuleFile(name string) bool {
+	return strings.HasSuffix(name, ".yml") || strings.HasSuffix(name, ".yaml")
+}
+
+// FileFinder discovers RuleGroups from YAML files in Dir, one group per
+// file, mirroring detection.Engine's filesystem rule loading.
+type FileFinder struct {
+	Dir string
+}
+
+// NewFileFinder creates a FileFinder rooted at dir.
+func NewFileFinder(dir string) *FileFinder {
+	return &FileFinder{Dir: dir}
+}
+
+// Find reads every rule group file under f.Dir. A missing directory yields
+// no groups rather than an error, so a hub that doesn't use correlation
+// rule groups yet doesn't need to pre-create one.
+func (f *FileFinder) Find(_ context.Context) ([]RuleGroup, error) {
+	entries, err := os.ReadDir(f.Dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading rule groups dir %s: %w", f.Dir, err)
+	}
+
+	var groups []RuleGroup
+	for _, entry := range entries {
+		if entry.IsDir() || !isRuleFile(entry.Name()) {
+			continue
+		}
+
+		path := filepath.Join(f.Dir, entry.Name())
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("reading rule group %s: %w", path, err)
+		}
+
+		var rules []Rule
+		if err := yaml.Unmarshal(data, &rules); err != nil {
+			return nil, fmt.Errorf("parsing rule group %s: %w", path, err)
+		}
+
+		sum := sha256.Sum256(data)
+		groups = append(groups, RuleGroup{
+			Name:     entry.Name(),
+			Source:   path,
+			Hash:     hex.EncodeToString(sum[:]),
+			Rules:    rules,
+			LoadedAt: time.Now(),
+		})
+	}
+	return groups, nil
+}
+
+// GitFinder discovers RuleGroups from YAML rule files at Ref in a Git
+// repository, so operators can `git push` new correlation rules instead of
+// copying files onto every hub instance.
+type GitFinder struct {
+	RepoURL string
+	Ref     string
+}
+
+// NewGitFinder creates a GitFinder.
+func NewGitFinder(repoURL, ref string) *GitFinder {
+	return &GitFinder{RepoURL: repoURL, Ref: ref}
+}
+
+func (f *GitFinder) Find(_ context.Context) ([]RuleGroup, error) {
+	// TODO: shallow-clone or fetch RepoURL at Ref into a temp dir and delegate
+	// to FileFinder, so a `git push` of new rules is visible within one poll
+	// interval without restarting the hub.
+	return nil, fmt.Errorf("git rule source not implemented")
+}
+
+// S3Finder discovers RuleGroups from YAML rule files under Prefix in an S3
+// bucket.
+type S3Finder struct {
+	Bucket string
+	Prefix string
+}
+
+// NewS3Finder creates an S3Finder.
+func NewS3Finder(bucket, prefix string) *S3Finder {
+	return &S3Finder{Bucket: bucket, Prefix: prefix}
+}
+
+func (f *S3Finder) Find(_ context.Context) ([]RuleGroup, error) {
+	// TODO: list and fetch objects under Prefix via aws-sdk-go-v2/service/s3.
+	return nil, fmt.Errorf("s3 rule source not implemented")
+}
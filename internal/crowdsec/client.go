@@ -0,0 +1,132 @@
+// Package crowdsec is a minimal client for the CrowdSec Local API (LAPI).
+// It's used on both sides of the hub's CrowdSec integration: the
+// "crowdsec" ingestion collector pulls the decisions stream with it, and
+// ticketing.CrowdSecRemediationSink pushes decisions back with it.
+package crowdsec
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/lvonguyen/threat-telemetry-hub/internal/config"
+)
+
+// Client is a thin LAPI client authenticating with a machine API key
+// (`cscli machines add`), not the browser-facing login/refresh-token flow.
+type Client struct {
+	baseURL    string
+	apiKey     string
+	httpClient *http.Client
+}
+
+// NewClient creates a new CrowdSec LAPI client from cfg.
+func NewClient(cfg config.CrowdSecConfig) (*Client, error) {
+	apiKey := os.Getenv(cfg.APIKeyEnv)
+	if cfg.LAPIURL == "" || apiKey == "" {
+		return nil, fmt.Errorf("missing required CrowdSec LAPI configuration")
+	}
+
+	return &Client{
+		baseURL:    cfg.LAPIURL,
+		apiKey:     apiKey,
+		httpClient: &http.Client{Timeout: 30 * time.Second},
+	}, nil
+}
+
+// Decision is a CrowdSec LAPI decision: Type (e.g. "ban") applied to Value
+// (an IP, CIDR range, or AS number) within Scope, on account of Scenario.
+type Decision struct {
+	ID       int    `json:"id,omitempty"`
+	Origin   string `json:"origin"`
+	Type     string `json:"type"`
+	Scope    string `json:"scope"`
+	Value    string `json:"value"`
+	Duration string `json:"duration"`
+	Scenario string `json:"scenario"`
+}
+
+// DecisionsStreamResponse is the /v1/decisions/stream response body:
+// decisions added or expired/removed since the previous poll.
+type DecisionsStreamResponse struct {
+	New     []Decision `json:"new"`
+	Deleted []Decision `json:"deleted"`
+}
+
+// StreamDecisions polls /v1/decisions/stream. startup must be true on a
+// collector's first call - LAPI then responds with every currently active
+// decision rather than only what changed - and false on every call after.
+func (c *Client) StreamDecisions(ctx context.Context, startup bool) (*DecisionsStreamResponse, error) {
+	url := fmt.Sprintf("%s/v1/decisions/stream?startup=%t", c.baseURL, startup)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("creating request: %w", err)
+	}
+	req.Header.Set("X-Api-Key", c.apiKey)
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("making request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("LAPI returned status %d", resp.StatusCode)
+	}
+
+	var stream DecisionsStreamResponse
+	if err := json.NewDecoder(resp.Body).Decode(&stream); err != nil {
+		return nil, fmt.Errorf("decoding response: %w", err)
+	}
+	return &stream, nil
+}
+
+// Alert is the LAPI record a pushed Decision is attached to, so it shows up
+// in `cscli alerts list` alongside decisions from CrowdSec's own scenarios.
+type Alert struct {
+	Scenario  string      `json:"scenario"`
+	Message   string      `json:"message"`
+	StartAt   string      `json:"start_at"`
+	StopAt    string      `json:"stop_at"`
+	Source    AlertSource `json:"source"`
+	Decisions []Decision  `json:"decisions"`
+}
+
+// AlertSource identifies what an Alert's decisions apply to.
+type AlertSource struct {
+	Scope string `json:"scope"`
+	Value string `json:"value"`
+}
+
+// PushAlert posts alert to /v1/alerts, the LAPI endpoint bouncers poll to
+// enforce its attached decisions.
+func (c *Client) PushAlert(ctx context.Context, alert Alert) error {
+	body, err := json.Marshal([]Alert{alert})
+	if err != nil {
+		return fmt.Errorf("marshaling alert: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.baseURL+"/v1/alerts", bytes.NewBuffer(body))
+	if err != nil {
+		return fmt.Errorf("creating request: %w", err)
+	}
+	req.Header.Set("X-Api-Key", c.apiKey)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("making request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
+		return fmt.Errorf("LAPI returned status %d", resp.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,95 @@
+package detection
+
+import (
+	"fmt"
+
+	"github.com/google/cel-go/cel"
+	"gopkg.in/yaml.v3"
+
+	"github.com/lvonguyen/threat-telemetry-hub/internal/normalization"
+)
+
+// celRuleDoc is the on-disk shape of a CEL rule file: a single boolean
+// expression evaluated against `event` and `enrichment`.
+type celRuleDoc struct {
+	ID         string   `yaml:"id"`
+	Name       string   `yaml:"name"`
+	Level      string   `yaml:"level"`
+	MITRE      []string `yaml:"mitre"`
+	Expression string   `yaml:"expression"`
+}
+
+// CELRule wraps a compiled CEL expression for conditions Sigma's
+// field-equality model can't express - cross-field arithmetic,
+// enrichment-aware thresholds like
+// `enrichment.entra_id.risk_level == "high" && event.severity >= 70`.
+type CELRule struct {
+	id      string
+	name    string
+	level   string
+	mitre   []string
+	program cel.Program
+}
+
+func (r *CELRule) ID() string            { return r.id }
+func (r *CELRule) Name() string          { return r.name }
+func (r *CELRule) Enabled() bool         { return true }
+func (r *CELRule) MITRE() []string       { return r.mitre }
+func (r *CELRule) SeverityLevel() string { return r.level }
+
+func (r *CELRule) Evaluate(event *normalization.NormalizedEvent, enrichments map[string]interface{}) (bool, map[string]interface{}) {
+	fields := make(map[string]interface{})
+	flatten("", event.Data, fields)
+	fields["severity"] = event.Severity
+	fields["category"] = event.Category
+	fields["type"] = event.Type
+
+	out, _, err := r.program.Eval(map[string]interface{}{
+		"event":      fields,
+		"enrichment": enrichments,
+	})
+	if err != nil {
+		return false, nil
+	}
+	matched, ok := out.Value().(bool)
+	if !ok || !matched {
+		return false, nil
+	}
+	return true, map[string]interface{}{"cel_expression": true}
+}
+
+// CompileCELRule parses a CEL rule file and compiles its expression against
+// an environment exposing `event` and `enrichment` as dynamic maps.
+func CompileCELRule(data []byte) (*CELRule, error) {
+	var doc celRuleDoc
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("parsing cel rule: %w", err)
+	}
+	if doc.ID == "" || doc.Expression == "" {
+		return nil, fmt.Errorf("cel rule missing id or expression")
+	}
+
+	env, err := cel.NewEnv(
+		cel.Variable("event", cel.DynType),
+		cel.Variable("enrichment", cel.DynType),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("creating cel environment: %w", err)
+	}
+	ast, iss := env.Compile(doc.Expression)
+	if iss.Err() != nil {
+		return nil, fmt.Errorf("compiling cel expression: %w", iss.Err())
+	}
+	program, err := env.Program(ast)
+	if err != nil {
+		return nil, fmt.Errorf("building cel program: %w", err)
+	}
+
+	return &CELRule{
+		id:      doc.ID,
+		name:    doc.Name,
+		level:   doc.Level,
+		mitre:   doc.MITRE,
+		program: program,
+	}, nil
+}
@@ -0,0 +1,170 @@
+package detection
+
+import (
+	"fmt"
+	"strings"
+)
+
+// conditionNode is a compiled Sigma `condition` expression. This engine
+// supports the common subset of the Sigma condition grammar - selection
+// identifiers combined with and/or/not and parentheses. Aggregation forms
+// like "1 of selection*" or "count() > N" are not yet implemented; a rule
+// using them fails to compile with a clear error rather than silently
+// matching nothing.
+type conditionNode interface {
+	eval(selections map[string]selection, fields map[string]interface{}) (bool, map[string]interface{})
+}
+
+type identNode string
+
+func (n identNode) eval(selections map[string]selection, fields map[string]interface{}) (bool, map[string]interface{}) {
+	sel, ok := selections[string(n)]
+	if !ok {
+		return false, nil
+	}
+	return sel.matches(fields)
+}
+
+type notNode struct{ inner conditionNode }
+
+func (n notNode) eval(selections map[string]selection, fields map[string]interface{}) (bool, map[string]interface{}) {
+	matched, _ := n.inner.eval(selections, fields)
+	return !matched, nil
+}
+
+type andNode struct{ left, right conditionNode }
+
+func (n andNode) eval(selections map[string]selection, fields map[string]interface{}) (bool, map[string]interface{}) {
+	lok, lm := n.left.eval(selections, fields)
+	if !lok {
+		return false, nil
+	}
+	rok, rm := n.right.eval(selections, fields)
+	if !rok {
+		return false, nil
+	}
+	return true, mergeMatches(lm, rm)
+}
+
+type orNode struct{ left, right conditionNode }
+
+func (n orNode) eval(selections map[string]selection, fields map[string]interface{}) (bool, map[string]interface{}) {
+	if ok, m := n.left.eval(selections, fields); ok {
+		return true, m
+	}
+	return n.right.eval(selections, fields)
+}
+
+func mergeMatches(a, b map[string]interface{}) map[string]interface{} {
+	out := make(map[string]interface{}, len(a)+len(b))
+	for k, v := range a {
+		out[k] = v
+	}
+	for k, v := range b {
+		out[k] = v
+	}
+	return out
+}
+
+// parseCondition compiles a Sigma condition expression (e.g.
+// "selection1 and not selection2") into a conditionNode.
+func parseCondition(expr string) (conditionNode, error) {
+	p := &condParser{tokens: tokenizeCondition(expr)}
+	node, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if p.pos != len(p.tokens) {
+		return nil, fmt.Errorf("unexpected token %q", p.tokens[p.pos])
+	}
+	return node, nil
+}
+
+func tokenizeCondition(expr string) []string {
+	expr = strings.ReplaceAll(expr, "(", " ( ")
+	expr = strings.ReplaceAll(expr, ")", " ) ")
+	return strings.Fields(expr)
+}
+
+type condParser struct {
+	tokens []string
+	pos    int
+}
+
+func (p *condParser) peek() string {
+	if p.pos >= len(p.tokens) {
+		return ""
+	}
+	return p.tokens[p.pos]
+}
+
+func (p *condParser) next() string {
+	tok := p.peek()
+	p.pos++
+	return tok
+}
+
+func (p *condParser) parseOr() (conditionNode, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for strings.EqualFold(p.peek(), "or") {
+		p.next()
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = orNode{left, right}
+	}
+	return left, nil
+}
+
+func (p *condParser) parseAnd() (conditionNode, error) {
+	left, err := p.parseNot()
+	if err != nil {
+		return nil, err
+	}
+	for strings.EqualFold(p.peek(), "and") {
+		p.next()
+		right, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		left = andNode{left, right}
+	}
+	return left, nil
+}
+
+func (p *condParser) parseNot() (conditionNode, error) {
+	if strings.EqualFold(p.peek(), "not") {
+		p.next()
+		inner, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		return notNode{inner}, nil
+	}
+	return p.parseAtom()
+}
+
+func (p *condParser) parseAtom() (conditionNode, error) {
+	tok := p.next()
+	switch tok {
+	case "":
+		return nil, fmt.Errorf("unexpected end of condition")
+	case "(":
+		inner, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if p.next() != ")" {
+			return nil, fmt.Errorf("expected closing parenthesis")
+		}
+		return inner, nil
+	case ")":
+		return nil, fmt.Errorf("unexpected closing parenthesis")
+	default:
+		return identNode(tok), nil
+	}
+}
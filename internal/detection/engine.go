@@ -0,0 +1,221 @@
+package detection
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/fsnotify/fsnotify"
+	"go.uber.org/zap"
+
+	"github.com/lvonguyen/threat-telemetry-hub/internal/config"
+	"github.com/lvonguyen/threat-telemetry-hub/internal/normalization"
+)
+
+// DedupKeyField is the flattened field the rate limiter keys on when
+// picking which entity a detection belongs to. Most rules in this hub are
+// scoped to a user, so this is a reasonable default; a rule-specific key
+// can be layered on later without changing the Engine's public surface.
+const DedupKeyField = "actor.user.name"
+
+// Engine evaluates every NormalizedEvent against the loaded rule set,
+// returning a Detection per match. It sits between enrichment and any
+// downstream alert sink.
+type Engine struct {
+	config config.DetectionConfig
+	logger *zap.Logger
+
+	mu    sync.RWMutex
+	rules []Rule
+
+	limiterMu sync.Mutex
+	limiters  map[string]*leakyBucket
+}
+
+// NewEngine creates an Engine and performs an initial rule load from the
+// configured Sigma and CEL rule directories.
+func NewEngine(cfg config.DetectionConfig, logger *zap.Logger) (*Engine, error) {
+	e := &Engine{
+		config:   cfg,
+		logger:   logger,
+		limiters: make(map[string]*leakyBucket),
+	}
+	if err := e.Reload(); err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+// Reload re-reads every rule file under the configured rule directories,
+// replacing the active rule set atomically. A rule that fails to compile
+// fails the whole reload rather than silently dropping that rule, so
+// broken rules are caught at deploy time (or by `tth rules validate`)
+// instead of quietly never firing.
+func (e *Engine) Reload() error {
+	var rules []Rule
+
+	sigmaRules, err := loadRules(e.config.SigmaRulesDir, func(data []byte) (Rule, error) {
+		return CompileSigmaRule(data)
+	})
+	if err != nil {
+		return err
+	}
+	rules = append(rules, sigmaRules...)
+
+	celRules, err := loadRules(e.config.CELRulesDir, func(data []byte) (Rule, error) {
+		return CompileCELRule(data)
+	})
+	if err != nil {
+		return err
+	}
+	rules = append(rules, celRules...)
+
+	e.mu.Lock()
+	e.rules = rules
+	e.mu.Unlock()
+
+	e.logger.Info("Detection rules loaded", zap.Int("count", len(rules)))
+	return nil
+}
+
+func loadRules(dir string, compile func([]byte) (Rule, error)) ([]Rule, error) {
+	entries, err := os.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading rules dir %s: %w", dir, err)
+	}
+
+	var rules []Rule
+	for _, entry := range entries {
+		if entry.IsDir() || !isRuleFile(entry.Name()) {
+			continue
+		}
+		path := filepath.Join(dir, entry.Name())
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("reading rule %s: %w", path, err)
+		}
+		rule, err := compile(data)
+		if err != nil {
+			return nil, fmt.Errorf("compiling rule %s: %w", path, err)
+		}
+		rules = append(rules, rule)
+	}
+	return rules, nil
+}
+
+// isRuleFile reports whether name is a rule file this engine loads. Rules
+// disabled via `tth rules disable` are renamed with a ".disabled" suffix
+// and skipped here.
+func isRuleFile(name string) bool {
+	return strings.HasSuffix(name, ".yml") || strings.HasSuffix(name, ".yaml")
+}
+
+// Watch watches the configured rule directories for changes and reloads
+// the rule set on every create/write/remove/rename, until ctx is canceled.
+func (e *Engine) Watch(ctx context.Context) error {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return fmt.Errorf("creating rule watcher: %w", err)
+	}
+	defer watcher.Close()
+
+	for _, dir := range []string{e.config.SigmaRulesDir, e.config.CELRulesDir} {
+		if dir == "" {
+			continue
+		}
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return fmt.Errorf("creating rules dir %s: %w", dir, err)
+		}
+		if err := watcher.Add(dir); err != nil {
+			return fmt.Errorf("watching rules dir %s: %w", dir, err)
+		}
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case event, ok := <-watcher.Events:
+			if !ok {
+				return nil
+			}
+			if !isRuleFile(event.Name) {
+				continue
+			}
+			if err := e.Reload(); err != nil {
+				e.logger.Warn("Rule hot-reload failed", zap.Error(err))
+				continue
+			}
+			e.logger.Info("Rule hot-reload applied", zap.String("trigger", event.Name))
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return nil
+			}
+			e.logger.Warn("Rule watcher error", zap.Error(err))
+		}
+	}
+}
+
+// Evaluate runs event (with its enrichment map) against every loaded,
+// enabled rule and returns one Detection per match, subject to each rule's
+// leaky-bucket rate limit.
+func (e *Engine) Evaluate(event *normalization.NormalizedEvent, enrichments map[string]interface{}) []Detection {
+	e.mu.RLock()
+	rules := e.rules
+	e.mu.RUnlock()
+
+	var detections []Detection
+	for _, rule := range rules {
+		if !rule.Enabled() {
+			continue
+		}
+		matched, fields := rule.Evaluate(event, enrichments)
+		if !matched {
+			continue
+		}
+		if !e.allow(rule.ID(), dedupKey(fields, event)) {
+			continue
+		}
+		detections = append(detections, Detection{
+			RuleID:        rule.ID(),
+			RuleName:      rule.Name(),
+			MITRE:         rule.MITRE(),
+			Severity:      rule.SeverityLevel(),
+			MatchedFields: fields,
+			Event:         event,
+		})
+	}
+	return detections
+}
+
+func (e *Engine) allow(ruleID, key string) bool {
+	e.limiterMu.Lock()
+	limiter, ok := e.limiters[ruleID]
+	if !ok {
+		limiter = newLeakyBucket(0.1, 5) // burst of 5, steady state 1 per 10s
+		e.limiters[ruleID] = limiter
+	}
+	e.limiterMu.Unlock()
+	return limiter.allow(key)
+}
+
+// dedupKey picks the entity the rate limiter keys on: the matched field
+// value for DedupKeyField if the rule matched on it, else the event's own
+// actor, else the rule is treated as ungrouped ("global").
+func dedupKey(matched map[string]interface{}, event *normalization.NormalizedEvent) string {
+	if v, ok := matched[DedupKeyField]; ok {
+		return fmt.Sprintf("%v", v)
+	}
+	fields := make(map[string]interface{})
+	flatten("", event.Data, fields)
+	if v, ok := fields[DedupKeyField]; ok {
+		return fmt.Sprintf("%v", v)
+	}
+	return "global"
+}
@@ -0,0 +1,39 @@
+package detection
+
+import "github.com/lvonguyen/threat-telemetry-hub/internal/normalization"
+
+// flatten turns a nested map into a dot-joined key -> scalar map, e.g.
+// {"actor": {"user": {"name": "alice"}}} -> {"actor.user.name": "alice"}.
+// Sigma field selectors and CEL expressions both operate on this flat view.
+func flatten(prefix string, in map[string]interface{}, out map[string]interface{}) {
+	for k, v := range in {
+		key := k
+		if prefix != "" {
+			key = prefix + "." + k
+		}
+		if nested, ok := v.(map[string]interface{}); ok {
+			flatten(key, nested, out)
+			continue
+		}
+		out[key] = v
+	}
+}
+
+// eventFields builds the flat field view Sigma rules match against: the
+// normalized event's data, common top-level metadata, and each enrichment
+// source's fields namespaced by source name (e.g. "entra_id.risk_level").
+func eventFields(event *normalization.NormalizedEvent, enrichments map[string]interface{}) map[string]interface{} {
+	fields := make(map[string]interface{})
+	flatten("", event.Data, fields)
+	fields["severity"] = event.Severity
+	fields["category"] = event.Category
+	fields["type"] = event.Type
+	fields["source"] = event.Source.Name
+
+	for source, data := range enrichments {
+		if m, ok := data.(map[string]interface{}); ok {
+			flatten(source, m, fields)
+		}
+	}
+	return fields
+}
@@ -0,0 +1,53 @@
+package detection
+
+import (
+	"sync"
+	"time"
+)
+
+// leakyBucket rate-limits detections per dedup key (e.g. a user_id) so one
+// noisy entity can't drown out everything else a rule would otherwise
+// catch. Each key gets its own bucket, leaking at a fixed rate.
+type leakyBucket struct {
+	rate     float64 // tokens leaked per second
+	capacity float64
+
+	mu      sync.Mutex
+	buckets map[string]*bucketState
+}
+
+type bucketState struct {
+	level    float64
+	lastSeen time.Time
+}
+
+func newLeakyBucket(rate, capacity float64) *leakyBucket {
+	return &leakyBucket{rate: rate, capacity: capacity, buckets: make(map[string]*bucketState)}
+}
+
+// allow reports whether an event keyed by key should pass, leaking tokens
+// based on elapsed time since the key was last seen.
+func (b *leakyBucket) allow(key string) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := time.Now()
+	state, ok := b.buckets[key]
+	if !ok {
+		state = &bucketState{lastSeen: now}
+		b.buckets[key] = state
+	}
+
+	elapsed := now.Sub(state.lastSeen).Seconds()
+	state.level -= elapsed * b.rate
+	if state.level < 0 {
+		state.level = 0
+	}
+	state.lastSeen = now
+
+	if state.level >= b.capacity {
+		return false
+	}
+	state.level++
+	return true
+}
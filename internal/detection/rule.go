@@ -0,0 +1,30 @@
+// Package detection evaluates normalized events against a loaded set of
+// detection rules - Sigma YAML rules for field-equality matching, and CEL
+// expressions for cross-field or enrichment-aware conditions Sigma can't
+// express. It sits between enrichment and any downstream alert sink.
+package detection
+
+import "github.com/lvonguyen/threat-telemetry-hub/internal/normalization"
+
+// Detection is emitted when a rule matches a NormalizedEvent.
+type Detection struct {
+	RuleID        string                         `json:"rule_id"`
+	RuleName      string                         `json:"rule_name"`
+	MITRE         []string                       `json:"mitre,omitempty"`
+	Severity      string                         `json:"severity"`
+	MatchedFields map[string]interface{}         `json:"matched_fields,omitempty"`
+	Event         *normalization.NormalizedEvent `json:"event"`
+}
+
+// Rule is a compiled detection rule, independent of its source format
+// (Sigma YAML or a CEL expression).
+type Rule interface {
+	ID() string
+	Name() string
+	Enabled() bool
+	MITRE() []string
+	SeverityLevel() string
+	// Evaluate reports whether event (plus its enrichment map) matches the
+	// rule, and if so, which fields drove the match.
+	Evaluate(event *normalization.NormalizedEvent, enrichments map[string]interface{}) (bool, map[string]interface{})
+}
@@ -0,0 +1,181 @@
+package detection
+
+import (
+	"fmt"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/lvonguyen/threat-telemetry-hub/internal/normalization"
+)
+
+// sigmaDoc is the subset of the Sigma rule schema
+// (https://github.com/SigmaHQ/sigma) this engine understands: named
+// selections under `detection`, combined by a boolean `condition`
+// expression.
+type sigmaDoc struct {
+	Title     string                 `yaml:"title"`
+	ID        string                 `yaml:"id"`
+	Level     string                 `yaml:"level"`
+	Tags      []string               `yaml:"tags"`
+	Detection map[string]interface{} `yaml:"detection"`
+}
+
+// selectionField is one `field` or `field|modifier` entry within a
+// selection block.
+type selectionField struct {
+	modifier string // "", "contains", "startswith", "endswith"
+	values   []string
+}
+
+// selection is a named block under `detection`, e.g. Sigma's `selection:`.
+// All fields in a selection must match for the selection to match (an
+// implicit AND), and a field with multiple values matches on any of them
+// (an implicit OR) - standard Sigma semantics.
+type selection map[string]selectionField
+
+func (s selection) matches(fields map[string]interface{}) (bool, map[string]interface{}) {
+	matched := make(map[string]interface{}, len(s))
+	for field, sf := range s {
+		v, ok := fields[field]
+		if !ok {
+			return false, nil
+		}
+		if !matchValue(sf, fmt.Sprintf("%v", v)) {
+			return false, nil
+		}
+		matched[field] = v
+	}
+	return true, matched
+}
+
+func matchValue(sf selectionField, actual string) bool {
+	for _, want := range sf.values {
+		switch sf.modifier {
+		case "contains":
+			if strings.Contains(strings.ToLower(actual), strings.ToLower(want)) {
+				return true
+			}
+		case "startswith":
+			if strings.HasPrefix(strings.ToLower(actual), strings.ToLower(want)) {
+				return true
+			}
+		case "endswith":
+			if strings.HasSuffix(strings.ToLower(actual), strings.ToLower(want)) {
+				return true
+			}
+		default:
+			if strings.EqualFold(actual, want) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// SigmaRule is a Sigma rule compiled to an evaluable condition AST.
+type SigmaRule struct {
+	id         string
+	name       string
+	level      string
+	mitre      []string
+	selections map[string]selection
+	condition  conditionNode
+}
+
+func (r *SigmaRule) ID() string            { return r.id }
+func (r *SigmaRule) Name() string          { return r.name }
+func (r *SigmaRule) Enabled() bool         { return true }
+func (r *SigmaRule) MITRE() []string       { return r.mitre }
+func (r *SigmaRule) SeverityLevel() string { return r.level }
+
+func (r *SigmaRule) Evaluate(event *normalization.NormalizedEvent, enrichments map[string]interface{}) (bool, map[string]interface{}) {
+	return r.condition.eval(r.selections, eventFields(event, enrichments))
+}
+
+// CompileSigmaRule parses a Sigma rule YAML document and compiles its
+// condition expression.
+func CompileSigmaRule(data []byte) (*SigmaRule, error) {
+	var doc sigmaDoc
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("parsing sigma rule: %w", err)
+	}
+	if doc.Title == "" || doc.ID == "" {
+		return nil, fmt.Errorf("sigma rule missing title or id")
+	}
+
+	selections, conditionExpr, err := parseSelections(doc.Detection)
+	if err != nil {
+		return nil, fmt.Errorf("rule %s: %w", doc.ID, err)
+	}
+	cond, err := parseCondition(conditionExpr)
+	if err != nil {
+		return nil, fmt.Errorf("rule %s: condition: %w", doc.ID, err)
+	}
+
+	var mitre []string
+	for _, tag := range doc.Tags {
+		if strings.HasPrefix(tag, "attack.") {
+			mitre = append(mitre, tag)
+		}
+	}
+
+	return &SigmaRule{
+		id:         doc.ID,
+		name:       doc.Title,
+		level:      doc.Level,
+		mitre:      mitre,
+		selections: selections,
+		condition:  cond,
+	}, nil
+}
+
+func parseSelections(detection map[string]interface{}) (map[string]selection, string, error) {
+	selections := make(map[string]selection)
+	var condition string
+
+	for name, raw := range detection {
+		if name == "condition" {
+			c, ok := raw.(string)
+			if !ok {
+				return nil, "", fmt.Errorf("detection.condition must be a string")
+			}
+			condition = c
+			continue
+		}
+
+		fieldsRaw, ok := raw.(map[string]interface{})
+		if !ok {
+			return nil, "", fmt.Errorf("selection %q must be a mapping", name)
+		}
+		sel := make(selection, len(fieldsRaw))
+		for fieldKey, v := range fieldsRaw {
+			field, modifier := splitModifier(fieldKey)
+			sel[field] = selectionField{modifier: modifier, values: toStrings(v)}
+		}
+		selections[name] = sel
+	}
+
+	if condition == "" {
+		return nil, "", fmt.Errorf("detection.condition is required")
+	}
+	return selections, condition, nil
+}
+
+func splitModifier(fieldKey string) (field, modifier string) {
+	if i := strings.Index(fieldKey, "|"); i >= 0 {
+		return fieldKey[:i], fieldKey[i+1:]
+	}
+	return fieldKey, ""
+}
+
+func toStrings(v interface{}) []string {
+	if list, ok := v.([]interface{}); ok {
+		out := make([]string, 0, len(list))
+		for _, e := range list {
+			out = append(out, fmt.Sprintf("%v", e))
+		}
+		return out
+	}
+	return []string{fmt.Sprintf("%v", v)}
+}
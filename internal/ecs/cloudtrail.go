@@ -0,0 +1,43 @@
+package ecs
+
+import (
+	"github.com/lvonguyen/threat-telemetry-hub/internal/ingestion"
+)
+
+func init() {
+	Register("aws-cloudtrail", cloudTrailMapper{})
+}
+
+// cloudTrailMapper maps AWS CloudTrail records to ECS configuration-change
+// categorization.
+type cloudTrailMapper struct{}
+
+func (cloudTrailMapper) Map(raw *ingestion.RawEvent) (*Event, error) {
+	data := ingestion.DataMap(raw)
+
+	outcome := "success"
+	if getString(data, "errorCode") != "" {
+		outcome = "failure"
+	}
+
+	event := &Event{
+		Event: EventMeta{
+			Category: []string{"configuration"},
+			Type:     []string{"change"},
+			Action:   getString(data, "eventName"),
+			Outcome:  outcome,
+			Severity: 10,
+		},
+		User: &User{
+			Name: getString(data, "userName"),
+			ID:   getString(data, "principalId"),
+		},
+	}
+
+	if ip := getString(data, "sourceIPAddress"); ip != "" {
+		event.Source = &IPEndpoint{IP: ip}
+	}
+
+	event.Unmapped = remaining(data, "eventName", "errorCode", "userName", "principalId", "sourceIPAddress")
+	return event, nil
+}
@@ -0,0 +1,37 @@
+package ecs
+
+import (
+	"github.com/lvonguyen/threat-telemetry-hub/internal/ingestion"
+)
+
+func init() {
+	Register("crowdstrike", crowdStrikeMapper{})
+}
+
+// crowdStrikeMapper maps CrowdStrike Falcon detections to ECS malware
+// categorization.
+type crowdStrikeMapper struct{}
+
+func (crowdStrikeMapper) Map(raw *ingestion.RawEvent) (*Event, error) {
+	data := ingestion.DataMap(raw)
+
+	event := &Event{
+		Event: EventMeta{
+			Category: []string{"malware"},
+			Type:     []string{"info"},
+			Action:   "detection",
+			Outcome:  "success",
+			Severity: severityFromName(getString(data, "severity_name")),
+		},
+		Host:   &Host{Hostname: getString(data, "device_hostname")},
+		User:   &User{Name: getString(data, "username")},
+		Threat: mitreFromData(data, "technique_id", "technique"),
+	}
+
+	if hash := getString(data, "sha256"); hash != "" {
+		event.File = &File{Hash: FileHash{SHA256: hash}}
+	}
+
+	event.Unmapped = remaining(data, "device_hostname", "username", "sha256", "severity_name", "technique_id", "technique")
+	return event, nil
+}
@@ -0,0 +1,32 @@
+package ecs
+
+import (
+	"github.com/lvonguyen/threat-telemetry-hub/internal/ingestion"
+)
+
+func init() {
+	Register("defender", defenderMapper{})
+}
+
+// defenderMapper maps Microsoft Defender for Endpoint alerts to ECS malware
+// categorization.
+type defenderMapper struct{}
+
+func (defenderMapper) Map(raw *ingestion.RawEvent) (*Event, error) {
+	data := ingestion.DataMap(raw)
+
+	event := &Event{
+		Event: EventMeta{
+			Category: []string{"malware"},
+			Type:     []string{"info"},
+			Action:   "alert",
+			Outcome:  "success",
+			Severity: severityFromName(getString(data, "severity")),
+		},
+		Host: &Host{Hostname: getString(data, "computerDnsName")},
+		User: &User{Name: getString(data, "userPrincipalName")},
+	}
+
+	event.Unmapped = remaining(data, "computerDnsName", "userPrincipalName", "severity")
+	return event, nil
+}
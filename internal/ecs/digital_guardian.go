@@ -0,0 +1,35 @@
+package ecs
+
+import (
+	"github.com/lvonguyen/threat-telemetry-hub/internal/ingestion"
+)
+
+func init() {
+	Register("digital-guardian", digitalGuardianMapper{})
+}
+
+// digitalGuardianMapper maps Digital Guardian DLP events to ECS file-access
+// categorization.
+type digitalGuardianMapper struct{}
+
+func (digitalGuardianMapper) Map(raw *ingestion.RawEvent) (*Event, error) {
+	data := ingestion.DataMap(raw)
+
+	event := &Event{
+		Event: EventMeta{
+			Category: []string{"file"},
+			Type:     []string{"access"},
+			Action:   getString(data, "operation"),
+			Outcome:  "success",
+			Severity: severityFromName(getString(data, "severity")),
+		},
+		User: &User{Name: getString(data, "user")},
+	}
+
+	if name := getString(data, "fileName"); name != "" {
+		event.File = &File{Name: name}
+	}
+
+	event.Unmapped = remaining(data, "operation", "severity", "user", "fileName")
+	return event, nil
+}
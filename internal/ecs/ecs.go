@@ -0,0 +1,97 @@
+// Package ecs maps vendor-specific RawEvent payloads onto the Elastic
+// Common Schema (https://www.elastic.co/guide/en/ecs/current/index.html),
+// mirroring internal/ocsf's per-collector mapper registry for the ECS
+// target schema.
+package ecs
+
+import (
+	"github.com/lvonguyen/threat-telemetry-hub/internal/ingestion"
+)
+
+// Event is a normalized ECS 8.11 event, trimmed to the field sets this hub
+// populates: event categorization, a MITRE-tagged threat indicator, and the
+// file/process/user/host/network entities a detection or policy match most
+// often carries.
+type Event struct {
+	Event       EventMeta              `json:"event"`
+	Threat      *Threat                `json:"threat,omitempty"`
+	File        *File                  `json:"file,omitempty"`
+	Process     *Process               `json:"process,omitempty"`
+	User        *User                  `json:"user,omitempty"`
+	Host        *Host                  `json:"host,omitempty"`
+	Source      *IPEndpoint            `json:"source,omitempty"`
+	Destination *IPEndpoint            `json:"destination,omitempty"`
+	Unmapped    map[string]interface{} `json:"unmapped,omitempty"`
+}
+
+// EventMeta is the ECS "event" field set.
+type EventMeta struct {
+	Category []string `json:"category,omitempty"`
+	Type     []string `json:"type,omitempty"`
+	Action   string   `json:"action,omitempty"`
+	Outcome  string   `json:"outcome,omitempty"`
+	// Severity is this hub's 0-100 scale (see normalization.Normalizer),
+	// not a vendor severity value passed through unscaled.
+	Severity int `json:"severity,omitempty"`
+}
+
+// Threat is the ECS "threat" field set, trimmed to MITRE ATT&CK technique
+// enrichment.
+type Threat struct {
+	Technique *MITRETechnique `json:"technique,omitempty"`
+}
+
+// MITRETechnique is the ECS "threat.technique" field set.
+type MITRETechnique struct {
+	ID   string `json:"id,omitempty"`
+	Name string `json:"name,omitempty"`
+}
+
+// File is the ECS "file" field set.
+type File struct {
+	Name string   `json:"name,omitempty"`
+	Hash FileHash `json:"hash,omitempty"`
+}
+
+// FileHash is the ECS "file.hash" field set.
+type FileHash struct {
+	SHA256 string `json:"sha256,omitempty"`
+}
+
+// Process is the ECS "process" field set.
+type Process struct {
+	Name        string `json:"name,omitempty"`
+	PID         int    `json:"pid,omitempty"`
+	CommandLine string `json:"command_line,omitempty"`
+}
+
+// User is the ECS "user" field set.
+type User struct {
+	Name   string `json:"name,omitempty"`
+	ID     string `json:"id,omitempty"`
+	Domain string `json:"domain,omitempty"`
+}
+
+// Host is the ECS "host" field set.
+type Host struct {
+	Hostname string `json:"hostname,omitempty"`
+	OS       *OS    `json:"os,omitempty"`
+}
+
+// OS is the ECS "host.os" field set.
+type OS struct {
+	Full string `json:"full,omitempty"`
+}
+
+// IPEndpoint is the ECS "source"/"destination" field set, trimmed to IP.
+type IPEndpoint struct {
+	IP string `json:"ip,omitempty"`
+}
+
+// FieldMapper converts a collector's raw vendor payload into an ECS Event.
+type FieldMapper interface {
+	// Map converts raw into an ECS event. Fields with no vendor equivalent
+	// are left zero-valued; vendor-specific data that doesn't fit the
+	// schema belongs in Event.Unmapped rather than being dropped.
+	Map(raw *ingestion.RawEvent) (*Event, error)
+}
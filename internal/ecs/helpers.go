@@ -0,0 +1,74 @@
+package ecs
+
+// getString returns m[key] as a string, or "" if absent or not a string.
+// Vendor payloads are untyped JSON, so every mapper leans on this rather
+// than repeating type assertions.
+func getString(m map[string]interface{}, key string) string {
+	if m == nil {
+		return ""
+	}
+	if v, ok := m[key].(string); ok {
+		return v
+	}
+	return ""
+}
+
+// remaining returns a shallow copy of m with the given keys removed, for use
+// as an Event's Unmapped field.
+func remaining(m map[string]interface{}, consumed ...string) map[string]interface{} {
+	out := make(map[string]interface{}, len(m))
+	for k, v := range m {
+		out[k] = v
+	}
+	for _, k := range consumed {
+		delete(out, k)
+	}
+	return out
+}
+
+// severityFromName maps a vendor's named severity onto this hub's 0-100
+// normalized scale (see normalization.Normalizer).
+func severityFromName(name string) int {
+	switch name {
+	case "critical":
+		return 100
+	case "high":
+		return 80
+	case "medium":
+		return 50
+	case "low":
+		return 20
+	case "informational", "info":
+		return 10
+	default:
+		return 0
+	}
+}
+
+// severityFromSplunkUrgency maps a Splunk Enterprise Security notable
+// event's urgency field onto this hub's 0-100 normalized scale.
+func severityFromSplunkUrgency(urgency string) int {
+	switch urgency {
+	case "critical":
+		return 100
+	case "high":
+		return 80
+	case "medium":
+		return 50
+	case "low":
+		return 20
+	default:
+		return 0
+	}
+}
+
+// mitreFromData extracts an ATT&CK technique id/name out of data under
+// idKey/nameKey, for vendors whose payload tags a detection with one
+// directly. It returns nil if idKey is absent.
+func mitreFromData(data map[string]interface{}, idKey, nameKey string) *Threat {
+	id := getString(data, idKey)
+	if id == "" {
+		return nil
+	}
+	return &Threat{Technique: &MITRETechnique{ID: id, Name: getString(data, nameKey)}}
+}
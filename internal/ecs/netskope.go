@@ -0,0 +1,34 @@
+package ecs
+
+import (
+	"github.com/lvonguyen/threat-telemetry-hub/internal/ingestion"
+)
+
+func init() {
+	Register("netskope", netskopeMapper{})
+}
+
+// netskopeMapper maps Netskope cloud security events to ECS network
+// categorization. Host carries the destination endpoint's hostname - ECS
+// has no dedicated destination.hostname field for an entity Netskope
+// doesn't also give us an IP for.
+type netskopeMapper struct{}
+
+func (netskopeMapper) Map(raw *ingestion.RawEvent) (*Event, error) {
+	data := ingestion.DataMap(raw)
+
+	event := &Event{
+		Event: EventMeta{
+			Category: []string{"network"},
+			Type:     []string{"connection"},
+			Action:   getString(data, "activity"),
+			Outcome:  "success",
+			Severity: 10,
+		},
+		User: &User{Name: getString(data, "user")},
+		Host: &Host{Hostname: getString(data, "dst_host")},
+	}
+
+	event.Unmapped = remaining(data, "activity", "user", "dst_host")
+	return event, nil
+}
@@ -0,0 +1,30 @@
+package ecs
+
+import (
+	"github.com/lvonguyen/threat-telemetry-hub/internal/ingestion"
+)
+
+func init() {
+	Register("proofpoint", proofpointMapper{})
+}
+
+// proofpointMapper maps Proofpoint DLP events to ECS email categorization.
+type proofpointMapper struct{}
+
+func (proofpointMapper) Map(raw *ingestion.RawEvent) (*Event, error) {
+	data := ingestion.DataMap(raw)
+
+	event := &Event{
+		Event: EventMeta{
+			Category: []string{"email"},
+			Type:     []string{"info"},
+			Action:   getString(data, "classification"),
+			Outcome:  "success",
+			Severity: severityFromName(getString(data, "severity")),
+		},
+		User: &User{Name: getString(data, "recipient")},
+	}
+
+	event.Unmapped = remaining(data, "classification", "severity", "recipient")
+	return event, nil
+}
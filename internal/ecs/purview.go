@@ -0,0 +1,35 @@
+package ecs
+
+import (
+	"github.com/lvonguyen/threat-telemetry-hub/internal/ingestion"
+)
+
+func init() {
+	Register("purview", purviewMapper{})
+}
+
+// purviewMapper maps Microsoft Purview DLP policy matches to ECS file-access
+// categorization.
+type purviewMapper struct{}
+
+func (purviewMapper) Map(raw *ingestion.RawEvent) (*Event, error) {
+	data := ingestion.DataMap(raw)
+
+	event := &Event{
+		Event: EventMeta{
+			Category: []string{"file"},
+			Type:     []string{"access"},
+			Action:   "policy_match",
+			Outcome:  "success",
+			Severity: severityFromName(getString(data, "severity")),
+		},
+		User: &User{ID: getString(data, "userId")},
+	}
+
+	if name := getString(data, "fileName"); name != "" {
+		event.File = &File{Name: name}
+	}
+
+	event.Unmapped = remaining(data, "userId", "fileName", "severity")
+	return event, nil
+}
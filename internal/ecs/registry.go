@@ -0,0 +1,44 @@
+package ecs
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/lvonguyen/threat-telemetry-hub/internal/ingestion"
+)
+
+// registry holds the FieldMapper registered for each collector's short
+// source name (e.g. "crowdstrike"), mirroring internal/ocsf's mapper
+// registry.
+var registry = struct {
+	mu      sync.RWMutex
+	mappers map[string]FieldMapper
+}{mappers: make(map[string]FieldMapper)}
+
+// Register associates a FieldMapper with a collector's source name.
+// Intended to be called from an init() in each mapper's file.
+func Register(source string, m FieldMapper) {
+	registry.mu.Lock()
+	defer registry.mu.Unlock()
+	if _, exists := registry.mappers[source]; exists {
+		panic(fmt.Sprintf("ecs: mapper already registered for source %q", source))
+	}
+	registry.mappers[source] = m
+}
+
+// Map converts raw into an ECS event using the FieldMapper registered for
+// its source. If no mapper is registered, it returns an error so callers can
+// fall back to coarser category/type handling instead of forwarding an
+// unmapped event.
+func Map(raw *ingestion.RawEvent) (*Event, error) {
+	source := ingestion.SourceName(raw)
+
+	registry.mu.RLock()
+	m, ok := registry.mappers[source]
+	registry.mu.RUnlock()
+
+	if !ok {
+		return nil, fmt.Errorf("ecs: no mapper registered for source %q", source)
+	}
+	return m.Map(raw)
+}
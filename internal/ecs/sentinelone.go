@@ -0,0 +1,38 @@
+package ecs
+
+import (
+	"github.com/lvonguyen/threat-telemetry-hub/internal/ingestion"
+)
+
+func init() {
+	Register("sentinelone", sentinelOneMapper{})
+}
+
+// sentinelOneMapper maps SentinelOne Singularity threats to ECS malware
+// categorization.
+type sentinelOneMapper struct{}
+
+func (sentinelOneMapper) Map(raw *ingestion.RawEvent) (*Event, error) {
+	data := ingestion.DataMap(raw)
+
+	event := &Event{
+		Event: EventMeta{
+			Category: []string{"malware"},
+			Type:     []string{"info"},
+			Action:   "threat_detected",
+			Outcome:  "success",
+			Severity: severityFromName(getString(data, "confidenceLevel")),
+		},
+		Host: &Host{
+			Hostname: getString(data, "agentComputerName"),
+			OS:       &OS{Full: getString(data, "agentOsType")},
+		},
+	}
+
+	if hash := getString(data, "fileSha256"); hash != "" {
+		event.File = &File{Hash: FileHash{SHA256: hash}}
+	}
+
+	event.Unmapped = remaining(data, "agentComputerName", "agentOsType", "fileSha256", "confidenceLevel")
+	return event, nil
+}
@@ -0,0 +1,38 @@
+package ecs
+
+import (
+	"github.com/lvonguyen/threat-telemetry-hub/internal/ingestion"
+)
+
+func init() {
+	Register("splunk", splunkMapper{})
+}
+
+// splunkMapper maps Splunk Enterprise Security notable events to ECS
+// intrusion-detection categorization.
+type splunkMapper struct{}
+
+func (splunkMapper) Map(raw *ingestion.RawEvent) (*Event, error) {
+	data := ingestion.DataMap(raw)
+
+	event := &Event{
+		Event: EventMeta{
+			Category: []string{"intrusion_detection"},
+			Type:     []string{"indicator"},
+			Action:   getString(data, "signature"),
+			Outcome:  "unknown",
+			Severity: severityFromSplunkUrgency(getString(data, "urgency")),
+		},
+		User: &User{Name: getString(data, "user")},
+	}
+
+	if ip := getString(data, "src"); ip != "" {
+		event.Source = &IPEndpoint{IP: ip}
+	}
+	if ip := getString(data, "dest"); ip != "" {
+		event.Destination = &IPEndpoint{IP: ip}
+	}
+
+	event.Unmapped = remaining(data, "signature", "urgency", "user", "src", "dest")
+	return event, nil
+}
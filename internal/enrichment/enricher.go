@@ -3,17 +3,27 @@ package enrichment
 
 import (
 	"context"
+	"log/slog"
 
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
 
 	"github.com/lvonguyen/threat-telemetry-hub/internal/config"
 	"github.com/lvonguyen/threat-telemetry-hub/internal/normalization"
+	"github.com/lvonguyen/threat-telemetry-hub/internal/observability"
 )
 
+// tracerName identifies spans started by this package in OpenTelemetry
+// backends; by convention it's the package's own import path.
+const tracerName = "github.com/lvonguyen/threat-telemetry-hub/internal/enrichment"
+
 // Enricher handles event enrichment from multiple sources
 type Enricher struct {
 	config  config.EnrichmentConfig
 	logger  *zap.Logger
+	slog    *slog.Logger
 	sources []EnrichmentSource
 }
 
@@ -32,6 +42,7 @@ func NewEnricher(cfg config.EnrichmentConfig, logger *zap.Logger) *Enricher {
 	e := &Enricher{
 		config:  cfg,
 		logger:  logger,
+		slog:    observability.NewSlogLogger(logger),
 		sources: make([]EnrichmentSource, 0),
 	}
 
@@ -58,11 +69,17 @@ func NewEnricher(cfg config.EnrichmentConfig, logger *zap.Logger) *Enricher {
 
 // Enrich adds contextual data to an event from all enabled sources
 func (e *Enricher) Enrich(ctx context.Context, event *normalization.NormalizedEvent) (map[string]interface{}, error) {
+	ctx, span := otel.Tracer(tracerName).Start(ctx, "enrichment.enrich", trace.WithAttributes(
+		attribute.String(observability.KeyEventID, event.ID),
+		attribute.String(observability.KeyEventSource, event.Source.Name),
+	))
+	defer span.End()
+
 	enrichments := make(map[string]interface{})
 
 	for _, source := range e.sources {
 		if source.Enabled() {
-			data, err := source.Enrich(ctx, event)
+			data, err := e.enrichFromSource(ctx, source, event)
 			if err != nil {
 				e.logger.Warn("Enrichment source failed",
 					zap.String("source", source.Name()),
@@ -77,6 +94,29 @@ func (e *Enricher) Enrich(ctx context.Context, event *normalization.NormalizedEv
 	return enrichments, nil
 }
 
+// enrichFromSource runs a single source under its own child span and logs
+// its outcome with the standardized enrichment.source key, so a slow or
+// failing source can be picked out of a trace without reading every
+// source's logs.
+func (e *Enricher) enrichFromSource(ctx context.Context, source EnrichmentSource, event *normalization.NormalizedEvent) (map[string]interface{}, error) {
+	ctx, span := otel.Tracer(tracerName).Start(ctx, "enrichment.source", trace.WithAttributes(
+		attribute.String(observability.KeyEnrichmentSource, source.Name()),
+	))
+	defer span.End()
+
+	data, err := source.Enrich(ctx, event)
+	if err != nil {
+		span.RecordError(err)
+		return nil, err
+	}
+
+	e.slog.InfoContext(ctx, "Enrichment source completed",
+		observability.KeyEnrichmentSource, source.Name(),
+		observability.KeyEventID, event.ID,
+	)
+	return data, nil
+}
+
 // =============================================================================
 // Enrichment Sources
 // =============================================================================
@@ -0,0 +1,128 @@
+// Package events provides a CloudEvents v1.0 transport layer connecting
+// ticketing, the AI analyzer, and downstream SOAR/GRC systems through a
+// common envelope, independent of any one broker. It complements
+// internal/ingestion's RawEvent (the same CloudEvents type, used for
+// inbound collector data) with the egress/interchange side: encoding a
+// CloudEvent for the wire and a Transport abstraction over HTTP, Kafka,
+// and MQTT.
+package events
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+)
+
+// CloudEvent is the canonical envelope this package's transports carry -
+// the same CloudEvents v1.0 event type internal/ingestion.RawEvent aliases.
+type CloudEvent = cloudevents.Event
+
+// Extension attribute names this hub attaches to outbound CloudEvents so a
+// downstream SOAR/GRC system can filter or route on them without parsing
+// the JSON payload.
+const (
+	ExtRiskScore    = "riskscore"
+	ExtMITRETactics = "mitretactics"
+	ExtTenant       = "tenant"
+)
+
+// ceTimeLayout is RFC3339Nano, the Timestamp encoding the CloudEvents spec
+// requires for the "time" attribute.
+const ceTimeLayout = "2006-01-02T15:04:05.999999999Z07:00"
+
+// ContentMode selects how Marshal/Unmarshal represent a CloudEvent on the
+// wire, per the CloudEvents HTTP/Kafka/MQTT protocol binding spec.
+type ContentMode int
+
+const (
+	// Structured carries the whole event - envelope and data together - as
+	// one application/cloudevents+json body.
+	Structured ContentMode = iota
+	// Binary carries the data payload as the body and the envelope
+	// attributes as ce-* headers, letting a broker or proxy route on
+	// headers without parsing the body.
+	Binary
+)
+
+// Marshal encodes ce for mode, returning the wire body and, for Binary
+// mode, the ce-* headers (plus Content-Type) a transport should send
+// alongside it. Structured mode returns a single Content-Type header.
+func Marshal(ce *CloudEvent, mode ContentMode) ([]byte, map[string]string, error) {
+	if mode == Structured {
+		body, err := ce.MarshalJSON()
+		if err != nil {
+			return nil, nil, fmt.Errorf("marshaling structured CloudEvent: %w", err)
+		}
+		return body, map[string]string{"Content-Type": "application/cloudevents+json"}, nil
+	}
+
+	headers := map[string]string{
+		"ce-id":          ce.ID(),
+		"ce-source":      ce.Source(),
+		"ce-specversion": ce.SpecVersion(),
+		"ce-type":        ce.Type(),
+	}
+	if !ce.Time().IsZero() {
+		headers["ce-time"] = ce.Time().UTC().Format(ceTimeLayout)
+	}
+	if ce.Subject() != "" {
+		headers["ce-subject"] = ce.Subject()
+	}
+	if ce.DataContentType() != "" {
+		headers["Content-Type"] = ce.DataContentType()
+	}
+	for name, val := range ce.Extensions() {
+		headers["ce-"+name] = fmt.Sprintf("%v", val)
+	}
+	return ce.Data(), headers, nil
+}
+
+// Unmarshal decodes body/headers back into a CloudEvent, detecting
+// structured vs binary mode from the Content-Type header.
+func Unmarshal(body []byte, headers map[string]string) (*CloudEvent, error) {
+	if headers["Content-Type"] == "application/cloudevents+json" {
+		ce := cloudevents.NewEvent()
+		if err := ce.UnmarshalJSON(body); err != nil {
+			return nil, fmt.Errorf("unmarshaling structured CloudEvent: %w", err)
+		}
+		return &ce, nil
+	}
+	return unmarshalBinary(body, headers)
+}
+
+func unmarshalBinary(body []byte, headers map[string]string) (*CloudEvent, error) {
+	ce := cloudevents.NewEvent()
+	ce.SetID(headers["ce-id"])
+	ce.SetSource(headers["ce-source"])
+	ce.SetType(headers["ce-type"])
+	if v := headers["ce-subject"]; v != "" {
+		ce.SetSubject(v)
+	}
+	if v := headers["ce-time"]; v != "" {
+		if t, err := time.Parse(ceTimeLayout, v); err == nil {
+			ce.SetTime(t)
+		}
+	}
+	for key, val := range headers {
+		name, ok := strings.CutPrefix(key, "ce-")
+		if !ok {
+			continue
+		}
+		switch name {
+		case "id", "source", "type", "subject", "time", "specversion":
+			continue
+		}
+		ce.SetExtension(name, val)
+	}
+
+	contentType := headers["Content-Type"]
+	if contentType == "" {
+		contentType = cloudevents.ApplicationJSON
+	}
+	if err := ce.SetData(contentType, body); err != nil {
+		return nil, fmt.Errorf("setting CloudEvent data: %w", err)
+	}
+	return &ce, nil
+}
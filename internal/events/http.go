@@ -0,0 +1,82 @@
+package events
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	"go.uber.org/zap"
+)
+
+// HTTPTransport implements Transport as a CloudEvents webhook: Publish
+// POSTs a structured-mode event to a configured URL, Subscribe runs an
+// HTTP server accepting structured or binary mode events at Addr.
+type HTTPTransport struct {
+	targetURL string
+	addr      string
+	logger    *zap.Logger
+	client    cloudevents.Client
+}
+
+// NewHTTPTransport creates an HTTPTransport. targetURL is where Publish
+// sends events; addr is where Subscribe listens (e.g. ":8090"). Either may
+// be empty if this process only publishes or only subscribes.
+func NewHTTPTransport(targetURL, addr string, logger *zap.Logger) (*HTTPTransport, error) {
+	client, err := cloudevents.NewClientHTTP()
+	if err != nil {
+		return nil, fmt.Errorf("creating CloudEvents HTTP client: %w", err)
+	}
+	return &HTTPTransport{targetURL: targetURL, addr: addr, logger: logger, client: client}, nil
+}
+
+// Name returns the transport name.
+func (t *HTTPTransport) Name() string { return "http" }
+
+// Publish sends ce to targetURL as a structured-mode CloudEvents HTTP
+// request.
+func (t *HTTPTransport) Publish(ctx context.Context, ce *CloudEvent) error {
+	if t.targetURL == "" {
+		return fmt.Errorf("events: HTTPTransport has no target URL configured")
+	}
+	ctx = cloudevents.ContextWithTarget(ctx, t.targetURL)
+	ctx = cloudevents.WithEncodingStructured(ctx)
+	if result := t.client.Send(ctx, *ce); cloudevents.IsUndelivered(result) {
+		return fmt.Errorf("publishing CloudEvent: %w", result)
+	}
+	return nil
+}
+
+// Subscribe starts an HTTP server on addr that decodes inbound requests as
+// CloudEvents (structured or binary mode) and invokes handler for each.
+// It blocks until ctx is canceled.
+func (t *HTTPTransport) Subscribe(ctx context.Context, handler func(*CloudEvent) error) error {
+	if t.addr == "" {
+		return fmt.Errorf("events: HTTPTransport has no listen address configured")
+	}
+
+	p, err := cloudevents.NewHTTP()
+	if err != nil {
+		return fmt.Errorf("creating CloudEvents HTTP protocol: %w", err)
+	}
+
+	h, err := cloudevents.NewHTTPReceiveHandler(ctx, p, func(ctx context.Context, ce cloudevents.Event) {
+		if err := handler(&ce); err != nil {
+			t.logger.Warn("events: HTTPTransport subscriber returned error", zap.Error(err))
+		}
+	})
+	if err != nil {
+		return fmt.Errorf("creating CloudEvents HTTP receive handler: %w", err)
+	}
+
+	server := &http.Server{Addr: t.addr, Handler: h}
+	go func() {
+		<-ctx.Done()
+		_ = server.Close()
+	}()
+
+	if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+		return fmt.Errorf("events: HTTPTransport server: %w", err)
+	}
+	return nil
+}
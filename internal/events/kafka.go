@@ -0,0 +1,49 @@
+package events
+
+import (
+	"context"
+	"fmt"
+
+	"go.uber.org/zap"
+)
+
+// KafkaTransport carries CloudEvents over a Kafka topic (binary content
+// mode: ce-* headers alongside a raw payload value), for interchange with
+// SOAR/GRC systems that already consume Kafka. Mirrors
+// internal/ingestion.KafkaTransport, which covers the inbound-only case.
+//
+// Publish and Subscribe aren't implemented: doing so for real needs a
+// Kafka client (segmentio/kafka-go), which isn't a dependency of this
+// module yet.
+type KafkaTransport struct {
+	Brokers []string
+	Topic   string
+	GroupID string
+	logger  *zap.Logger
+}
+
+// NewKafkaTransport creates a KafkaTransport.
+func NewKafkaTransport(brokers []string, topic, groupID string, logger *zap.Logger) *KafkaTransport {
+	return &KafkaTransport{Brokers: brokers, Topic: topic, GroupID: groupID, logger: logger}
+}
+
+// Name returns the transport name.
+func (t *KafkaTransport) Name() string { return "kafka" }
+
+// Publish is a stub; see the KafkaTransport doc comment. It returns an
+// error rather than silently discarding ce, so a caller can't mistake a
+// missing dependency for a delivered event.
+func (t *KafkaTransport) Publish(ctx context.Context, ce *CloudEvent) error {
+	// TODO: Implement using segmentio/kafka-go, encoding ce as a
+	// binary-mode CloudEvent (ce-* headers + Data() as the message value).
+	t.logger.Error("Kafka CloudEvents publish is not implemented; event dropped", zap.String("topic", t.Topic))
+	return fmt.Errorf("events: KafkaTransport.Publish not yet implemented")
+}
+
+// Subscribe is a stub; see the KafkaTransport doc comment.
+func (t *KafkaTransport) Subscribe(ctx context.Context, handler func(*CloudEvent) error) error {
+	// TODO: Implement using a segmentio/kafka-go consumer group, decoding
+	// each message as a binary-mode CloudEvent before invoking handler.
+	t.logger.Error("Kafka CloudEvents subscribe is not implemented", zap.String("topic", t.Topic))
+	return fmt.Errorf("events: KafkaTransport.Subscribe not yet implemented")
+}
@@ -0,0 +1,51 @@
+package events
+
+import (
+	"context"
+	"fmt"
+
+	"go.uber.org/zap"
+)
+
+// MQTTTransport carries CloudEvents over MQTT 5 (binary content mode: ce-*
+// user properties alongside a raw payload), letting this hub interoperate
+// with agent fleets the way open-cluster-management's work-agent does
+// over its own MQTT-based transport.
+//
+// Publish and Subscribe aren't implemented: doing so for real needs an
+// MQTT client (eclipse/paho.mqtt.golang), which isn't a dependency of this
+// module yet.
+type MQTTTransport struct {
+	BrokerURL string
+	ClientID  string
+	Topic     string
+	logger    *zap.Logger
+}
+
+// NewMQTTTransport creates an MQTTTransport.
+func NewMQTTTransport(brokerURL, clientID, topic string, logger *zap.Logger) *MQTTTransport {
+	return &MQTTTransport{BrokerURL: brokerURL, ClientID: clientID, Topic: topic, logger: logger}
+}
+
+// Name returns the transport name.
+func (t *MQTTTransport) Name() string { return "mqtt" }
+
+// Publish is a stub; see the MQTTTransport doc comment. It returns an
+// error rather than silently discarding ce, so a caller can't mistake a
+// missing dependency for a delivered event.
+func (t *MQTTTransport) Publish(ctx context.Context, ce *CloudEvent) error {
+	// TODO: Implement using eclipse/paho.mqtt.golang (v5), publishing ce as
+	// a binary-mode CloudEvent: ce-* MQTT 5 user properties plus Data() as
+	// the payload.
+	t.logger.Error("MQTT CloudEvents publish is not implemented; event dropped", zap.String("topic", t.Topic))
+	return fmt.Errorf("events: MQTTTransport.Publish not yet implemented")
+}
+
+// Subscribe is a stub; see the MQTTTransport doc comment.
+func (t *MQTTTransport) Subscribe(ctx context.Context, handler func(*CloudEvent) error) error {
+	// TODO: Implement using eclipse/paho.mqtt.golang (v5), decoding each
+	// message's user properties and payload back into a CloudEvent before
+	// invoking handler.
+	t.logger.Error("MQTT CloudEvents subscribe is not implemented", zap.String("topic", t.Topic))
+	return fmt.Errorf("events: MQTTTransport.Subscribe not yet implemented")
+}
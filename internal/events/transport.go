@@ -0,0 +1,19 @@
+package events
+
+import "context"
+
+// Transport is a pluggable CloudEvents carrier for interchange between this
+// hub and downstream SOAR/GRC systems or peer agent fleets, as opposed to
+// internal/ingestion.Transport, which is specifically about pulling raw
+// vendor data into the pipeline.
+type Transport interface {
+	Name() string
+
+	// Publish sends ce over the transport.
+	Publish(ctx context.Context, ce *CloudEvent) error
+
+	// Subscribe blocks, invoking handler for each CloudEvent received,
+	// until ctx is canceled. What a non-nil handler error does depends on
+	// the transport: HTTPTransport logs it and keeps serving.
+	Subscribe(ctx context.Context, handler func(*CloudEvent) error) error
+}
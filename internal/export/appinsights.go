@@ -0,0 +1,74 @@
+package export
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/microsoft/ApplicationInsights-Go/appinsights"
+	"github.com/microsoft/ApplicationInsights-Go/appinsights/contracts"
+
+	"github.com/lvonguyen/threat-telemetry-hub/internal/config"
+)
+
+// AppInsightsSink exports ProcessedEvents to Azure Application Insights: a
+// TrackEvent call so the event shows up in the Events blade, plus a
+// TrackTrace call whose SeverityLevel is mapped from RiskLevel so elevated-
+// risk events are easy to alert on directly from Application Insights.
+type AppInsightsSink struct {
+	client appinsights.TelemetryClient
+}
+
+// NewAppInsightsSink creates an AppInsightsSink, reading the instrumentation
+// key from the environment variable named by cfg.InstrumentationKeyEnv.
+func NewAppInsightsSink(cfg config.AppInsightsExportConfig) (*AppInsightsSink, error) {
+	key := os.Getenv(cfg.InstrumentationKeyEnv)
+	if key == "" {
+		return nil, fmt.Errorf("missing required Application Insights instrumentation key")
+	}
+
+	return &AppInsightsSink{client: appinsights.NewTelemetryClient(key)}, nil
+}
+
+// Name returns the sink's name.
+func (s *AppInsightsSink) Name() string { return "appinsights" }
+
+// Emit tracks event as both an Application Insights event and trace.
+func (s *AppInsightsSink) Emit(_ context.Context, event *Event) error {
+	evt := appinsights.NewEventTelemetry("processed_event")
+	evt.Properties["event_id"] = event.ID
+	evt.Properties["source"] = event.Source
+	evt.Properties["correlation_id"] = event.CorrelationID
+	s.client.Track(evt)
+
+	trace := appinsights.NewTraceTelemetry(event.ID, severityFromRiskLevel(event.RiskLevel))
+	trace.Properties["risk_level"] = event.RiskLevel
+	trace.Properties["correlation_id"] = event.CorrelationID
+	s.client.Track(trace)
+
+	return nil
+}
+
+// severityFromRiskLevel maps a ProcessedEvent.RiskLevel onto the
+// SeverityLevel Application Insights uses to color and filter traces.
+func severityFromRiskLevel(level string) contracts.SeverityLevel {
+	switch level {
+	case "critical":
+		return appinsights.Critical
+	case "high":
+		return appinsights.Error
+	case "medium":
+		return appinsights.Warning
+	case "low":
+		return appinsights.Information
+	default:
+		return appinsights.Verbose
+	}
+}
+
+// Shutdown flushes buffered telemetry and waits for the client's submission
+// channel to close.
+func (s *AppInsightsSink) Shutdown(_ context.Context) error {
+	<-s.client.Channel().Close()
+	return nil
+}
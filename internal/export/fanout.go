@@ -0,0 +1,79 @@
+package export
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+
+	"github.com/lvonguyen/threat-telemetry-hub/internal/safe"
+)
+
+// FanOut distributes events to every configured Sink over an independent
+// bounded queue per sink, so a slow or stalled sink only drops its own
+// events instead of blocking delivery to the others or stalling the
+// pipeline worker that produced them - the same drop-on-backpressure
+// tradeoff dropPolicySink makes for ingestion.
+type FanOut struct {
+	sinks  []Sink
+	queues []chan *Event
+	logger *zap.Logger
+}
+
+// NewFanOut creates a FanOut delivering to sinks, each over a queue buffered
+// to queueSize events.
+func NewFanOut(sinks []Sink, queueSize int, logger *zap.Logger) *FanOut {
+	queues := make([]chan *Event, len(sinks))
+	for i := range sinks {
+		queues[i] = make(chan *Event, queueSize)
+	}
+	return &FanOut{sinks: sinks, queues: queues, logger: logger}
+}
+
+// Start spins up one worker goroutine per sink, draining its queue until ctx
+// is done.
+func (f *FanOut) Start(ctx context.Context) {
+	for i, sink := range f.sinks {
+		queue := f.queues[i]
+		go safe.Loop(ctx, f.logger, "export."+sink.Name(), func(ctx context.Context) {
+			f.drain(ctx, sink, queue)
+		})
+	}
+}
+
+func (f *FanOut) drain(ctx context.Context, sink Sink, queue chan *Event) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case event := <-queue:
+			if err := sink.Emit(ctx, event); err != nil {
+				f.logger.Warn("Export sink failed", zap.String("sink", sink.Name()), zap.Error(err))
+			}
+		}
+	}
+}
+
+// Emit enqueues event onto every sink's queue, dropping (and counting) it
+// for any sink whose queue is full rather than blocking the caller.
+func (f *FanOut) Emit(event *Event) {
+	for i, sink := range f.sinks {
+		select {
+		case f.queues[i] <- event:
+		default:
+			eventsDroppedTotal.WithLabelValues(sink.Name()).Inc()
+		}
+	}
+}
+
+// Shutdown flushes and closes every sink that implements Shutdownable.
+func (f *FanOut) Shutdown(ctx context.Context) error {
+	var err error
+	for _, sink := range f.sinks {
+		if s, ok := sink.(Shutdownable); ok {
+			if e := s.Shutdown(ctx); e != nil {
+				err = e
+			}
+		}
+	}
+	return err
+}
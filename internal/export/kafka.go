@@ -0,0 +1,36 @@
+package export
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+
+	"github.com/lvonguyen/threat-telemetry-hub/internal/config"
+)
+
+// KafkaSink publishes ProcessedEvents as CloudEvents (binary content mode)
+// to a Kafka topic for downstream SIEM re-ingestion, mirroring the inbound
+// ingestion.KafkaTransport.
+type KafkaSink struct {
+	Brokers []string
+	Topic   string
+	logger  *zap.Logger
+}
+
+// NewKafkaSink creates a KafkaSink.
+func NewKafkaSink(cfg config.KafkaExportConfig, logger *zap.Logger) *KafkaSink {
+	logger.Info("Kafka export sink started (stub)", zap.String("topic", cfg.Topic))
+	return &KafkaSink{Brokers: cfg.Brokers, Topic: cfg.Topic, logger: logger}
+}
+
+// Name returns the sink's name.
+func (s *KafkaSink) Name() string { return "kafka" }
+
+// Emit is a no-op until the producer below is implemented.
+func (s *KafkaSink) Emit(_ context.Context, _ *Event) error {
+	// TODO: Implement using segmentio/kafka-go, encoding event as a
+	// binary-mode CloudEvent (ce-* headers + JSON payload body) keyed by
+	// CorrelationID so a downstream consumer group can partition by
+	// correlation group.
+	return nil
+}
@@ -0,0 +1,19 @@
+package export
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// eventsDroppedTotal counts events FanOut discarded because a sink's bounded
+// queue was full, by sink, so one slow export destination shows up in
+// metrics instead of just silently falling behind.
+var eventsDroppedTotal = promauto.NewCounterVec(
+	prometheus.CounterOpts{
+		Namespace: "threat_telemetry_hub",
+		Subsystem: "export",
+		Name:      "events_dropped_total",
+		Help:      "Total processed events dropped from an export sink's bounded queue, by sink.",
+	},
+	[]string{"sink"},
+)
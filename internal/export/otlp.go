@@ -0,0 +1,136 @@
+package export
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/lvonguyen/threat-telemetry-hub/internal/config"
+)
+
+// parentTTL bounds how long a correlation group's parent span stays open
+// waiting for more events, so a group that goes quiet doesn't leak a span
+// forever.
+const parentTTL = 1 * time.Hour
+
+// OTLPSink exports ProcessedEvents as OpenTelemetry spans, independently of
+// the tracer internal/observability.Telemetry sets up for pipeline/HTTP
+// trace propagation: one span per event, nested under a parent span per
+// CorrelationID so every event in a correlation group shows up as part of
+// the same trace. Span attributes carry mitre_technique/risk_level rather than
+// OTLP logs - the locked OTel SDK version predates the logs API stabilizing,
+// so a log line per event would mean vendoring an experimental module for a
+// feature spans already cover reasonably well.
+type OTLPSink struct {
+	tp     *sdktrace.TracerProvider
+	tracer trace.Tracer
+
+	mu      sync.Mutex
+	parents map[string]*parentSpan // correlation ID -> open parent span
+}
+
+// parentSpan tracks when a correlation group's parent span was opened, so
+// cleanup can end and evict it once parentTTL has passed with no new events.
+type parentSpan struct {
+	span      trace.Span
+	createdAt time.Time
+	lastSeen  time.Time
+}
+
+// NewOTLPSink creates an OTLPSink exporting to cfg.Endpoint over its own
+// TracerProvider, independent of the global one observability.Telemetry
+// installs.
+func NewOTLPSink(ctx context.Context, cfg config.OTLPExportConfig) (*OTLPSink, error) {
+	exporter, err := otlptracegrpc.New(ctx,
+		otlptracegrpc.WithEndpoint(cfg.Endpoint),
+		otlptracegrpc.WithInsecure(),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("creating OTLP export exporter: %w", err)
+	}
+
+	tp := sdktrace.NewTracerProvider(sdktrace.WithBatcher(exporter))
+
+	s := &OTLPSink{
+		tp:      tp,
+		tracer:  tp.Tracer("threat-telemetry-hub/export"),
+		parents: make(map[string]*parentSpan),
+	}
+	go s.cleanup()
+
+	return s, nil
+}
+
+// Name returns the sink's name.
+func (s *OTLPSink) Name() string { return "otlp" }
+
+// Emit starts a span for event, nested under the parent span for its
+// CorrelationID (creating one if this is the first event seen for it).
+func (s *OTLPSink) Emit(ctx context.Context, event *Event) error {
+	ctx = s.parentContext(ctx, event.CorrelationID)
+
+	_, span := s.tracer.Start(ctx, "processed_event",
+		trace.WithAttributes(
+			attribute.String("event.id", event.ID),
+			attribute.String("risk_level", event.RiskLevel),
+			attribute.Float64("risk_score", event.RiskScore),
+			attribute.StringSlice("mitre_technique", event.MITRETechniques),
+		),
+	)
+	span.End()
+
+	return nil
+}
+
+// parentContext returns ctx with the open parent span for correlationID
+// attached, creating that parent span on first use.
+func (s *OTLPSink) parentContext(ctx context.Context, correlationID string) context.Context {
+	if correlationID == "" {
+		return ctx
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	parent, ok := s.parents[correlationID]
+	if !ok {
+		_, span := s.tracer.Start(ctx, "correlation_group",
+			trace.WithAttributes(attribute.String("correlation_id", correlationID)),
+		)
+		parent = &parentSpan{span: span, createdAt: time.Now()}
+		s.parents[correlationID] = parent
+	}
+	parent.lastSeen = time.Now()
+
+	return trace.ContextWithSpan(ctx, parent.span)
+}
+
+// cleanup ends and evicts parent spans that have seen no event for longer
+// than parentTTL, mirroring correlation.Correlator's own group cleanup.
+func (s *OTLPSink) cleanup() {
+	ticker := time.NewTicker(5 * time.Minute)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		s.mu.Lock()
+		now := time.Now()
+		for id, parent := range s.parents {
+			if now.Sub(parent.lastSeen) > parentTTL {
+				parent.span.End()
+				delete(s.parents, id)
+			}
+		}
+		s.mu.Unlock()
+	}
+}
+
+// Shutdown flushes and closes the sink's TracerProvider.
+func (s *OTLPSink) Shutdown(ctx context.Context) error {
+	return s.tp.Shutdown(ctx)
+}
@@ -0,0 +1,40 @@
+// Package export ships fully-processed events out to external systems once
+// the pipeline has finished AI analysis, enrichment, detection, and
+// correlation - OTLP traces, Azure Application Insights, and Kafka for
+// downstream SIEM re-ingestion.
+package export
+
+import (
+	"context"
+	"time"
+)
+
+// Event is the subset of cmd/hub.ProcessedEvent a Sink needs. It's declared
+// here rather than imported because ProcessedEvent lives in package main,
+// which internal packages can't import; cmd/hub maps its ProcessedEvent to
+// an Event when it calls FanOut.Emit.
+type Event struct {
+	ID              string
+	Timestamp       time.Time
+	Source          string
+	SourceType      string
+	CorrelationID   string
+	RiskScore       float64
+	RiskLevel       string
+	MITRETactics    []string
+	MITRETechniques []string
+}
+
+// Sink is a pluggable destination for processed events.
+type Sink interface {
+	Name() string
+	Emit(ctx context.Context, event *Event) error
+}
+
+// Shutdownable is implemented by sinks holding resources - a batched
+// exporter, a buffered client - that need an explicit flush/close on
+// shutdown. It's checked via type assertion so Sink itself stays minimal for
+// sinks (like Kafka's stub in kafka.go) that don't need it.
+type Shutdownable interface {
+	Shutdown(ctx context.Context) error
+}
@@ -0,0 +1,102 @@
+//go:build !no_aws_cloudtrail
+
+package ingestion
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/lvonguyen/threat-telemetry-hub/internal/checkpoint"
+	"github.com/lvonguyen/threat-telemetry-hub/internal/config"
+)
+
+func init() {
+	Register("aws-cloudtrail", func(cfg config.IngestionConfig, logger *zap.Logger, checkpointer checkpoint.Checkpointer) (Collector, bool, error) {
+		c := cfg.Cloud.AWS
+		if !c.Enabled {
+			return nil, false, nil
+		}
+		return NewAWSCloudTrailCollector(c, logger, checkpointer), true, nil
+	})
+}
+
+// cloudTrailCursor is this collector's cursor encoding: resume listing the
+// trail's S3 bucket for objects modified at or after AfterTime, skipping
+// back to LastObjectKey within that same second so objects already
+// processed aren't re-read.
+type cloudTrailCursor struct {
+	AfterTime     time.Time `json:"after_time"`
+	LastObjectKey string    `json:"last_object_key"`
+}
+
+// AWSCloudTrailCollector collects events from AWS CloudTrail
+type AWSCloudTrailCollector struct {
+	BaseCollector
+	config config.AWSCloudConfig
+}
+
+func NewAWSCloudTrailCollector(cfg config.AWSCloudConfig, logger *zap.Logger, checkpointer checkpoint.Checkpointer) *AWSCloudTrailCollector {
+	return &AWSCloudTrailCollector{
+		BaseCollector: BaseCollector{
+			name: "aws-cloudtrail", cType: "cloud", enabled: cfg.Enabled, logger: logger,
+			checkpointer: checkpointer,
+			// S3 listing can return the same object twice across polls
+			// that straddle a page boundary, and a retried digest-file
+			// download can reprocess a trail file already handed
+			// downstream - the dedup filter catches both.
+			dedup: checkpoint.NewBloomFilter(100_000, 0.01),
+		},
+		config: cfg,
+	}
+}
+
+func (c *AWSCloudTrailCollector) Collect(ctx context.Context, output *ChannelSink) error {
+	cursor, err := c.loadCloudTrailCursor(ctx)
+	if err != nil {
+		c.logger.Warn("Failed to load checkpoint, starting from now", zap.Error(err))
+		cursor = cloudTrailCursor{AfterTime: time.Now().UTC()}
+	}
+
+	// TODO: Implement AWS CloudTrail integration
+	// - Use OIDC federation for authentication (no stored credentials)
+	// - List objects in the trail's S3 bucket newer than cursor.AfterTime,
+	//   resuming after cursor.LastObjectKey within that second
+	// - Filter for security-relevant events
+	//
+	// For each object: check SeenRecently(objectKey) and skip if true,
+	// otherwise build and send the RawEvent, call MarkSeen(objectKey),
+	// and only then save a new cloudTrailCursor via saveCloudTrailCursor -
+	// in that order, so a crash between send and cursor-save re-delivers
+	// the object next run instead of silently skipping it.
+	c.logger.Info("AWS CloudTrail collector started (stub)", zap.Time("resume_after", cursor.AfterTime))
+	<-ctx.Done()
+	return nil
+}
+
+// CursorAtTime implements Rewindable, so Manager.Rewind can force this
+// collector to resume listing from an operator-supplied timestamp.
+func (c *AWSCloudTrailCollector) CursorAtTime(t time.Time) []byte {
+	cur, _ := json.Marshal(cloudTrailCursor{AfterTime: t})
+	return cur
+}
+
+func (c *AWSCloudTrailCollector) loadCloudTrailCursor(ctx context.Context) (cloudTrailCursor, error) {
+	var cur cloudTrailCursor
+	raw, err := c.LoadCursor(ctx)
+	if err != nil || raw == nil {
+		return cur, err
+	}
+	err = json.Unmarshal(raw, &cur)
+	return cur, err
+}
+
+func (c *AWSCloudTrailCollector) saveCloudTrailCursor(ctx context.Context, cur cloudTrailCursor) error {
+	raw, err := json.Marshal(cur)
+	if err != nil {
+		return err
+	}
+	return c.SaveCursor(ctx, raw)
+}
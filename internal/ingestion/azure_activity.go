@@ -0,0 +1,45 @@
+//go:build !no_azure_activity
+
+package ingestion
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+
+	"github.com/lvonguyen/threat-telemetry-hub/internal/checkpoint"
+	"github.com/lvonguyen/threat-telemetry-hub/internal/config"
+)
+
+func init() {
+	Register("azure-activity", func(cfg config.IngestionConfig, logger *zap.Logger, checkpointer checkpoint.Checkpointer) (Collector, bool, error) {
+		c := cfg.Cloud.Azure
+		if !c.Enabled {
+			return nil, false, nil
+		}
+		return NewAzureActivityCollector(c, logger, checkpointer), true, nil
+	})
+}
+
+// AzureActivityCollector collects events from Azure Activity Log
+type AzureActivityCollector struct {
+	BaseCollector
+	config config.AzureCloudConfig
+}
+
+func NewAzureActivityCollector(cfg config.AzureCloudConfig, logger *zap.Logger, checkpointer checkpoint.Checkpointer) *AzureActivityCollector {
+	return &AzureActivityCollector{
+		BaseCollector: BaseCollector{name: "azure-activity", cType: "cloud", enabled: cfg.Enabled, logger: logger, checkpointer: checkpointer},
+		config:        cfg,
+	}
+}
+
+func (c *AzureActivityCollector) Collect(ctx context.Context, output *ChannelSink) error {
+	// TODO: Implement Azure Activity Log integration
+	// - Use Managed Identity for authentication
+	// - Query Azure Monitor API
+	// - Filter for security-relevant events
+	c.logger.Info("Azure Activity collector started (stub)")
+	<-ctx.Done()
+	return nil
+}
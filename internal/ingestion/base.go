@@ -0,0 +1,247 @@
+// Package ingestion handles data ingestion from various security sources
+package ingestion
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+
+	"github.com/lvonguyen/threat-telemetry-hub/internal/checkpoint"
+	"github.com/lvonguyen/threat-telemetry-hub/internal/observability"
+)
+
+// RawEvent is the canonical ingestion envelope. It is a CloudEvents v1.0
+// event: `source` is a reverse-DNS-free URI identifying the producing
+// collector (e.g. "//crowdstrike.example/detects"), `type` is reverse-DNS
+// (e.g. "com.crowdstrike.falcon.detection"), and the vendor payload lives in
+// Data(). The two extension attributes below carry the routing metadata the
+// rest of the pipeline keys on.
+type RawEvent = cloudevents.Event
+
+const (
+	extSourceName = "sourcename" // e.g. "crowdstrike" - short collector identity
+	extSourceType = "sourcetype" // e.g. "edr", "siem", "cloud", "dlp"
+)
+
+// BaseCollector provides common functionality for all collectors
+type BaseCollector struct {
+	name    string
+	cType   string
+	enabled bool
+	logger  *zap.Logger
+
+	checkpointer checkpoint.Checkpointer
+	dedup        *checkpoint.BloomFilter
+
+	cursorMu       sync.Mutex
+	lastCursorSave time.Time
+
+	statusMu      sync.Mutex
+	healthy       bool
+	lastError     string
+	initializedAt time.Time
+}
+
+func (b *BaseCollector) Name() string  { return b.name }
+func (b *BaseCollector) Type() string  { return b.cType }
+func (b *BaseCollector) Enabled() bool { return b.enabled }
+
+// Init performs no setup and reports healthy immediately. Collectors that
+// need authenticated setup (OAuth token exchange, session cookies, ADC
+// discovery) before Collect can run override this and call markInitialized
+// themselves with the outcome.
+func (b *BaseCollector) Init(ctx context.Context) error {
+	b.markInitialized(nil)
+	return nil
+}
+
+// Stop performs no cleanup. Collectors holding open connections or
+// in-flight requests override this to drain them before returning.
+func (b *BaseCollector) Stop(ctx context.Context) error {
+	return nil
+}
+
+// markInitialized records the outcome of Init, for HealthStatus to surface
+// through CollectorStatus. Call it with nil on success, or the error Init
+// is about to return on failure.
+func (b *BaseCollector) markInitialized(err error) {
+	b.statusMu.Lock()
+	defer b.statusMu.Unlock()
+	if err != nil {
+		b.healthy = false
+		b.lastError = err.Error()
+		return
+	}
+	b.healthy = true
+	b.lastError = ""
+	b.initializedAt = time.Now()
+}
+
+// HealthStatus implements statusReporter (see manager.go), exposing Init's
+// outcome through Manager.GetCollectorStatus.
+func (b *BaseCollector) HealthStatus() (healthy bool, lastError string, initializedAt time.Time) {
+	b.statusMu.Lock()
+	defer b.statusMu.Unlock()
+	return b.healthy, b.lastError, b.initializedAt
+}
+
+// LoadCursor returns this collector's last saved cursor, or nil if it has
+// never saved one (a cold start or a backend with no prior run). Call this
+// once when Collect starts to resume polling from where the last run left
+// off.
+func (b *BaseCollector) LoadCursor(ctx context.Context) ([]byte, error) {
+	if b.checkpointer == nil {
+		return nil, nil
+	}
+	return b.checkpointer.Load(ctx, b.name)
+}
+
+// SaveCursor persists cursor as this collector's new read position. Call
+// this only after the events up to cursor have been handed off to the
+// output channel (ChannelSink.Send/TrySend), so a crash before that
+// hand-off re-delivers the event next run rather than skipping it.
+//
+// This is at-least-once, not exactly-once: handing an event to the
+// channel only means it's been buffered in this process's memory, not
+// that a downstream worker has durably processed it. A crash after
+// SaveCursor but before the buffered event is drained loses that event
+// even though the cursor has already advanced past it. Collectors pair
+// this with their dedup bloom filter (SeenRecently/MarkSeen) to make
+// redelivery of an event that *was* drained safe, but there is no
+// per-event ack from the pipeline back to the collector, so a crash in
+// that narrow window is not covered.
+func (b *BaseCollector) SaveCursor(ctx context.Context, cursor []byte) error {
+	if b.checkpointer == nil {
+		return nil
+	}
+	if err := b.checkpointer.Save(ctx, b.name, cursor); err != nil {
+		return err
+	}
+	b.cursorMu.Lock()
+	b.lastCursorSave = time.Now()
+	b.cursorMu.Unlock()
+	cursorLagSeconds.WithLabelValues(b.name).Set(0)
+	return nil
+}
+
+// CursorLag returns how long it's been since this collector last advanced
+// its checkpoint. It's zero until the first successful SaveCursor, which
+// for a checkpointer-less collector is never - callers polling this should
+// treat zero as "no data" rather than "perfectly caught up".
+func (b *BaseCollector) CursorLag() time.Duration {
+	b.cursorMu.Lock()
+	defer b.cursorMu.Unlock()
+	if b.lastCursorSave.IsZero() {
+		return 0
+	}
+	return time.Since(b.lastCursorSave)
+}
+
+// SeenRecently reports whether id has probably already been collected,
+// for sources whose API can redeliver the same event across overlapping
+// poll windows or object listings. Collectors that don't need dedup (no
+// bloom filter configured) always report false.
+func (b *BaseCollector) SeenRecently(id string) bool {
+	if b.dedup == nil {
+		return false
+	}
+	return b.dedup.Test(id)
+}
+
+// MarkSeen records id in the dedup filter, if one is configured.
+func (b *BaseCollector) MarkSeen(id string) {
+	if b.dedup != nil {
+		b.dedup.Add(id)
+	}
+}
+
+// CreateRawEvent builds a CloudEvents-compliant RawEvent from a collector's
+// short identity (source), its category (sourceType), and the vendor JSON
+// payload. id should be unique per collector (e.g. vendor event ID).
+//
+// It also starts the root OpenTelemetry span for e's entire journey
+// through the pipeline. The span ends before CreateRawEvent returns, but
+// its trace context is injected into e's CloudEvents extension attributes
+// (see ContextWithTrace), so every later stage - normalization,
+// enrichment, AI analysis, detection - starts its own child span under the
+// same trace without needing a live Go context to hold the root span open
+// across the channel and goroutine boundaries between them.
+func CreateRawEvent(ctx context.Context, id, source, sourceType string, data map[string]interface{}) *RawEvent {
+	ctx, span := otel.Tracer(tracerName).Start(ctx, "ingestion.create_raw_event", trace.WithAttributes(
+		attribute.String(observability.KeyEventID, id),
+		attribute.String(observability.KeyEventSource, source),
+	))
+	defer span.End()
+
+	e := cloudevents.NewEvent()
+	e.SetID(id)
+	e.SetSource(fmt.Sprintf("//%s.example/events", source))
+	e.SetType(fmt.Sprintf("io.threat-telemetry-hub.%s.raw", source))
+	e.SetTime(time.Now().UTC())
+	e.SetExtension(extSourceName, source)
+	e.SetExtension(extSourceType, sourceType)
+	_ = e.SetData(cloudevents.ApplicationJSON, data)
+	injectTraceContext(ctx, &e)
+	return &e
+}
+
+// SourceName returns the short collector identity carried in the
+// "sourcename" extension attribute, e.g. "crowdstrike".
+func SourceName(e *RawEvent) string {
+	if v, ok := e.Extensions()[extSourceName].(string); ok {
+		return v
+	}
+	return e.Source()
+}
+
+// SourceType returns the collector category carried in the "sourcetype"
+// extension attribute, e.g. "edr".
+func SourceType(e *RawEvent) string {
+	if v, ok := e.Extensions()[extSourceType].(string); ok {
+		return v
+	}
+	return ""
+}
+
+// DataMap decodes the event's JSON payload into a map, mirroring the shape
+// downstream normalization/AI code expects from vendor APIs.
+func DataMap(e *RawEvent) map[string]interface{} {
+	var m map[string]interface{}
+	if len(e.Data()) == 0 {
+		return m
+	}
+	_ = json.Unmarshal(e.Data(), &m)
+	return m
+}
+
+// CollectorStatus represents the status of a collector
+type CollectorStatus struct {
+	Name    string `json:"name"`
+	Type    string `json:"type"`
+	Enabled bool   `json:"enabled"`
+	// Healthy, LastError, and InitializedAt reflect the outcome of this
+	// collector's last Init call (see statusReporter in manager.go) - a
+	// collector can be Enabled but not Healthy if Init failed and it was
+	// skipped rather than started.
+	Healthy       bool      `json:"healthy"`
+	LastError     string    `json:"last_error,omitempty"`
+	InitializedAt time.Time `json:"initialized_at,omitempty"`
+}
+
+// Rewindable is implemented by collectors whose cursor can be derived from
+// a point in time, letting Manager.Rewind force a backfill from an
+// operator-supplied timestamp without knowing the collector's own cursor
+// encoding.
+type Rewindable interface {
+	// CursorAtTime returns the cursor value that would make a subsequent
+	// Collect resume from t.
+	CursorAtTime(t time.Time) []byte
+}
@@ -1,331 +0,0 @@
-// Package ingestion handles data ingestion from various security sources
-package ingestion
-
-import (
-	"context"
-	"time"
-
-	"go.uber.org/zap"
-
-	"github.com/lvonguyen/threat-telemetry-hub/internal/config"
-)
-
-// BaseCollector provides common functionality for all collectors
-type BaseCollector struct {
-	name    string
-	cType   string
-	enabled bool
-	logger  *zap.Logger
-}
-
-func (b *BaseCollector) Name() string    { return b.name }
-func (b *BaseCollector) Type() string    { return b.cType }
-func (b *BaseCollector) Enabled() bool   { return b.enabled }
-
-// =============================================================================
-// EDR Collectors
-// =============================================================================
-
-// CrowdStrikeCollector collects events from CrowdStrike Falcon
-type CrowdStrikeCollector struct {
-	BaseCollector
-	config config.CrowdStrikeConfig
-}
-
-func NewCrowdStrikeCollector(cfg config.CrowdStrikeConfig, logger *zap.Logger) *CrowdStrikeCollector {
-	return &CrowdStrikeCollector{
-		BaseCollector: BaseCollector{name: "crowdstrike", cType: "edr", enabled: cfg.Enabled, logger: logger},
-		config:        cfg,
-	}
-}
-
-func (c *CrowdStrikeCollector) Collect(ctx context.Context, output chan<- *RawEvent) error {
-	// TODO: Implement CrowdStrike Falcon API integration
-	// - Authenticate using OAuth2 client credentials
-	// - Poll /detects/queries/detects/v1 for new detections
-	// - Get full details via /detects/entities/summaries/GET/v2
-	c.logger.Info("CrowdStrike collector started (stub)")
-	<-ctx.Done()
-	return nil
-}
-
-// SentinelOneCollector collects events from SentinelOne
-type SentinelOneCollector struct {
-	BaseCollector
-	config config.SentinelOneConfig
-}
-
-func NewSentinelOneCollector(cfg config.SentinelOneConfig, logger *zap.Logger) *SentinelOneCollector {
-	return &SentinelOneCollector{
-		BaseCollector: BaseCollector{name: "sentinelone", cType: "edr", enabled: cfg.Enabled, logger: logger},
-		config:        cfg,
-	}
-}
-
-func (c *SentinelOneCollector) Collect(ctx context.Context, output chan<- *RawEvent) error {
-	// TODO: Implement SentinelOne API integration
-	// - Authenticate using API token
-	// - Poll /web/api/v2.1/threats for new threats
-	// - Get activity logs via /web/api/v2.1/activities
-	c.logger.Info("SentinelOne collector started (stub)")
-	<-ctx.Done()
-	return nil
-}
-
-// DefenderCollector collects events from Microsoft Defender for Endpoint
-type DefenderCollector struct {
-	BaseCollector
-	config config.DefenderConfig
-}
-
-func NewDefenderCollector(cfg config.DefenderConfig, logger *zap.Logger) *DefenderCollector {
-	return &DefenderCollector{
-		BaseCollector: BaseCollector{name: "defender", cType: "edr", enabled: cfg.Enabled, logger: logger},
-		config:        cfg,
-	}
-}
-
-func (c *DefenderCollector) Collect(ctx context.Context, output chan<- *RawEvent) error {
-	// TODO: Implement Microsoft Defender API integration
-	// - Authenticate using OAuth2 with app registration
-	// - Query /api/alerts for alerts
-	// - Use Advanced Hunting API for custom queries
-	c.logger.Info("Defender collector started (stub)")
-	<-ctx.Done()
-	return nil
-}
-
-// =============================================================================
-// SIEM Collectors
-// =============================================================================
-
-// SplunkCollector collects events from Splunk
-type SplunkCollector struct {
-	BaseCollector
-	config config.SplunkConfig
-}
-
-func NewSplunkCollector(cfg config.SplunkConfig, logger *zap.Logger) *SplunkCollector {
-	return &SplunkCollector{
-		BaseCollector: BaseCollector{name: "splunk", cType: "siem", enabled: cfg.Enabled, logger: logger},
-		config:        cfg,
-	}
-}
-
-func (c *SplunkCollector) Collect(ctx context.Context, output chan<- *RawEvent) error {
-	// TODO: Implement Splunk integration
-	// - Use Splunk REST API or HEC
-	// - Run saved searches or real-time searches
-	// - Stream results to output channel
-	c.logger.Info("Splunk collector started (stub)")
-	<-ctx.Done()
-	return nil
-}
-
-// ElasticsearchCollector collects events from Elasticsearch
-type ElasticsearchCollector struct {
-	BaseCollector
-	config config.ElasticsearchConfig
-}
-
-func NewElasticsearchCollector(cfg config.ElasticsearchConfig, logger *zap.Logger) *ElasticsearchCollector {
-	return &ElasticsearchCollector{
-		BaseCollector: BaseCollector{name: "elasticsearch", cType: "siem", enabled: cfg.Enabled, logger: logger},
-		config:        cfg,
-	}
-}
-
-func (c *ElasticsearchCollector) Collect(ctx context.Context, output chan<- *RawEvent) error {
-	// TODO: Implement Elasticsearch integration
-	// - Use scroll API or search_after for pagination
-	// - Query security-* indices
-	// - Support custom queries
-	c.logger.Info("Elasticsearch collector started (stub)")
-	<-ctx.Done()
-	return nil
-}
-
-// =============================================================================
-// Cloud Collectors
-// =============================================================================
-
-// AWSCloudTrailCollector collects events from AWS CloudTrail
-type AWSCloudTrailCollector struct {
-	BaseCollector
-	config config.AWSCloudConfig
-}
-
-func NewAWSCloudTrailCollector(cfg config.AWSCloudConfig, logger *zap.Logger) *AWSCloudTrailCollector {
-	return &AWSCloudTrailCollector{
-		BaseCollector: BaseCollector{name: "aws-cloudtrail", cType: "cloud", enabled: cfg.Enabled, logger: logger},
-		config:        cfg,
-	}
-}
-
-func (c *AWSCloudTrailCollector) Collect(ctx context.Context, output chan<- *RawEvent) error {
-	// TODO: Implement AWS CloudTrail integration
-	// - Use OIDC federation for authentication (no stored credentials)
-	// - Query CloudTrail Lake or S3 bucket
-	// - Filter for security-relevant events
-	c.logger.Info("AWS CloudTrail collector started (stub)")
-	<-ctx.Done()
-	return nil
-}
-
-// AzureActivityCollector collects events from Azure Activity Log
-type AzureActivityCollector struct {
-	BaseCollector
-	config config.AzureCloudConfig
-}
-
-func NewAzureActivityCollector(cfg config.AzureCloudConfig, logger *zap.Logger) *AzureActivityCollector {
-	return &AzureActivityCollector{
-		BaseCollector: BaseCollector{name: "azure-activity", cType: "cloud", enabled: cfg.Enabled, logger: logger},
-		config:        cfg,
-	}
-}
-
-func (c *AzureActivityCollector) Collect(ctx context.Context, output chan<- *RawEvent) error {
-	// TODO: Implement Azure Activity Log integration
-	// - Use Managed Identity for authentication
-	// - Query Azure Monitor API
-	// - Filter for security-relevant events
-	c.logger.Info("Azure Activity collector started (stub)")
-	<-ctx.Done()
-	return nil
-}
-
-// GCPAuditCollector collects events from GCP Audit Logs
-type GCPAuditCollector struct {
-	BaseCollector
-	config config.GCPCloudConfig
-}
-
-func NewGCPAuditCollector(cfg config.GCPCloudConfig, logger *zap.Logger) *GCPAuditCollector {
-	return &GCPAuditCollector{
-		BaseCollector: BaseCollector{name: "gcp-audit", cType: "cloud", enabled: cfg.Enabled, logger: logger},
-		config:        cfg,
-	}
-}
-
-func (c *GCPAuditCollector) Collect(ctx context.Context, output chan<- *RawEvent) error {
-	// TODO: Implement GCP Audit Log integration
-	// - Use Workload Identity Federation for authentication
-	// - Query Cloud Logging API
-	// - Filter for security-relevant events
-	c.logger.Info("GCP Audit collector started (stub)")
-	<-ctx.Done()
-	return nil
-}
-
-// =============================================================================
-// DLP Collectors (COTS Integration)
-// =============================================================================
-
-// DigitalGuardianCollector collects events from Digital Guardian DLP
-type DigitalGuardianCollector struct {
-	BaseCollector
-	config config.DigitalGuardianConfig
-}
-
-func NewDigitalGuardianCollector(cfg config.DigitalGuardianConfig, logger *zap.Logger) *DigitalGuardianCollector {
-	return &DigitalGuardianCollector{
-		BaseCollector: BaseCollector{name: "digital-guardian", cType: "dlp", enabled: cfg.Enabled, logger: logger},
-		config:        cfg,
-	}
-}
-
-func (c *DigitalGuardianCollector) Collect(ctx context.Context, output chan<- *RawEvent) error {
-	// TODO: Implement Digital Guardian integration
-	// - Authenticate using API key
-	// - Poll for DLP policy violations
-	// - Normalize to common DLP event schema
-	c.logger.Info("Digital Guardian collector started (stub)")
-	<-ctx.Done()
-	return nil
-}
-
-// ProofpointCollector collects events from Proofpoint
-type ProofpointCollector struct {
-	BaseCollector
-	config config.ProofpointConfig
-}
-
-func NewProofpointCollector(cfg config.ProofpointConfig, logger *zap.Logger) *ProofpointCollector {
-	return &ProofpointCollector{
-		BaseCollector: BaseCollector{name: "proofpoint", cType: "dlp", enabled: cfg.Enabled, logger: logger},
-		config:        cfg,
-	}
-}
-
-func (c *ProofpointCollector) Collect(ctx context.Context, output chan<- *RawEvent) error {
-	// TODO: Implement Proofpoint integration
-	// - Can use API polling or webhook receiver
-	// - Get email DLP events and threat intelligence
-	// - Normalize to common DLP event schema
-	c.logger.Info("Proofpoint collector started (stub)")
-	<-ctx.Done()
-	return nil
-}
-
-// PurviewCollector collects events from Microsoft Purview DLP
-type PurviewCollector struct {
-	BaseCollector
-	config config.PurviewConfig
-}
-
-func NewPurviewCollector(cfg config.PurviewConfig, logger *zap.Logger) *PurviewCollector {
-	return &PurviewCollector{
-		BaseCollector: BaseCollector{name: "purview", cType: "dlp", enabled: cfg.Enabled, logger: logger},
-		config:        cfg,
-	}
-}
-
-func (c *PurviewCollector) Collect(ctx context.Context, output chan<- *RawEvent) error {
-	// TODO: Implement Microsoft Purview integration
-	// - Authenticate using OAuth2 with app registration
-	// - Query Microsoft Graph Security API
-	// - Get DLP policy match events from M365
-	c.logger.Info("Purview collector started (stub)")
-	<-ctx.Done()
-	return nil
-}
-
-// NetskopeCollector collects events from Netskope
-type NetskopeCollector struct {
-	BaseCollector
-	config config.NetskopeConfig
-}
-
-func NewNetskopeCollector(cfg config.NetskopeConfig, logger *zap.Logger) *NetskopeCollector {
-	return &NetskopeCollector{
-		BaseCollector: BaseCollector{name: "netskope", cType: "dlp", enabled: cfg.Enabled, logger: logger},
-		config:        cfg,
-	}
-}
-
-func (c *NetskopeCollector) Collect(ctx context.Context, output chan<- *RawEvent) error {
-	// TODO: Implement Netskope integration
-	// - Authenticate using API token
-	// - Poll /api/v2/events/data/dlp for DLP events
-	// - Also collect CASB and UEBA events
-	c.logger.Info("Netskope collector started (stub)")
-	<-ctx.Done()
-	return nil
-}
-
-// =============================================================================
-// Helper Functions
-// =============================================================================
-
-// CreateRawEvent is a helper to create RawEvent with current timestamp
-func CreateRawEvent(id, source, sourceType string, data map[string]interface{}) *RawEvent {
-	return &RawEvent{
-		ID:         id,
-		Timestamp:  time.Now().UTC(),
-		Source:     source,
-		SourceType: sourceType,
-		Data:       data,
-	}
-}
-
@@ -0,0 +1,164 @@
+//go:build !no_crowdsec
+
+package ingestion
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/lvonguyen/threat-telemetry-hub/internal/checkpoint"
+	"github.com/lvonguyen/threat-telemetry-hub/internal/config"
+	"github.com/lvonguyen/threat-telemetry-hub/internal/crowdsec"
+)
+
+func init() {
+	Register("crowdsec", func(cfg config.IngestionConfig, logger *zap.Logger, checkpointer checkpoint.Checkpointer) (Collector, bool, error) {
+		c := cfg.IPS.CrowdSec
+		if !c.Enabled {
+			return nil, false, nil
+		}
+		return NewCrowdSecCollector(c, logger, checkpointer), true, nil
+	})
+}
+
+// crowdsecCursor is this collector's cursor encoding. LAPI's startup=true
+// response carries every currently active decision; every later poll is
+// startup=false and carries only what changed since, so StartupDone must
+// survive a restart or the hub would re-emit the entire ban list each time
+// it reconnects.
+type crowdsecCursor struct {
+	StartupDone bool `json:"startup_done"`
+}
+
+// CrowdSecCollector polls the CrowdSec Local API's decisions stream for
+// bans, ranges, and ASN blocks (SourceType "ips").
+type CrowdSecCollector struct {
+	BaseCollector
+	config config.CrowdSecConfig
+	client *crowdsec.Client
+}
+
+func NewCrowdSecCollector(cfg config.CrowdSecConfig, logger *zap.Logger, checkpointer checkpoint.Checkpointer) *CrowdSecCollector {
+	return &CrowdSecCollector{
+		BaseCollector: BaseCollector{
+			name: "crowdsec", cType: "ips", enabled: cfg.Enabled, logger: logger,
+			checkpointer: checkpointer,
+			// Decisions can reappear across overlapping stream polls until
+			// CrowdSec actually expires them.
+			dedup: checkpoint.NewBloomFilter(100_000, 0.01),
+		},
+		config: cfg,
+	}
+}
+
+func (c *CrowdSecCollector) Init(ctx context.Context) error {
+	client, err := crowdsec.NewClient(c.config)
+	if err != nil {
+		c.markInitialized(err)
+		return err
+	}
+	c.client = client
+	c.logger.Info("CrowdSec collector initialized")
+	c.markInitialized(nil)
+	return nil
+}
+
+func (c *CrowdSecCollector) Collect(ctx context.Context, output *ChannelSink) error {
+	cursor, err := c.loadCrowdSecCursor(ctx)
+	if err != nil {
+		c.logger.Warn("Failed to load checkpoint, starting from a full snapshot", zap.Error(err))
+		cursor = crowdsecCursor{}
+	}
+
+	pollInterval := time.Duration(c.config.PollIntervalSeconds) * time.Second
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+
+	for {
+		if err := c.poll(ctx, output, &cursor); err != nil {
+			c.logger.Warn("CrowdSec decisions poll failed", zap.Error(err))
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// poll runs a single LAPI stream call, emits a RawEvent per new or deleted
+// decision, and only advances cursor.StartupDone (and persists it) once
+// every decision from this call has been handed to output.TrySend - a
+// crash before that point re-polls the same (still-startup) snapshot, and
+// MarkSeen from the partial run dedupes anything already sent. This is
+// at-least-once, not exactly-once: TrySend only buffers a decision onto
+// the in-memory output channel, so a crash after this call returns but
+// before the pipeline drains that buffer still loses the buffered
+// decisions even though the cursor has already advanced past them (see
+// BaseCollector.SaveCursor).
+func (c *CrowdSecCollector) poll(ctx context.Context, output *ChannelSink, cursor *crowdsecCursor) error {
+	resp, err := c.client.StreamDecisions(ctx, !cursor.StartupDone)
+	if err != nil {
+		return fmt.Errorf("streaming decisions: %w", err)
+	}
+
+	for _, d := range resp.New {
+		c.emitDecision(ctx, output, d, "new")
+	}
+	for _, d := range resp.Deleted {
+		c.emitDecision(ctx, output, d, "deleted")
+	}
+
+	cursor.StartupDone = true
+	return c.saveCrowdSecCursor(ctx, *cursor)
+}
+
+// emitDecision sends one decision as a RawEvent, skipping it if the same
+// scenario+id+action has already been collected - LAPI can redeliver a
+// decision across overlapping stream polls until it actually expires.
+func (c *CrowdSecCollector) emitDecision(ctx context.Context, output *ChannelSink, d crowdsec.Decision, action string) {
+	id := fmt.Sprintf("%s:%d:%s", d.Scenario, d.ID, action)
+	if c.SeenRecently(id) {
+		return
+	}
+
+	event := CreateRawEvent(ctx, id, c.name, "ips", map[string]interface{}{
+		"action":   action,
+		"type":     d.Type,
+		"scope":    d.Scope,
+		"value":    d.Value,
+		"duration": d.Duration,
+		"scenario": d.Scenario,
+		"origin":   d.Origin,
+	})
+
+	if !output.TrySend(event) {
+		c.logger.Warn("Dropping CrowdSec decision, output channel full",
+			zap.String("decision_id", id), zap.String("action", action))
+		return
+	}
+	c.MarkSeen(id)
+}
+
+func (c *CrowdSecCollector) loadCrowdSecCursor(ctx context.Context) (crowdsecCursor, error) {
+	var cur crowdsecCursor
+	raw, err := c.LoadCursor(ctx)
+	if err != nil || raw == nil {
+		return cur, err
+	}
+	err = json.Unmarshal(raw, &cur)
+	return cur, err
+}
+
+func (c *CrowdSecCollector) saveCrowdSecCursor(ctx context.Context, cur crowdsecCursor) error {
+	raw, err := json.Marshal(cur)
+	if err != nil {
+		return err
+	}
+	return c.SaveCursor(ctx, raw)
+}
@@ -0,0 +1,53 @@
+//go:build !no_crowdstrike
+
+package ingestion
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+
+	"github.com/lvonguyen/threat-telemetry-hub/internal/checkpoint"
+	"github.com/lvonguyen/threat-telemetry-hub/internal/config"
+)
+
+func init() {
+	Register("crowdstrike", func(cfg config.IngestionConfig, logger *zap.Logger, checkpointer checkpoint.Checkpointer) (Collector, bool, error) {
+		c := cfg.EDR.CrowdStrike
+		if !c.Enabled {
+			return nil, false, nil
+		}
+		return NewCrowdStrikeCollector(c, logger, checkpointer), true, nil
+	})
+}
+
+// CrowdStrikeCollector collects events from CrowdStrike Falcon
+type CrowdStrikeCollector struct {
+	BaseCollector
+	config config.CrowdStrikeConfig
+}
+
+func NewCrowdStrikeCollector(cfg config.CrowdStrikeConfig, logger *zap.Logger, checkpointer checkpoint.Checkpointer) *CrowdStrikeCollector {
+	return &CrowdStrikeCollector{
+		BaseCollector: BaseCollector{name: "crowdstrike", cType: "edr", enabled: cfg.Enabled, logger: logger, checkpointer: checkpointer},
+		config:        cfg,
+	}
+}
+
+func (c *CrowdStrikeCollector) Init(ctx context.Context) error {
+	// TODO: Exchange the configured client ID/secret for an OAuth2 bearer
+	// token via /oauth2/token and cache it for reuse until it expires.
+	c.logger.Info("CrowdStrike collector initialized (stub)")
+	c.markInitialized(nil)
+	return nil
+}
+
+func (c *CrowdStrikeCollector) Collect(ctx context.Context, output *ChannelSink) error {
+	// TODO: Implement CrowdStrike Falcon API integration
+	// - Authenticate using OAuth2 client credentials
+	// - Poll /detects/queries/detects/v1 for new detections
+	// - Get full details via /detects/entities/summaries/GET/v2
+	c.logger.Info("CrowdStrike collector started (stub)")
+	<-ctx.Done()
+	return nil
+}
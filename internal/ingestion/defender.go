@@ -0,0 +1,53 @@
+//go:build !no_defender
+
+package ingestion
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+
+	"github.com/lvonguyen/threat-telemetry-hub/internal/checkpoint"
+	"github.com/lvonguyen/threat-telemetry-hub/internal/config"
+)
+
+func init() {
+	Register("defender", func(cfg config.IngestionConfig, logger *zap.Logger, checkpointer checkpoint.Checkpointer) (Collector, bool, error) {
+		c := cfg.EDR.Defender
+		if !c.Enabled {
+			return nil, false, nil
+		}
+		return NewDefenderCollector(c, logger, checkpointer), true, nil
+	})
+}
+
+// DefenderCollector collects events from Microsoft Defender for Endpoint
+type DefenderCollector struct {
+	BaseCollector
+	config config.DefenderConfig
+}
+
+func NewDefenderCollector(cfg config.DefenderConfig, logger *zap.Logger, checkpointer checkpoint.Checkpointer) *DefenderCollector {
+	return &DefenderCollector{
+		BaseCollector: BaseCollector{name: "defender", cType: "edr", enabled: cfg.Enabled, logger: logger, checkpointer: checkpointer},
+		config:        cfg,
+	}
+}
+
+func (c *DefenderCollector) Init(ctx context.Context) error {
+	// TODO: Authenticate against the app registration via OAuth2 client
+	// credentials and cache the resulting bearer token until it expires.
+	c.logger.Info("Defender collector initialized (stub)")
+	c.markInitialized(nil)
+	return nil
+}
+
+func (c *DefenderCollector) Collect(ctx context.Context, output *ChannelSink) error {
+	// TODO: Implement Microsoft Defender API integration
+	// - Authenticate using OAuth2 with app registration
+	// - Query /api/alerts for alerts
+	// - Use Advanced Hunting API for custom queries
+	c.logger.Info("Defender collector started (stub)")
+	<-ctx.Done()
+	return nil
+}
@@ -0,0 +1,45 @@
+//go:build !no_digital_guardian
+
+package ingestion
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+
+	"github.com/lvonguyen/threat-telemetry-hub/internal/checkpoint"
+	"github.com/lvonguyen/threat-telemetry-hub/internal/config"
+)
+
+func init() {
+	Register("digital-guardian", func(cfg config.IngestionConfig, logger *zap.Logger, checkpointer checkpoint.Checkpointer) (Collector, bool, error) {
+		c := cfg.DLP.DigitalGuardian
+		if !c.Enabled {
+			return nil, false, nil
+		}
+		return NewDigitalGuardianCollector(c, logger, checkpointer), true, nil
+	})
+}
+
+// DigitalGuardianCollector collects events from Digital Guardian DLP
+type DigitalGuardianCollector struct {
+	BaseCollector
+	config config.DigitalGuardianConfig
+}
+
+func NewDigitalGuardianCollector(cfg config.DigitalGuardianConfig, logger *zap.Logger, checkpointer checkpoint.Checkpointer) *DigitalGuardianCollector {
+	return &DigitalGuardianCollector{
+		BaseCollector: BaseCollector{name: "digital-guardian", cType: "dlp", enabled: cfg.Enabled, logger: logger, checkpointer: checkpointer},
+		config:        cfg,
+	}
+}
+
+func (c *DigitalGuardianCollector) Collect(ctx context.Context, output *ChannelSink) error {
+	// TODO: Implement Digital Guardian integration
+	// - Authenticate using API key
+	// - Poll for DLP policy violations
+	// - Normalize to common DLP event schema
+	c.logger.Info("Digital Guardian collector started (stub)")
+	<-ctx.Done()
+	return nil
+}
@@ -0,0 +1,45 @@
+//go:build !no_elasticsearch
+
+package ingestion
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+
+	"github.com/lvonguyen/threat-telemetry-hub/internal/checkpoint"
+	"github.com/lvonguyen/threat-telemetry-hub/internal/config"
+)
+
+func init() {
+	Register("elasticsearch", func(cfg config.IngestionConfig, logger *zap.Logger, checkpointer checkpoint.Checkpointer) (Collector, bool, error) {
+		c := cfg.SIEM.Elasticsearch
+		if !c.Enabled {
+			return nil, false, nil
+		}
+		return NewElasticsearchCollector(c, logger, checkpointer), true, nil
+	})
+}
+
+// ElasticsearchCollector collects events from Elasticsearch
+type ElasticsearchCollector struct {
+	BaseCollector
+	config config.ElasticsearchConfig
+}
+
+func NewElasticsearchCollector(cfg config.ElasticsearchConfig, logger *zap.Logger, checkpointer checkpoint.Checkpointer) *ElasticsearchCollector {
+	return &ElasticsearchCollector{
+		BaseCollector: BaseCollector{name: "elasticsearch", cType: "siem", enabled: cfg.Enabled, logger: logger, checkpointer: checkpointer},
+		config:        cfg,
+	}
+}
+
+func (c *ElasticsearchCollector) Collect(ctx context.Context, output *ChannelSink) error {
+	// TODO: Implement Elasticsearch integration
+	// - Use scroll API or search_after for pagination
+	// - Query security-* indices
+	// - Support custom queries
+	c.logger.Info("Elasticsearch collector started (stub)")
+	<-ctx.Done()
+	return nil
+}
@@ -3,139 +3,375 @@ package ingestion
 
 import (
 	"context"
+	"fmt"
+	"log/slog"
 	"time"
 
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
 
+	"github.com/lvonguyen/threat-telemetry-hub/internal/checkpoint"
 	"github.com/lvonguyen/threat-telemetry-hub/internal/config"
+	"github.com/lvonguyen/threat-telemetry-hub/internal/observability"
+	"github.com/lvonguyen/threat-telemetry-hub/internal/safe"
 )
 
-// RawEvent represents a raw event from any source
-type RawEvent struct {
-	ID         string                 `json:"id"`
-	Timestamp  time.Time              `json:"timestamp"`
-	Source     string                 `json:"source"`      // e.g., "crowdstrike", "splunk", "aws"
-	SourceType string                 `json:"source_type"` // e.g., "edr", "siem", "cloud", "dlp"
-	Data       map[string]interface{} `json:"data"`
+// DiscoveryInterval is the default period between config re-reads when
+// Manager.StartDiscovery is used for hot-reload.
+const DiscoveryInterval = 30 * time.Second
+
+// cursorLagReportInterval is how often running collectors' cursor_lag_seconds
+// gauge is refreshed.
+const cursorLagReportInterval = 15 * time.Second
+
+// collectorLifecycleTimeout bounds each individual collector's Init and
+// Stop call, so one hung vendor OAuth exchange or a slow connection drain
+// can't stall startup or shutdown for every other source.
+const collectorLifecycleTimeout = 30 * time.Second
+
+// cursorLagReporter is implemented by every collector via its embedded
+// BaseCollector; it's declared narrowly here rather than added to the
+// Collector interface so collectors that skip checkpointing entirely
+// aren't forced to do anything differently.
+type cursorLagReporter interface {
+	CursorLag() time.Duration
 }
 
-// Manager orchestrates data ingestion from all configured sources
-type Manager struct {
-	config     config.IngestionConfig
-	logger     *zap.Logger
-	collectors []Collector
+// statusReporter is implemented by every collector via its embedded
+// BaseCollector; declared narrowly here for the same reason as
+// cursorLagReporter, so GetCollectorStatus can report Init's outcome
+// without the Collector interface needing to know about health state.
+type statusReporter interface {
+	HealthStatus() (healthy bool, lastError string, initializedAt time.Time)
 }
 
-// Collector defines the interface for data collectors
-type Collector interface {
-	// Name returns the collector name
-	Name() string
-	// Type returns the collector type (edr, siem, cloud, dlp)
-	Type() string
-	// Collect gathers events and sends them to the output channel
-	Collect(ctx context.Context, output chan<- *RawEvent) error
-	// Enabled returns whether this collector is enabled
-	Enabled() bool
+// runningCollector tracks the bookkeeping needed to stop a single collector's
+// goroutine and drain its dedicated channel without disturbing the others.
+type runningCollector struct {
+	collector Collector
+	cancel    context.CancelFunc
+	events    chan *RawEvent
 }
 
-// NewManager creates a new ingestion manager
+// Manager orchestrates data ingestion from all configured sources
+type Manager struct {
+	config       config.IngestionConfig
+	logger       *zap.Logger
+	slog         *slog.Logger
+	checkpointer checkpoint.Checkpointer
+	running      map[string]*runningCollector
+	output       Sink
+}
+
+// NewManager creates a new ingestion manager, instantiating every collector
+// that is both compiled into this binary and enabled in cfg via the
+// package-level registry (see registry.go). If the configured checkpoint
+// backend fails to initialize (e.g. a bad BoltDB path), it falls back to an
+// in-memory one rather than failing startup - collectors still run, they
+// just won't survive a restart without duplicating or losing events.
 func NewManager(cfg config.IngestionConfig, logger *zap.Logger) *Manager {
-	m := &Manager{
-		config:     cfg,
-		logger:     logger,
-		collectors: make([]Collector, 0),
+	checkpointer, err := checkpoint.New(cfg.Checkpoint, logger)
+	if err != nil {
+		logger.Error("Failed to initialize checkpoint backend, falling back to in-memory", zap.Error(err))
+		checkpointer = checkpoint.NewMemoryCheckpointer()
 	}
 
-	// Initialize EDR collectors
-	if cfg.EDR.CrowdStrike.Enabled {
-		m.collectors = append(m.collectors, NewCrowdStrikeCollector(cfg.EDR.CrowdStrike, logger))
-	}
-	if cfg.EDR.SentinelOne.Enabled {
-		m.collectors = append(m.collectors, NewSentinelOneCollector(cfg.EDR.SentinelOne, logger))
+	m := &Manager{
+		config:       cfg,
+		logger:       logger,
+		slog:         observability.NewSlogLogger(logger),
+		checkpointer: checkpointer,
+		running:      make(map[string]*runningCollector),
 	}
-	if cfg.EDR.Defender.Enabled {
-		m.collectors = append(m.collectors, NewDefenderCollector(cfg.EDR.Defender, logger))
+
+	collectors, err := NewCollectorsFromConfig(cfg, logger, checkpointer)
+	if err != nil {
+		logger.Error("Failed to construct collectors from config", zap.Error(err))
+		return m
 	}
 
-	// Initialize SIEM collectors
-	if cfg.SIEM.Splunk.Enabled {
-		m.collectors = append(m.collectors, NewSplunkCollector(cfg.SIEM.Splunk, logger))
+	for _, c := range collectors {
+		m.running[c.Name()] = &runningCollector{collector: c}
 	}
-	if cfg.SIEM.Elasticsearch.Enabled {
-		m.collectors = append(m.collectors, NewElasticsearchCollector(cfg.SIEM.Elasticsearch, logger))
+
+	logger.Info("Ingestion manager initialized",
+		zap.Int("collectors", len(m.running)),
+		zap.Strings("registered", RegisteredNames()),
+	)
+
+	return m
+}
+
+// Start begins data collection from all enabled sources. output is typically
+// a ChannelSink wrapping the pipeline's event channel, but callers that need
+// their own backpressure policy (e.g. a bounded worker pool wanting
+// drop-oldest instead of block) can pass any Sink implementation.
+func (m *Manager) Start(ctx context.Context, output Sink) {
+	m.output = output
+
+	m.logger.Info("Starting ingestion from all sources")
+
+	m.initCollectors(ctx)
+
+	for name, rc := range m.running {
+		m.startCollector(ctx, name, rc)
 	}
 
-	// Initialize Cloud collectors
-	if cfg.Cloud.AWS.Enabled {
-		m.collectors = append(m.collectors, NewAWSCloudTrailCollector(cfg.Cloud.AWS, logger))
+	go m.reportCursorLag(ctx)
+}
+
+// initCollectors calls Init on every constructed collector, sequentially
+// and each under its own collectorLifecycleTimeout, before any of them
+// start collecting. Following the provider-lifecycle pattern this mirrors,
+// a collector whose Init fails (bad credentials, vendor API unreachable) is
+// skipped - startCollector won't start it, and its failure is visible via
+// GetCollectorStatus - rather than aborting startup for every other source.
+func (m *Manager) initCollectors(ctx context.Context) {
+	for name, rc := range m.running {
+		initCtx, cancel := context.WithTimeout(ctx, collectorLifecycleTimeout)
+		err := rc.collector.Init(initCtx)
+		cancel()
+		if err != nil {
+			m.logger.Warn("Collector Init failed, skipping", zap.String("name", name), zap.Error(err))
+			continue
+		}
+		m.logger.Info("Collector initialized", zap.String("name", name))
 	}
-	if cfg.Cloud.Azure.Enabled {
-		m.collectors = append(m.collectors, NewAzureActivityCollector(cfg.Cloud.Azure, logger))
+}
+
+// stopCollector cancels the collector's Collect loop, then gives it
+// collectorLifecycleTimeout to drain in-flight requests via Stop.
+func (m *Manager) stopCollector(ctx context.Context, name string, rc *runningCollector) {
+	if rc.cancel != nil {
+		rc.cancel()
 	}
-	if cfg.Cloud.GCP.Enabled {
-		m.collectors = append(m.collectors, NewGCPAuditCollector(cfg.Cloud.GCP, logger))
+	stopCtx, cancel := context.WithTimeout(ctx, collectorLifecycleTimeout)
+	defer cancel()
+	if err := rc.collector.Stop(stopCtx); err != nil {
+		m.logger.Warn("Collector Stop failed", zap.String("name", name), zap.Error(err))
 	}
+}
 
-	// Initialize DLP collectors (COTS integrations)
-	if cfg.DLP.DigitalGuardian.Enabled {
-		m.collectors = append(m.collectors, NewDigitalGuardianCollector(cfg.DLP.DigitalGuardian, logger))
-	}
-	if cfg.DLP.Proofpoint.Enabled {
-		m.collectors = append(m.collectors, NewProofpointCollector(cfg.DLP.Proofpoint, logger))
+// reportCursorLag periodically refreshes the cursor_lag_seconds gauge for
+// every running collector, so a collector stuck mid-poll (rather than
+// crashed, which Prometheus would catch via absence of CollectorStatus)
+// shows up before its backlog becomes a problem.
+func (m *Manager) reportCursorLag(ctx context.Context) {
+	ticker := time.NewTicker(cursorLagReportInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			for name, rc := range m.running {
+				if r, ok := rc.collector.(cursorLagReporter); ok {
+					cursorLagSeconds.WithLabelValues(name).Set(r.CursorLag().Seconds())
+				}
+			}
+		}
 	}
-	if cfg.DLP.Purview.Enabled {
-		m.collectors = append(m.collectors, NewPurviewCollector(cfg.DLP.Purview, logger))
+}
+
+// startCollector launches a single collector's Collect loop under a
+// cancellable child context and fans its private channel into the shared
+// output channel, so the collector can be stopped independently by
+// cancelling only its own context and closing only its own channel.
+func (m *Manager) startCollector(ctx context.Context, name string, rc *runningCollector) {
+	if !rc.collector.Enabled() {
+		return
 	}
-	if cfg.DLP.Netskope.Enabled {
-		m.collectors = append(m.collectors, NewNetskopeCollector(cfg.DLP.Netskope, logger))
+	if sr, ok := rc.collector.(statusReporter); ok {
+		if healthy, lastErr, _ := sr.HealthStatus(); !healthy {
+			m.logger.Warn("Skipping collector with failed Init", zap.String("name", name), zap.String("error", lastErr))
+			return
+		}
 	}
 
-	logger.Info("Ingestion manager initialized",
-		zap.Int("collectors", len(m.collectors)),
-	)
+	collectorCtx, cancel := context.WithCancel(ctx)
+	rc.cancel = cancel
+	rc.events = make(chan *RawEvent, 100)
 
-	return m
-}
-
-// Start begins data collection from all enabled sources
-func (m *Manager) Start(ctx context.Context, output chan<- *RawEvent) {
-	m.logger.Info("Starting ingestion from all sources")
+	safe.Go(m.logger, "ingestion.forward."+name, func() {
+		for event := range rc.events {
+			m.forward(ctx, name, event)
+		}
+	})
 
-	for _, collector := range m.collectors {
-		if collector.Enabled() {
-			go func(c Collector) {
-				m.logger.Info("Starting collector",
+	go func(c Collector, events chan *RawEvent) {
+		m.logger.Info("Starting collector",
+			zap.String("name", c.Name()),
+			zap.String("type", c.Type()),
+		)
+		// Loop recovers a panic from a malformed vendor payload instead of
+		// crashing the process, and re-invokes Collect with exponential
+		// backoff rather than leaving the collector dead until restart.
+		safe.Loop(collectorCtx, m.logger, "ingestion.collector."+name, func(ctx context.Context) {
+			if err := c.Collect(ctx, NewChannelSink(events)); err != nil {
+				m.logger.Error("Collector error",
 					zap.String("name", c.Name()),
-					zap.String("type", c.Type()),
+					zap.Error(err),
 				)
-				if err := c.Collect(ctx, output); err != nil {
-					m.logger.Error("Collector error",
-						zap.String("name", c.Name()),
-						zap.Error(err),
-					)
+			}
+		})
+		close(events)
+	}(rc.collector, rc.events)
+
+	m.logger.Debug("Collector discovery started", zap.String("name", name))
+}
+
+// forward hands a single collected event to the pipeline under a
+// "ingestion.collect" span joined to the trace CreateRawEvent started for
+// it, then logs its completion with the standardized keys so the event can
+// be found by trace_id, event id, or collector name alike.
+func (m *Manager) forward(ctx context.Context, collectorName string, event *RawEvent) {
+	spanCtx, span := otel.Tracer(tracerName).Start(ContextWithTrace(ctx, event), "ingestion.collect", trace.WithAttributes(
+		attribute.String(observability.KeyCollectorName, collectorName),
+		attribute.String(observability.KeyEventID, event.ID()),
+		attribute.String(observability.KeyEventSource, SourceName(event)),
+	))
+	defer span.End()
+
+	if err := m.output.Send(spanCtx, event); err != nil {
+		return
+	}
+	m.slog.InfoContext(spanCtx, "Event collected",
+		observability.KeyCollectorName, collectorName,
+		observability.KeyEventID, event.ID(),
+	)
+}
+
+// StartDiscovery periodically re-reads the config file and applies any
+// changes via Reload, so editing configs/config.yaml (enabling/disabling a
+// source) takes effect without a full process restart.
+func (m *Manager) StartDiscovery(ctx context.Context, configPath string, interval time.Duration) {
+	if interval <= 0 {
+		interval = DiscoveryInterval
+	}
+
+	ticker := time.NewTicker(interval)
+	go func() {
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				cfg, err := config.Load(configPath)
+				if err != nil {
+					m.logger.Warn("Discovery: failed to reload config", zap.Error(err))
+					continue
 				}
-			}(collector)
+				m.Reload(ctx, cfg.Ingestion)
+			}
 		}
+	}()
+}
+
+// Reload diffs the running collectors against cfg by name and type, without
+// restarting collectors that are unaffected. A removed collector, or one
+// whose type changed (treated as a full replace), is stopped via
+// stopCollector so in-flight requests drain; a newly enabled or replaced
+// collector goes through Init - and is skipped, not started, if Init fails -
+// exactly as it would at startup. This lets configs/config.yaml edits be
+// applied via SIGHUP or the /api/v1/sources endpoints without a process
+// restart.
+func (m *Manager) Reload(ctx context.Context, cfg config.IngestionConfig) {
+	collectors, err := NewCollectorsFromConfig(cfg, m.logger, m.checkpointer)
+	if err != nil {
+		m.logger.Error("Reload: failed to construct collectors", zap.Error(err))
+		return
+	}
+
+	desired := make(map[string]Collector, len(collectors))
+	for _, c := range collectors {
+		desired[c.Name()] = c
 	}
+
+	// Stop collectors that are no longer enabled/compiled in, or whose
+	// type changed underneath the same name.
+	for name, rc := range m.running {
+		c, keep := desired[name]
+		if keep && c.Type() == rc.collector.Type() {
+			continue
+		}
+		m.stopCollector(ctx, name, rc)
+		delete(m.running, name)
+		m.logger.Info("Collector stopped by reload", zap.String("name", name))
+	}
+
+	// Start collectors that are newly enabled or replaced.
+	for name, c := range desired {
+		if _, exists := m.running[name]; exists {
+			continue
+		}
+		rc := &runningCollector{collector: c}
+		m.running[name] = rc
+
+		initCtx, cancel := context.WithTimeout(ctx, collectorLifecycleTimeout)
+		err := c.Init(initCtx)
+		cancel()
+		if err != nil {
+			m.logger.Warn("Reload: collector Init failed, skipping", zap.String("name", name), zap.Error(err))
+			continue
+		}
+
+		m.startCollector(ctx, name, rc)
+		m.logger.Info("Collector started by reload", zap.String("name", name))
+	}
+
+	m.config = cfg
 }
 
 // GetCollectorStatus returns the status of all collectors
 func (m *Manager) GetCollectorStatus() []CollectorStatus {
-	statuses := make([]CollectorStatus, 0, len(m.collectors))
-	for _, c := range m.collectors {
-		statuses = append(statuses, CollectorStatus{
-			Name:    c.Name(),
-			Type:    c.Type(),
-			Enabled: c.Enabled(),
-		})
+	statuses := make([]CollectorStatus, 0, len(m.running))
+	for _, rc := range m.running {
+		status := CollectorStatus{
+			Name:    rc.collector.Name(),
+			Type:    rc.collector.Type(),
+			Enabled: rc.collector.Enabled(),
+		}
+		if sr, ok := rc.collector.(statusReporter); ok {
+			status.Healthy, status.LastError, status.InitializedAt = sr.HealthStatus()
+		}
+		statuses = append(statuses, status)
 	}
 	return statuses
 }
 
-// CollectorStatus represents the status of a collector
-type CollectorStatus struct {
-	Name    string `json:"name"`
-	Type    string `json:"type"`
-	Enabled bool   `json:"enabled"`
+// Rewind forces the named collector's cursor to the position it would have
+// been at at time t, for backfills after discovering a gap or a mapping
+// bug. It only works for collectors that implement Rewindable; others
+// return an error naming the collector rather than silently doing nothing.
+func (m *Manager) Rewind(ctx context.Context, name string, t time.Time) error {
+	rc, ok := m.running[name]
+	if !ok {
+		return fmt.Errorf("rewind: unknown collector %q", name)
+	}
+
+	rw, ok := rc.collector.(Rewindable)
+	if !ok {
+		return fmt.Errorf("rewind: collector %q does not support time-based rewind", name)
+	}
+
+	if err := m.checkpointer.Save(ctx, name, rw.CursorAtTime(t)); err != nil {
+		return fmt.Errorf("rewind: saving cursor for %q: %w", name, err)
+	}
+
+	m.logger.Info("Collector rewound", zap.String("name", name), zap.Time("to", t))
+	return nil
 }
 
+// Close stops every running collector (cancelling Collect and giving it
+// collectorLifecycleTimeout to drain via Stop) and then releases the
+// checkpoint backend's resources.
+func (m *Manager) Close() error {
+	ctx := context.Background()
+	for name, rc := range m.running {
+		m.stopCollector(ctx, name, rc)
+	}
+	return m.checkpointer.Close()
+}
@@ -0,0 +1,21 @@
+package ingestion
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// cursorLagSeconds tracks how long it's been since each collector last
+// advanced its checkpoint - i.e. how far back a restart right now would
+// resume from. A climbing value for one collector usually means it's
+// stuck or its vendor API is down, long before anyone notices missing
+// events downstream.
+var cursorLagSeconds = promauto.NewGaugeVec(
+	prometheus.GaugeOpts{
+		Namespace: "threat_telemetry_hub",
+		Subsystem: "ingestion",
+		Name:      "cursor_lag_seconds",
+		Help:      "Seconds since the collector's checkpoint cursor was last advanced.",
+	},
+	[]string{"collector"},
+)
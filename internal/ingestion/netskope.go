@@ -0,0 +1,109 @@
+//go:build !no_netskope
+
+package ingestion
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/lvonguyen/threat-telemetry-hub/internal/checkpoint"
+	"github.com/lvonguyen/threat-telemetry-hub/internal/config"
+)
+
+func init() {
+	Register("netskope", func(cfg config.IngestionConfig, logger *zap.Logger, checkpointer checkpoint.Checkpointer) (Collector, bool, error) {
+		c := cfg.DLP.Netskope
+		if !c.Enabled {
+			return nil, false, nil
+		}
+		return NewNetskopeCollector(c, logger, checkpointer), true, nil
+	})
+}
+
+// netskopeCursor is this collector's cursor encoding: the Netskope
+// events-data API's own opaque pagination "operation" token, scoped to the
+// time window it was issued in.
+type netskopeCursor struct {
+	WindowStart time.Time `json:"window_start"`
+	Operation   string    `json:"operation"`
+}
+
+// NetskopeCollector collects events from Netskope
+type NetskopeCollector struct {
+	BaseCollector
+	config config.NetskopeConfig
+}
+
+func NewNetskopeCollector(cfg config.NetskopeConfig, logger *zap.Logger, checkpointer checkpoint.Checkpointer) *NetskopeCollector {
+	return &NetskopeCollector{
+		BaseCollector: BaseCollector{
+			name: "netskope", cType: "dlp", enabled: cfg.Enabled, logger: logger,
+			checkpointer: checkpointer,
+			// Netskope's events-data API only guarantees at-least-once
+			// delivery within a poll window; polling on a fixed interval
+			// means each window deliberately overlaps the previous one
+			// so nothing near the boundary is missed, which in turn
+			// means the same event ID can arrive twice.
+			dedup: checkpoint.NewBloomFilter(100_000, 0.01),
+		},
+		config: cfg,
+	}
+}
+
+func (c *NetskopeCollector) Init(ctx context.Context) error {
+	// TODO: Validate the configured API token against the tenant's
+	// events-data API before Collect starts polling it.
+	c.logger.Info("Netskope collector initialized (stub)")
+	c.markInitialized(nil)
+	return nil
+}
+
+func (c *NetskopeCollector) Collect(ctx context.Context, output *ChannelSink) error {
+	cursor, err := c.loadNetskopeCursor(ctx)
+	if err != nil {
+		c.logger.Warn("Failed to load checkpoint, starting from now", zap.Error(err))
+		cursor = netskopeCursor{WindowStart: time.Now().UTC()}
+	}
+
+	// TODO: Implement Netskope integration
+	// - Authenticate using API token
+	// - Poll /api/v2/events/data/dlp (and CASB/UEBA equivalents) for
+	//   events whose window overlaps cursor.WindowStart, continuing from
+	//   cursor.Operation if the last poll was paginated
+	// - For each event: check SeenRecently(event ID) and skip if true,
+	//   otherwise build and send the RawEvent, call MarkSeen(id), and
+	//   only then save a new netskopeCursor via saveNetskopeCursor - in
+	//   that order, so a crash between send and cursor-save re-delivers
+	//   the event (caught by the dedup filter) instead of losing it
+	c.logger.Info("Netskope collector started (stub)", zap.Time("window_start", cursor.WindowStart))
+	<-ctx.Done()
+	return nil
+}
+
+// CursorAtTime implements Rewindable, so Manager.Rewind can force this
+// collector to resume polling from an operator-supplied timestamp.
+func (c *NetskopeCollector) CursorAtTime(t time.Time) []byte {
+	cur, _ := json.Marshal(netskopeCursor{WindowStart: t})
+	return cur
+}
+
+func (c *NetskopeCollector) loadNetskopeCursor(ctx context.Context) (netskopeCursor, error) {
+	var cur netskopeCursor
+	raw, err := c.LoadCursor(ctx)
+	if err != nil || raw == nil {
+		return cur, err
+	}
+	err = json.Unmarshal(raw, &cur)
+	return cur, err
+}
+
+func (c *NetskopeCollector) saveNetskopeCursor(ctx context.Context, cur netskopeCursor) error {
+	raw, err := json.Marshal(cur)
+	if err != nil {
+		return err
+	}
+	return c.SaveCursor(ctx, raw)
+}
@@ -0,0 +1,45 @@
+//go:build !no_proofpoint
+
+package ingestion
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+
+	"github.com/lvonguyen/threat-telemetry-hub/internal/checkpoint"
+	"github.com/lvonguyen/threat-telemetry-hub/internal/config"
+)
+
+func init() {
+	Register("proofpoint", func(cfg config.IngestionConfig, logger *zap.Logger, checkpointer checkpoint.Checkpointer) (Collector, bool, error) {
+		c := cfg.DLP.Proofpoint
+		if !c.Enabled {
+			return nil, false, nil
+		}
+		return NewProofpointCollector(c, logger, checkpointer), true, nil
+	})
+}
+
+// ProofpointCollector collects events from Proofpoint
+type ProofpointCollector struct {
+	BaseCollector
+	config config.ProofpointConfig
+}
+
+func NewProofpointCollector(cfg config.ProofpointConfig, logger *zap.Logger, checkpointer checkpoint.Checkpointer) *ProofpointCollector {
+	return &ProofpointCollector{
+		BaseCollector: BaseCollector{name: "proofpoint", cType: "dlp", enabled: cfg.Enabled, logger: logger, checkpointer: checkpointer},
+		config:        cfg,
+	}
+}
+
+func (c *ProofpointCollector) Collect(ctx context.Context, output *ChannelSink) error {
+	// TODO: Implement Proofpoint integration
+	// - Can use API polling or webhook receiver
+	// - Get email DLP events and threat intelligence
+	// - Normalize to common DLP event schema
+	c.logger.Info("Proofpoint collector started (stub)")
+	<-ctx.Done()
+	return nil
+}
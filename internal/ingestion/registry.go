@@ -0,0 +1,106 @@
+// Package ingestion handles data ingestion from various security sources
+package ingestion
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+
+	"go.uber.org/zap"
+
+	"github.com/lvonguyen/threat-telemetry-hub/internal/checkpoint"
+	"github.com/lvonguyen/threat-telemetry-hub/internal/config"
+)
+
+// Collector defines the interface for data collectors. Concrete collectors
+// self-register a Factory under their source name via init() so that
+// NewCollectorsFromConfig only has to know about the registry, not about
+// any specific vendor package - adding a new source means dropping in a new
+// file and importing it, not editing the manager.
+type Collector interface {
+	// Name returns the collector name, e.g. "crowdstrike"
+	Name() string
+	// Type returns the collector type (edr, siem, cloud, dlp, ips)
+	Type() string
+	// Init performs any one-time authenticated setup (OAuth token exchange,
+	// session cookies, ADC discovery) before Collect is called. Manager
+	// calls it once per collector, under its own timeout, and skips (does
+	// not start) any collector whose Init fails.
+	Init(ctx context.Context) error
+	// Collect gathers events and sends them to output. It should prefer
+	// output.TrySend over output.Send where the vendor API allows the
+	// collector to pace itself off the result, rather than blocking
+	// indefinitely on a full queue.
+	Collect(ctx context.Context, output *ChannelSink) error
+	// Stop drains any in-flight requests and releases resources acquired
+	// by Init. Manager calls it once per collector during shutdown and
+	// reload, after Collect's context has been cancelled.
+	Stop(ctx context.Context) error
+	// Enabled returns whether this collector is enabled
+	Enabled() bool
+}
+
+// Factory builds a Collector from the full ingestion config and returns
+// (collector, enabled). It returns enabled=false without constructing
+// anything when its section of cfg is disabled, so NewCollectorsFromConfig
+// doesn't need to know which config field belongs to which collector.
+// checkpointer is handed to every collector regardless of whether it uses
+// cursor tracking, so adding that support later doesn't change the
+// Factory signature again.
+type Factory func(cfg config.IngestionConfig, logger *zap.Logger, checkpointer checkpoint.Checkpointer) (collector Collector, enabled bool, err error)
+
+// registry is the global catalog of collector factories. Collectors compiled
+// into the binary (subject to build tags) register themselves here; building
+// with `-tags no_crowdstrike`, for example, omits that file so its init()
+// never runs and it never appears in the registry.
+var registry = struct {
+	mu        sync.RWMutex
+	factories map[string]Factory
+}{factories: make(map[string]Factory)}
+
+// Register adds a collector factory under name. Called from collector
+// package-level init() functions; panics on duplicate registration since
+// that indicates two collector files claiming the same source name.
+func Register(name string, factory Factory) {
+	registry.mu.Lock()
+	defer registry.mu.Unlock()
+	if _, exists := registry.factories[name]; exists {
+		panic(fmt.Sprintf("ingestion: collector %q already registered", name))
+	}
+	registry.factories[name] = factory
+}
+
+// RegisteredNames returns the sorted names of every collector compiled into
+// this binary, regardless of whether it is currently enabled in config.
+func RegisteredNames() []string {
+	registry.mu.RLock()
+	defer registry.mu.RUnlock()
+	names := make([]string, 0, len(registry.factories))
+	for name := range registry.factories {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// NewCollectorsFromConfig instantiates only the collectors that are both
+// compiled into the binary and enabled in cfg.
+func NewCollectorsFromConfig(cfg config.IngestionConfig, logger *zap.Logger, checkpointer checkpoint.Checkpointer) ([]Collector, error) {
+	registry.mu.RLock()
+	defer registry.mu.RUnlock()
+
+	collectors := make([]Collector, 0, len(registry.factories))
+	for name, factory := range registry.factories {
+		c, enabled, err := factory(cfg, logger, checkpointer)
+		if err != nil {
+			return nil, fmt.Errorf("constructing collector %q: %w", name, err)
+		}
+		if !enabled {
+			continue
+		}
+		collectors = append(collectors, c)
+	}
+
+	return collectors, nil
+}
@@ -0,0 +1,45 @@
+//go:build !no_sentinelone
+
+package ingestion
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+
+	"github.com/lvonguyen/threat-telemetry-hub/internal/checkpoint"
+	"github.com/lvonguyen/threat-telemetry-hub/internal/config"
+)
+
+func init() {
+	Register("sentinelone", func(cfg config.IngestionConfig, logger *zap.Logger, checkpointer checkpoint.Checkpointer) (Collector, bool, error) {
+		c := cfg.EDR.SentinelOne
+		if !c.Enabled {
+			return nil, false, nil
+		}
+		return NewSentinelOneCollector(c, logger, checkpointer), true, nil
+	})
+}
+
+// SentinelOneCollector collects events from SentinelOne
+type SentinelOneCollector struct {
+	BaseCollector
+	config config.SentinelOneConfig
+}
+
+func NewSentinelOneCollector(cfg config.SentinelOneConfig, logger *zap.Logger, checkpointer checkpoint.Checkpointer) *SentinelOneCollector {
+	return &SentinelOneCollector{
+		BaseCollector: BaseCollector{name: "sentinelone", cType: "edr", enabled: cfg.Enabled, logger: logger, checkpointer: checkpointer},
+		config:        cfg,
+	}
+}
+
+func (c *SentinelOneCollector) Collect(ctx context.Context, output *ChannelSink) error {
+	// TODO: Implement SentinelOne API integration
+	// - Authenticate using API token
+	// - Poll /web/api/v2.1/threats for new threats
+	// - Get activity logs via /web/api/v2.1/activities
+	c.logger.Info("SentinelOne collector started (stub)")
+	<-ctx.Done()
+	return nil
+}
@@ -0,0 +1,47 @@
+package ingestion
+
+import "context"
+
+// Sink accepts events collected or forwarded within the ingestion pipeline.
+// Manager.forward sends through one to hand events off to the processing
+// pipeline, which lets the caller (cmd/hub.Pipeline) enforce its own
+// backpressure policy - block, drop-oldest, drop-new - without ingestion
+// needing to know anything about that policy.
+type Sink interface {
+	Send(ctx context.Context, event *RawEvent) error
+}
+
+// ChannelSink is the default Sink: a thin wrapper around a channel. It's
+// also what every Collector.Collect receives as its output, so a collector
+// can choose TrySend over Send when it would rather back off its own poll
+// rate than block on a full per-collector queue.
+type ChannelSink struct {
+	ch chan<- *RawEvent
+}
+
+// NewChannelSink wraps ch for use as a Sink or as a Collector's output.
+func NewChannelSink(ch chan<- *RawEvent) *ChannelSink {
+	return &ChannelSink{ch: ch}
+}
+
+// Send implements Sink, blocking until event is delivered or ctx is done.
+func (s *ChannelSink) Send(ctx context.Context, event *RawEvent) error {
+	select {
+	case s.ch <- event:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// TrySend delivers event without blocking, reporting false if the channel
+// was full so the caller can apply its own backpressure (e.g. slow down its
+// own polling) instead of stalling indefinitely.
+func (s *ChannelSink) TrySend(event *RawEvent) bool {
+	select {
+	case s.ch <- event:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,54 @@
+//go:build !no_splunk
+
+package ingestion
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+
+	"github.com/lvonguyen/threat-telemetry-hub/internal/checkpoint"
+	"github.com/lvonguyen/threat-telemetry-hub/internal/config"
+)
+
+func init() {
+	Register("splunk", func(cfg config.IngestionConfig, logger *zap.Logger, checkpointer checkpoint.Checkpointer) (Collector, bool, error) {
+		c := cfg.SIEM.Splunk
+		if !c.Enabled {
+			return nil, false, nil
+		}
+		return NewSplunkCollector(c, logger, checkpointer), true, nil
+	})
+}
+
+// SplunkCollector collects events from Splunk
+type SplunkCollector struct {
+	BaseCollector
+	config config.SplunkConfig
+}
+
+func NewSplunkCollector(cfg config.SplunkConfig, logger *zap.Logger, checkpointer checkpoint.Checkpointer) *SplunkCollector {
+	return &SplunkCollector{
+		BaseCollector: BaseCollector{name: "splunk", cType: "siem", enabled: cfg.Enabled, logger: logger, checkpointer: checkpointer},
+		config:        cfg,
+	}
+}
+
+func (c *SplunkCollector) Init(ctx context.Context) error {
+	// TODO: Authenticate against the configured Splunk REST endpoint
+	// (username/password or token) and cache the resulting session key/
+	// cookie for reuse across polls until it expires.
+	c.logger.Info("Splunk collector initialized (stub)")
+	c.markInitialized(nil)
+	return nil
+}
+
+func (c *SplunkCollector) Collect(ctx context.Context, output *ChannelSink) error {
+	// TODO: Implement Splunk integration
+	// - Use Splunk REST API or HEC
+	// - Run saved searches or real-time searches
+	// - Stream results to output channel
+	c.logger.Info("Splunk collector started (stub)")
+	<-ctx.Done()
+	return nil
+}
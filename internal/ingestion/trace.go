@@ -0,0 +1,54 @@
+// Package ingestion handles data ingestion from various security sources
+package ingestion
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel"
+)
+
+// tracerName identifies spans started by this package in OpenTelemetry
+// backends; by convention it's the package's own import path.
+const tracerName = "github.com/lvonguyen/threat-telemetry-hub/internal/ingestion"
+
+// eventCarrier adapts a RawEvent's CloudEvents extension attributes to
+// OpenTelemetry's TextMapCarrier, so trace context can ride along inside
+// the envelope itself across goroutine and channel boundaries - the same
+// role HTTP headers or Kafka record headers play for other transports.
+type eventCarrier struct{ event *RawEvent }
+
+func (c eventCarrier) Get(key string) string {
+	v, _ := c.event.Extensions()[key].(string)
+	return v
+}
+
+func (c eventCarrier) Set(key, value string) {
+	c.event.SetExtension(key, value)
+}
+
+func (c eventCarrier) Keys() []string {
+	exts := c.event.Extensions()
+	keys := make([]string, 0, len(exts))
+	for k := range exts {
+		keys = append(keys, k)
+	}
+	return keys
+}
+
+// injectTraceContext writes ctx's trace context into e's CloudEvents
+// extension attributes, so a later call to ContextWithTrace on e rejoins
+// the same trace.
+func injectTraceContext(ctx context.Context, e *RawEvent) {
+	otel.GetTextMapPropagator().Inject(ctx, eventCarrier{event: e})
+}
+
+// ContextWithTrace returns ctx carrying the trace context embedded in e's
+// CloudEvents extension attributes by CreateRawEvent, so a span started
+// from the returned context joins the same trace as the span that
+// ingested e - letting a single detection be traced from the vendor API
+// call, through normalization and enrichment, to AI analysis and the
+// detection sink, even though each of those stages runs in its own
+// goroutine with no shared context.
+func ContextWithTrace(ctx context.Context, e *RawEvent) context.Context {
+	return otel.GetTextMapPropagator().Extract(ctx, eventCarrier{event: e})
+}
@@ -0,0 +1,106 @@
+// Package ingestion handles data ingestion from various security sources
+package ingestion
+
+import (
+	"context"
+	"net/http"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	"go.uber.org/zap"
+)
+
+// Transport is a pluggable source of CloudEvents for push-mode or
+// broker-backed sources that don't fit the poll-based Collector model
+// (webhooks, Kafka topics, Pub/Sub subscriptions).
+type Transport interface {
+	Name() string
+	Start(ctx context.Context, output chan<- *RawEvent) error
+}
+
+// HTTPReceiver exposes a cehttp.Handler that accepts CloudEvents pushed
+// directly by a vendor (e.g. Proofpoint's webhook, or a cloud provider's
+// audit-log forwarder) and forwards them onto output. Mount it alongside
+// the poll-based collectors with router.POST("/api/v1/events", receiver.Handler()).
+type HTTPReceiver struct {
+	logger *zap.Logger
+	client cloudevents.Client
+	output chan<- *RawEvent
+}
+
+// NewHTTPReceiver builds an HTTPReceiver bound to output. Events received
+// over HTTP are pushed onto output the same way a polling Collector would.
+func NewHTTPReceiver(logger *zap.Logger, output chan<- *RawEvent) (*HTTPReceiver, error) {
+	return &HTTPReceiver{logger: logger, output: output}, nil
+}
+
+// Handler returns an http.Handler suitable for mounting on any router; it
+// decodes the inbound request as a CloudEvents v1.0 message (structured or
+// binary content mode, per the spec) and forwards the result.
+func (r *HTTPReceiver) Handler() http.Handler {
+	h, err := cloudevents.NewHTTPReceiveHandler(context.Background(), newCEProtocol(), func(ctx context.Context, event cloudevents.Event) {
+		select {
+		case r.output <- &event:
+		case <-ctx.Done():
+		default:
+			r.logger.Warn("HTTP receiver: output channel full, dropping event",
+				zap.String("event_id", event.ID()),
+			)
+		}
+	})
+	if err != nil {
+		r.logger.Error("Failed to build CloudEvents HTTP handler", zap.Error(err))
+		return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			http.Error(w, "cloudevents receiver unavailable", http.StatusServiceUnavailable)
+		})
+	}
+	return h
+}
+
+func newCEProtocol() *cloudevents.HTTPProtocol {
+	p, _ := cloudevents.NewHTTP()
+	return p
+}
+
+// KafkaTransport consumes CloudEvents from a Kafka topic (binary content
+// mode) for SIEM re-ingestion pipelines.
+type KafkaTransport struct {
+	Brokers []string
+	Topic   string
+	GroupID string
+	logger  *zap.Logger
+}
+
+func NewKafkaTransport(brokers []string, topic, groupID string, logger *zap.Logger) *KafkaTransport {
+	return &KafkaTransport{Brokers: brokers, Topic: topic, GroupID: groupID, logger: logger}
+}
+
+func (t *KafkaTransport) Name() string { return "kafka" }
+
+func (t *KafkaTransport) Start(ctx context.Context, output chan<- *RawEvent) error {
+	// TODO: Implement using segmentio/kafka-go consumer group, decoding each
+	// message as a binary-mode CloudEvent (ce-* headers + raw payload body).
+	t.logger.Info("Kafka CloudEvents transport started (stub)", zap.String("topic", t.Topic))
+	<-ctx.Done()
+	return nil
+}
+
+// PubSubTransport consumes CloudEvents from a GCP Pub/Sub subscription.
+type PubSubTransport struct {
+	ProjectID      string
+	SubscriptionID string
+	logger         *zap.Logger
+}
+
+func NewPubSubTransport(projectID, subscriptionID string, logger *zap.Logger) *PubSubTransport {
+	return &PubSubTransport{ProjectID: projectID, SubscriptionID: subscriptionID, logger: logger}
+}
+
+func (t *PubSubTransport) Name() string { return "pubsub" }
+
+func (t *PubSubTransport) Start(ctx context.Context, output chan<- *RawEvent) error {
+	// TODO: Implement using cloud.google.com/go/pubsub, decoding each message
+	// as a structured-mode CloudEvent (application/cloudevents+json body).
+	t.logger.Info("Pub/Sub CloudEvents transport started (stub)", zap.String("subscription", t.SubscriptionID))
+	<-ctx.Done()
+	return nil
+}
@@ -2,13 +2,16 @@
 package normalization
 
 import (
+	"encoding/json"
 	"fmt"
 	"time"
 
 	"go.uber.org/zap"
 
 	"github.com/lvonguyen/threat-telemetry-hub/internal/config"
+	"github.com/lvonguyen/threat-telemetry-hub/internal/ecs"
 	"github.com/lvonguyen/threat-telemetry-hub/internal/ingestion"
+	"github.com/lvonguyen/threat-telemetry-hub/internal/ocsf"
 )
 
 // NormalizedEvent represents an event normalized to a standard schema
@@ -38,13 +41,52 @@ type SourceInfo struct {
 type Normalizer struct {
 	config config.NormalizationConfig
 	logger *zap.Logger
+	dlq    chan<- *ocsf.DLQEntry
 }
 
-// NewNormalizer creates a new normalizer
-func NewNormalizer(cfg config.NormalizationConfig, logger *zap.Logger) *Normalizer {
+// NewNormalizer creates a new normalizer. dlq receives raw events that fail
+// OCSF mapping or validation; pass nil to drop them (logged only).
+func NewNormalizer(cfg config.NormalizationConfig, logger *zap.Logger, dlq chan<- *ocsf.DLQEntry) *Normalizer {
 	return &Normalizer{
 		config: cfg,
 		logger: logger,
+		dlq:    dlq,
+	}
+}
+
+// reject routes a raw event that failed OCSF mapping/validation to the DLQ
+// channel, falling back to a log line if no DLQ is configured or it's full.
+func (n *Normalizer) reject(raw *ingestion.RawEvent, err error) {
+	entry := &ocsf.DLQEntry{Raw: raw, Err: err}
+	if n.dlq == nil {
+		n.logger.Warn("Rejected event has no DLQ configured, dropping", zap.Error(err))
+		return
+	}
+	select {
+	case n.dlq <- entry:
+	default:
+		n.logger.Warn("DLQ full, dropping rejected event", zap.Error(err))
+	}
+}
+
+// ocsfSeverityToScore maps an OCSF severity_id (0-6) onto this hub's 0-100
+// severity scale used across normalized/processed events.
+func ocsfSeverityToScore(severityID int) int {
+	switch severityID {
+	case 6:
+		return 100
+	case 5:
+		return 90
+	case 4:
+		return 70
+	case 3:
+		return 50
+	case 2:
+		return 30
+	case 1:
+		return 10
+	default:
+		return 0
 	}
 }
 
@@ -60,38 +102,58 @@ func (n *Normalizer) Normalize(raw *ingestion.RawEvent) (*NormalizedEvent, error
 	}
 }
 
-// normalizeToOCSF converts to Open Cybersecurity Schema Framework
+// normalizeToOCSF converts a raw event to an Open Cybersecurity Schema
+// Framework (https://schema.ocsf.io/) event, using the per-collector
+// ocsf.Mapper registered for its source. Events that can't be mapped or
+// fail required-field validation are routed to the DLQ instead of being
+// forwarded downstream.
 func (n *Normalizer) normalizeToOCSF(raw *ingestion.RawEvent) (*NormalizedEvent, error) {
-	// OCSF Schema Reference: https://schema.ocsf.io/
+	oe, err := ocsf.Map(raw)
+	if err != nil {
+		n.reject(raw, err)
+		return nil, fmt.Errorf("ocsf mapping: %w", err)
+	}
+	if err := ocsf.Validate(oe); err != nil {
+		n.reject(raw, err)
+		return nil, fmt.Errorf("ocsf validation: %w", err)
+	}
+
+	data, err := json.Marshal(oe)
+	if err != nil {
+		return nil, fmt.Errorf("marshaling ocsf event: %w", err)
+	}
+	var dataMap map[string]interface{}
+	if err := json.Unmarshal(data, &dataMap); err != nil {
+		return nil, fmt.Errorf("decoding ocsf event: %w", err)
+	}
 
 	event := &NormalizedEvent{
-		ID:            raw.ID,
-		Timestamp:     raw.Timestamp,
+		ID:            raw.ID(),
+		Timestamp:     raw.Time(),
 		Schema:        "ocsf",
 		SchemaVersion: "1.1.0",
 		Source:        n.getSourceInfo(raw),
-		Raw:           raw.Data,
-		Data:          make(map[string]interface{}),
+		Data:          dataMap,
+		Raw:           oe.Unmapped,
+		Severity:      ocsfSeverityToScore(oe.SeverityID),
 	}
 
-	// Map to OCSF categories based on source type
-	switch raw.SourceType {
-	case "edr":
+	switch oe.ClassUID {
+	case ocsf.ClassDetectionFinding:
 		event.Category = "security_finding"
 		event.Type = "detection"
-		n.mapEDRToOCSF(raw, event)
-	case "siem":
-		event.Category = "security_finding"
-		event.Type = "alert"
-		n.mapSIEMToOCSF(raw, event)
-	case "cloud":
+	case ocsf.ClassAPIActivity:
 		event.Category = "api_activity"
 		event.Type = "audit"
-		n.mapCloudToOCSF(raw, event)
-	case "dlp":
+	case ocsf.ClassNetworkActivity:
+		event.Category = "network_activity"
+		event.Type = "traffic"
+	case ocsf.ClassFileSystemActivity:
 		event.Category = "data_security"
 		event.Type = "policy_violation"
-		n.mapDLPToOCSF(raw, event)
+	case ocsf.ClassAuthentication:
+		event.Category = "iam"
+		event.Type = "authentication"
 	default:
 		event.Category = "unknown"
 		event.Type = "unknown"
@@ -100,44 +162,80 @@ func (n *Normalizer) normalizeToOCSF(raw *ingestion.RawEvent) (*NormalizedEvent,
 	return event, nil
 }
 
-// normalizeToECS converts to Elastic Common Schema
+// normalizeToECS converts a raw event to an Elastic Common Schema
+// (https://www.elastic.co/guide/en/ecs/current/index.html) event, using the
+// per-collector ecs.FieldMapper registered for its source. Unlike OCSF
+// normalization, a source with no registered FieldMapper isn't rejected to
+// the DLQ - it falls back to a coarse category/type by source type, since
+// ECS is this hub's secondary schema and not every collector has a
+// dedicated mapper yet.
 func (n *Normalizer) normalizeToECS(raw *ingestion.RawEvent) (*NormalizedEvent, error) {
-	// ECS Reference: https://www.elastic.co/guide/en/ecs/current/index.html
-
 	event := &NormalizedEvent{
-		ID:            raw.ID,
-		Timestamp:     raw.Timestamp,
+		ID:            raw.ID(),
+		Timestamp:     raw.Time(),
 		Schema:        "ecs",
 		SchemaVersion: "8.11.0",
 		Source:        n.getSourceInfo(raw),
-		Raw:           raw.Data,
+		Raw:           ingestion.DataMap(raw),
 		Data:          make(map[string]interface{}),
 	}
 
-	// Map to ECS categories based on source type
-	switch raw.SourceType {
+	ee, err := ecs.Map(raw)
+	if err != nil {
+		n.mapByCoarseSourceType(raw, event)
+		return event, nil
+	}
+
+	data, err := json.Marshal(ee)
+	if err != nil {
+		return nil, fmt.Errorf("marshaling ecs event: %w", err)
+	}
+	var dataMap map[string]interface{}
+	if err := json.Unmarshal(data, &dataMap); err != nil {
+		return nil, fmt.Errorf("decoding ecs event: %w", err)
+	}
+
+	event.Data = dataMap
+	event.Severity = ee.Event.Severity
+	if len(ee.Event.Category) > 0 {
+		event.Category = ee.Event.Category[0]
+	} else {
+		event.Category = "unknown"
+	}
+	if len(ee.Event.Type) > 0 {
+		event.Type = ee.Event.Type[0]
+	} else {
+		event.Type = "unknown"
+	}
+
+	return event, nil
+}
+
+// mapByCoarseSourceType sets event.Category/Type/Severity from the
+// collector's coarse source type (edr/siem/cloud/dlp), for sources without
+// a dedicated ecs.FieldMapper.
+func (n *Normalizer) mapByCoarseSourceType(raw *ingestion.RawEvent, event *NormalizedEvent) {
+	switch ingestion.SourceType(raw) {
 	case "edr":
 		event.Category = "malware"
 		event.Type = "detection"
-		n.mapEDRToECS(raw, event)
+		event.Severity = 70
 	case "siem":
 		event.Category = "threat"
 		event.Type = "indicator"
-		n.mapSIEMToECS(raw, event)
+		event.Severity = 50
 	case "cloud":
 		event.Category = "configuration"
 		event.Type = "change"
-		n.mapCloudToECS(raw, event)
+		event.Severity = 30
 	case "dlp":
 		event.Category = "file"
 		event.Type = "access"
-		n.mapDLPToECS(raw, event)
+		event.Severity = 60
 	default:
 		event.Category = "unknown"
 		event.Type = "unknown"
 	}
-
-	return event, nil
 }
 
 func (n *Normalizer) getSourceInfo(raw *ingestion.RawEvent) SourceInfo {
@@ -171,53 +269,12 @@ func (n *Normalizer) getSourceInfo(raw *ingestion.RawEvent) SourceInfo {
 		"netskope":         "Cloud Security",
 	}
 
+	name := ingestion.SourceName(raw)
 	return SourceInfo{
-		Name:    raw.Source,
-		Type:    raw.SourceType,
-		Vendor:  vendorMap[raw.Source],
-		Product: productMap[raw.Source],
+		Name:    name,
+		Type:    ingestion.SourceType(raw),
+		Vendor:  vendorMap[name],
+		Product: productMap[name],
 	}
 }
 
-// Schema-specific mapping functions (stubs)
-
-func (n *Normalizer) mapEDRToOCSF(raw *ingestion.RawEvent, event *NormalizedEvent) {
-	// TODO: Implement EDR to OCSF mapping
-	// Map fields like: detection_type, severity, process_name, file_hash, etc.
-	event.Severity = 70 // Default high for EDR
-}
-
-func (n *Normalizer) mapSIEMToOCSF(raw *ingestion.RawEvent, event *NormalizedEvent) {
-	// TODO: Implement SIEM to OCSF mapping
-	event.Severity = 50
-}
-
-func (n *Normalizer) mapCloudToOCSF(raw *ingestion.RawEvent, event *NormalizedEvent) {
-	// TODO: Implement Cloud to OCSF mapping
-	event.Severity = 30
-}
-
-func (n *Normalizer) mapDLPToOCSF(raw *ingestion.RawEvent, event *NormalizedEvent) {
-	// TODO: Implement DLP to OCSF mapping
-	event.Severity = 60
-}
-
-func (n *Normalizer) mapEDRToECS(raw *ingestion.RawEvent, event *NormalizedEvent) {
-	// TODO: Implement EDR to ECS mapping
-	event.Severity = 70
-}
-
-func (n *Normalizer) mapSIEMToECS(raw *ingestion.RawEvent, event *NormalizedEvent) {
-	// TODO: Implement SIEM to ECS mapping
-	event.Severity = 50
-}
-
-func (n *Normalizer) mapCloudToECS(raw *ingestion.RawEvent, event *NormalizedEvent) {
-	// TODO: Implement Cloud to ECS mapping
-	event.Severity = 30
-}
-
-func (n *Normalizer) mapDLPToECS(raw *ingestion.RawEvent, event *NormalizedEvent) {
-	// TODO: Implement DLP to ECS mapping
-	event.Severity = 60
-}
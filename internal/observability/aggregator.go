@@ -0,0 +1,219 @@
+package observability
+
+import (
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// Status is a component's point-in-time health state, modeled after the
+// OpenTelemetry Collector v2 health check extension's status events.
+type Status int
+
+const (
+	// StatusStarting is a component's initial state before it has
+	// reported anything, or while it's still coming up.
+	StatusStarting Status = iota
+	// StatusOK means the component is healthy.
+	StatusOK
+	// StatusRecoverableError means the component hit an error it's
+	// expected to recover from on its own (e.g. a transient network
+	// blip), short of the recovery window flapping its parent status.
+	StatusRecoverableError
+	// StatusPermanentError means the component hit an error it won't
+	// recover from without intervention.
+	StatusPermanentError
+	// StatusStopped means the component has shut down.
+	StatusStopped
+)
+
+// String implements fmt.Stringer, used for JSON serialization.
+func (s Status) String() string {
+	switch s {
+	case StatusStarting:
+		return "starting"
+	case StatusOK:
+		return "ok"
+	case StatusRecoverableError:
+		return "recoverable_error"
+	case StatusPermanentError:
+		return "permanent_error"
+	case StatusStopped:
+		return "stopped"
+	default:
+		return "unknown"
+	}
+}
+
+// severity orders Status from least to most severe, for worst-of-children
+// rollups - higher wins.
+func (s Status) severity() int {
+	switch s {
+	case StatusStopped, StatusPermanentError:
+		return 3
+	case StatusRecoverableError:
+		return 2
+	case StatusStarting:
+		return 1
+	default: // StatusOK
+		return 0
+	}
+}
+
+// snapshotUnhealthy reports whether a StatusSnapshot's rolled-up status
+// should fail a readiness probe (its Status field is already a Status's
+// String() form, so this compares against the same two terminal states).
+func snapshotUnhealthy(snap StatusSnapshot) bool {
+	return snap.Status == StatusPermanentError.String() || snap.Status == StatusStopped.String()
+}
+
+const defaultRecoveryWindow = 30 * time.Second
+
+// statusNode is one entry in the Aggregator's tree.
+type statusNode struct {
+	status   Status
+	err      error
+	since    time.Time
+	children map[string]*statusNode
+}
+
+func newStatusNode() *statusNode {
+	return &statusNode{status: StatusStarting, since: time.Now(), children: make(map[string]*statusNode)}
+}
+
+// Aggregator maintains a tree of component status keyed by dotted path
+// (e.g. "ingestion.crowdstrike", "ticketing.servicenow"), built from
+// ReportStatus events pushed by components rather than computed by
+// synchronously polling them on every request. Health handlers read
+// Snapshot in O(1); the tree is written only by ReportStatus calls, which
+// come from components pushing their own status and from Check reporting
+// every poll result.
+type Aggregator struct {
+	mu             sync.RWMutex
+	root           *statusNode
+	recoveryWindow time.Duration
+	logger         *zap.Logger
+}
+
+// NewAggregator creates an Aggregator. recoveryWindow bounds how long a
+// StatusRecoverableError is allowed to keep a node (and its ancestors)
+// degraded before it's treated as resolved back to StatusOK, so one
+// component's flapping retry loop doesn't permanently fail its parent
+// pipeline's rollup; zero uses a 30s default.
+func NewAggregator(recoveryWindow time.Duration, logger *zap.Logger) *Aggregator {
+	if recoveryWindow == 0 {
+		recoveryWindow = defaultRecoveryWindow
+	}
+	return &Aggregator{
+		root:           newStatusNode(),
+		recoveryWindow: recoveryWindow,
+		logger:         logger,
+	}
+}
+
+// ReportStatus records a status event for a dotted component path,
+// creating intermediate and leaf nodes as needed. Components call this
+// directly to push status changes as they happen; Check calls it on their
+// behalf for code still using the polling HealthCheck API.
+func (a *Aggregator) ReportStatus(path string, status Status, err error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	node := a.walk(path, true)
+	node.status = status
+	node.err = err
+	node.since = time.Now()
+
+	if a.logger != nil && (status == StatusRecoverableError || status == StatusPermanentError) {
+		a.logger.Warn("Component status change",
+			zap.String("component", path),
+			zap.String("status", status.String()),
+			zap.Error(err),
+		)
+	}
+}
+
+// walk returns the node at a dotted path, creating missing segments along
+// the way when create is true; otherwise it returns nil on the first
+// missing segment.
+func (a *Aggregator) walk(path string, create bool) *statusNode {
+	node := a.root
+	for _, seg := range strings.Split(path, ".") {
+		child, ok := node.children[seg]
+		if !ok {
+			if !create {
+				return nil
+			}
+			child = newStatusNode()
+			node.children[seg] = child
+		}
+		node = child
+	}
+	return node
+}
+
+// StatusSnapshot is a point-in-time, read-only view of one node in the
+// Aggregator tree, safe to serialize without holding the Aggregator's lock.
+type StatusSnapshot struct {
+	Status   string                    `json:"status"`
+	Error    string                    `json:"error,omitempty"`
+	Since    time.Time                 `json:"since"`
+	Children map[string]StatusSnapshot `json:"children,omitempty"`
+}
+
+// Snapshot returns the rolled-up status tree rooted at path ("" for the
+// overall hub), or false if path has no reported status. A node's rolled-up
+// status is the worst of its own last-reported status and all its
+// children's, with a StatusRecoverableError older than the recovery window
+// treated as resolved.
+func (a *Aggregator) Snapshot(path string) (StatusSnapshot, bool) {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	var node *statusNode
+	if path == "" {
+		node = a.root
+	} else {
+		node = a.walk(path, false)
+	}
+	if node == nil {
+		return StatusSnapshot{}, false
+	}
+	return a.snapshot(node, time.Now()), true
+}
+
+func (a *Aggregator) snapshot(node *statusNode, now time.Time) StatusSnapshot {
+	snap := StatusSnapshot{
+		Status: a.rollup(node, now).String(),
+		Since:  node.since,
+	}
+	if node.err != nil {
+		snap.Error = node.err.Error()
+	}
+	if len(node.children) > 0 {
+		snap.Children = make(map[string]StatusSnapshot, len(node.children))
+		for name, child := range node.children {
+			snap.Children[name] = a.snapshot(child, now)
+		}
+	}
+	return snap
+}
+
+// rollup computes a node's effective status: its own status (with a
+// recoverable error past the recovery window counted as StatusOK instead,
+// to avoid flapping), worsened by the worst of its children.
+func (a *Aggregator) rollup(node *statusNode, now time.Time) Status {
+	status := node.status
+	if status == StatusRecoverableError && now.Sub(node.since) > a.recoveryWindow {
+		status = StatusOK
+	}
+
+	for _, child := range node.children {
+		if childStatus := a.rollup(child, now); childStatus.severity() > status.severity() {
+			status = childStatus
+		}
+	}
+	return status
+}
@@ -0,0 +1,219 @@
+package observability
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/lvonguyen/threat-telemetry-hub/internal/config"
+)
+
+// PeerDiscovery resolves the addresses (host:port) of peer hub instances
+// for /health/cluster aggregation.
+type PeerDiscovery interface {
+	Peers(ctx context.Context) ([]string, error)
+}
+
+// NewPeerDiscovery builds the PeerDiscovery cfg.DiscoveryMode selects: a
+// static list, a DNS SRV record, or a Kubernetes headless Service (itself
+// just DNS - its A records are the pod IPs, so no client-go dependency is
+// needed).
+func NewPeerDiscovery(cfg config.ClusterHealthConfig) (PeerDiscovery, error) {
+	switch cfg.DiscoveryMode {
+	case "static":
+		return staticPeerDiscovery{peers: cfg.Peers}, nil
+	case "dns":
+		if cfg.DNSSRVName == "" {
+			return nil, fmt.Errorf("cluster health: dns discovery requires dns_srv_name")
+		}
+		return dnsSRVPeerDiscovery{name: cfg.DNSSRVName}, nil
+	case "k8s":
+		if cfg.K8sServiceName == "" || cfg.K8sNamespace == "" {
+			return nil, fmt.Errorf("cluster health: k8s discovery requires k8s_service_name and k8s_namespace")
+		}
+		return k8sHeadlessPeerDiscovery{service: cfg.K8sServiceName, namespace: cfg.K8sNamespace, port: cfg.K8sPeerPort}, nil
+	default:
+		return nil, fmt.Errorf("cluster health: unknown discovery_mode %q", cfg.DiscoveryMode)
+	}
+}
+
+type staticPeerDiscovery struct {
+	peers []string
+}
+
+func (d staticPeerDiscovery) Peers(ctx context.Context) ([]string, error) {
+	return d.peers, nil
+}
+
+// dnsSRVPeerDiscovery resolves peers from a DNS SRV record, e.g. one
+// published by a Consul or Kubernetes headless Service with named ports.
+type dnsSRVPeerDiscovery struct {
+	name string
+}
+
+func (d dnsSRVPeerDiscovery) Peers(ctx context.Context) ([]string, error) {
+	_, srvs, err := net.DefaultResolver.LookupSRV(ctx, "", "", d.name)
+	if err != nil {
+		return nil, fmt.Errorf("dns SRV lookup for %q: %w", d.name, err)
+	}
+
+	peers := make([]string, 0, len(srvs))
+	for _, srv := range srvs {
+		peers = append(peers, fmt.Sprintf("%s:%d", strings.TrimSuffix(srv.Target, "."), srv.Port))
+	}
+	return peers, nil
+}
+
+// k8sHeadlessPeerDiscovery resolves peers from a Kubernetes headless
+// Service's DNS A records, which resolve directly to pod IPs rather than a
+// single cluster IP.
+type k8sHeadlessPeerDiscovery struct {
+	service   string
+	namespace string
+	port      int
+}
+
+func (d k8sHeadlessPeerDiscovery) Peers(ctx context.Context) ([]string, error) {
+	host := fmt.Sprintf("%s.%s.svc.cluster.local", d.service, d.namespace)
+	ips, err := net.DefaultResolver.LookupHost(ctx, host)
+	if err != nil {
+		return nil, fmt.Errorf("dns lookup for headless service %q: %w", host, err)
+	}
+
+	peers := make([]string, 0, len(ips))
+	for _, ip := range ips {
+		peers = append(peers, fmt.Sprintf("%s:%d", ip, d.port))
+	}
+	return peers, nil
+}
+
+// peerHealthPayload is the subset of a peer's /health response this
+// aggregator reads - ServerTime is what the clock-skew check compares
+// against this instance's own clock.
+type peerHealthPayload struct {
+	Status     string    `json:"status"`
+	ServerTime time.Time `json:"server_time"`
+}
+
+// PeerStatus is one peer's entry in a ClusterStatus.
+type PeerStatus struct {
+	Reachable bool   `json:"reachable"`
+	Status    string `json:"status,omitempty"`
+	Latency   int64  `json:"latency_ms"`
+	ClockSkew int64  `json:"clock_skew_ms,omitempty"`
+	SkewAlert bool   `json:"clock_skew_alert,omitempty"`
+	Error     string `json:"error,omitempty"`
+}
+
+// ClusterStatus is the /health/cluster response: every discovered peer's
+// status, keyed by its address.
+type ClusterStatus struct {
+	Peers map[string]PeerStatus `json:"peers"`
+}
+
+// ClusterAggregator discovers peer hub instances and aggregates their
+// /health responses into a single ClusterStatus, analogous to Arvados's
+// /_health/all aggregator. A peer that can't be reached within the
+// configured timeout becomes an "unreachable" entry rather than failing
+// the whole request.
+type ClusterAggregator struct {
+	discovery    PeerDiscovery
+	client       *http.Client
+	timeout      time.Duration
+	maxClockSkew time.Duration
+	logger       *zap.Logger
+}
+
+// NewClusterAggregator creates a ClusterAggregator. The http.Client is
+// shared across every peer call so connections are reused instead of
+// dialed fresh each time.
+func NewClusterAggregator(discovery PeerDiscovery, timeout, maxClockSkew time.Duration, logger *zap.Logger) *ClusterAggregator {
+	return &ClusterAggregator{
+		discovery:    discovery,
+		client:       &http.Client{Timeout: timeout},
+		timeout:      timeout,
+		maxClockSkew: maxClockSkew,
+		logger:       logger,
+	}
+}
+
+// Aggregate discovers peers and calls each one's /health endpoint
+// concurrently, returning a ClusterStatus once every call has finished or
+// timed out.
+func (a *ClusterAggregator) Aggregate(ctx context.Context) (*ClusterStatus, error) {
+	peers, err := a.discovery.Peers(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("discovering peers: %w", err)
+	}
+
+	result := &ClusterStatus{Peers: make(map[string]PeerStatus, len(peers))}
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for _, addr := range peers {
+		wg.Add(1)
+		go func(addr string) {
+			defer wg.Done()
+			status := a.checkPeer(ctx, addr)
+
+			mu.Lock()
+			result.Peers[addr] = status
+			mu.Unlock()
+		}(addr)
+	}
+	wg.Wait()
+
+	return result, nil
+}
+
+func (a *ClusterAggregator) checkPeer(ctx context.Context, addr string) PeerStatus {
+	checkCtx, cancel := context.WithTimeout(ctx, a.timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(checkCtx, "GET", fmt.Sprintf("http://%s/health", addr), nil)
+	if err != nil {
+		return PeerStatus{Error: err.Error()}
+	}
+
+	start := time.Now()
+	resp, err := a.client.Do(req)
+	latency := time.Since(start)
+	if err != nil {
+		return PeerStatus{Latency: latency.Milliseconds(), Error: err.Error()}
+	}
+	defer resp.Body.Close()
+
+	var payload peerHealthPayload
+	if err := json.NewDecoder(resp.Body).Decode(&payload); err != nil {
+		return PeerStatus{Latency: latency.Milliseconds(), Error: fmt.Sprintf("decoding response: %v", err)}
+	}
+
+	skew := time.Since(payload.ServerTime)
+	if skew < 0 {
+		skew = -skew
+	}
+
+	status := PeerStatus{
+		Reachable: true,
+		Status:    payload.Status,
+		Latency:   latency.Milliseconds(),
+		ClockSkew: skew.Milliseconds(),
+		SkewAlert: skew > a.maxClockSkew,
+	}
+
+	if status.SkewAlert && a.logger != nil {
+		a.logger.Warn("Peer clock skew exceeds threshold",
+			zap.String("peer", addr),
+			zap.Duration("skew", skew),
+			zap.Duration("max_clock_skew", a.maxClockSkew),
+		)
+	}
+
+	return status
+}
@@ -0,0 +1,191 @@
+package observability
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// queueDepthWarnThreshold flags a queue as backlogged once it holds more
+// than this many pending events.
+const queueDepthWarnThreshold = 10000
+
+// DiagnosticsBundle is the /diagnostics response: the current health
+// snapshot, every CommonIssue DiagnoseHealthStatus (and pipeline-metric
+// inspection) could find, and recent log context per unhealthy component.
+type DiagnosticsBundle struct {
+	Status *HealthStatus       `json:"status"`
+	Issues []CommonIssue       `json:"issues"`
+	Logs   map[string][]string `json:"logs,omitempty"`
+}
+
+// DiagnosticsHandler returns the GET /diagnostics handler: it runs the
+// latest health snapshot through Troubleshooting.DiagnoseHealthStatus,
+// synthesizes additional issues by inspecting live pipeline metrics, and
+// attaches redacted recent log lines for each unhealthy component. An
+// "Accept: text/markdown" request gets a runbook-formatted response
+// suitable for pasting into an incident ticket instead of JSON.
+func (h *HealthChecker) DiagnosticsHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		status := h.Check(r.Context())
+
+		troubleshooting := NewTroubleshooting(h.logger)
+		issues := troubleshooting.DiagnoseHealthStatus(status)
+		issues = append(issues, diagnosePipelineIssues(status.Pipeline)...)
+
+		bundle := DiagnosticsBundle{Status: status, Issues: issues}
+		if h.logRing != nil {
+			bundle.Logs = make(map[string][]string)
+			for name, component := range status.Components {
+				if component.Status != "healthy" {
+					if lines := h.logRing.Lines(name); len(lines) > 0 {
+						bundle.Logs[name] = lines
+					}
+				}
+			}
+		}
+
+		if acceptsMarkdown(r) {
+			w.Header().Set("Content-Type", "text/markdown")
+			w.WriteHeader(http.StatusOK)
+			writeDiagnosticsRunbook(w, bundle)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(bundle)
+	}
+}
+
+// DiagnosticsKBHandler returns the GET /diagnostics/kb handler: the full
+// remediation catalog from Troubleshooting.GetCommonRemediations, so
+// operators and support tooling can render it without running the binary.
+func (h *HealthChecker) DiagnosticsKBHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		catalog := NewTroubleshooting(h.logger).GetCommonRemediations()
+
+		if acceptsMarkdown(r) {
+			w.Header().Set("Content-Type", "text/markdown")
+			w.WriteHeader(http.StatusOK)
+			writeKBRunbook(w, catalog)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(catalog)
+	}
+}
+
+func acceptsMarkdown(r *http.Request) bool {
+	return strings.Contains(r.Header.Get("Accept"), "text/markdown")
+}
+
+// diagnosePipelineIssues inspects PipelineHealth for signs of trouble that
+// DiagnoseHealthStatus's per-component view wouldn't catch on its own: a
+// backlogged queue, ingestion that's stopped producing events, or a
+// collector whose pipeline-reported status has degraded.
+func diagnosePipelineIssues(pipeline PipelineHealth) []CommonIssue {
+	var issues []CommonIssue
+
+	for name, depth := range pipeline.QueueDepth {
+		if depth > queueDepthWarnThreshold {
+			issues = append(issues, CommonIssue{
+				Component:   name,
+				Issue:       "Queue backlog",
+				Severity:    "medium",
+				Description: fmt.Sprintf("%s queue depth is %d, above the %d warning threshold", name, depth, queueDepthWarnThreshold),
+				Remediation: []string{
+					"Check processing rate vs ingestion rate",
+					"Scale horizontally if processing is bottleneck",
+					"Reduce batch sizes for faster processing",
+					"Check for slow downstream dependencies",
+				},
+			})
+		}
+	}
+
+	if pipeline.EventsPerSecond == 0 && !pipeline.LastEventTime.IsZero() {
+		issues = append(issues, CommonIssue{
+			Component:   "pipeline",
+			Issue:       "Ingestion stalled",
+			Severity:    "high",
+			Description: fmt.Sprintf("No events processed since %s", pipeline.LastEventTime.Format("2006-01-02T15:04:05Z")),
+			Remediation: []string{
+				"Check collector connectivity and credentials",
+				"Verify upstream sources are producing events",
+				"Check for a stuck or panicked pipeline worker goroutine",
+			},
+		})
+	}
+
+	for name, collectorStatus := range pipeline.CollectorStatus {
+		if collectorStatus != "healthy" {
+			issues = append(issues, CommonIssue{
+				Component:   name,
+				Issue:       "Collector degraded",
+				Severity:    "medium",
+				Description: fmt.Sprintf("Pipeline reports collector %s status as %q", name, collectorStatus),
+				Remediation: []string{
+					"Check component logs for errors",
+					"Verify collector credentials haven't expired",
+				},
+			})
+		}
+	}
+
+	return issues
+}
+
+// writeDiagnosticsRunbook renders bundle as a runbook suitable for pasting
+// into an incident ticket: overall status, one section per issue, and any
+// attached log context.
+func writeDiagnosticsRunbook(w http.ResponseWriter, bundle DiagnosticsBundle) {
+	fmt.Fprintf(w, "# Diagnostics report\n\n")
+	if bundle.Status != nil {
+		fmt.Fprintf(w, "**Overall status:** %s\n\n", bundle.Status.Status)
+	}
+
+	if len(bundle.Issues) == 0 {
+		fmt.Fprintf(w, "No issues detected.\n")
+		return
+	}
+
+	for _, issue := range bundle.Issues {
+		fmt.Fprintf(w, "## %s (%s) — %s\n\n", issue.Component, issue.Severity, issue.Issue)
+		if issue.Description != "" {
+			fmt.Fprintf(w, "%s\n\n", issue.Description)
+		}
+		if len(issue.Remediation) > 0 {
+			fmt.Fprintf(w, "**Remediation:**\n\n")
+			for _, step := range issue.Remediation {
+				fmt.Fprintf(w, "- %s\n", step)
+			}
+			fmt.Fprintln(w)
+		}
+		if issue.KBArticle != "" {
+			fmt.Fprintf(w, "See: %s\n\n", issue.KBArticle)
+		}
+		if lines := bundle.Logs[issue.Component]; len(lines) > 0 {
+			fmt.Fprintf(w, "**Recent logs:**\n\n```\n")
+			for _, line := range lines {
+				fmt.Fprintln(w, line)
+			}
+			fmt.Fprintf(w, "```\n\n")
+		}
+	}
+}
+
+// writeKBRunbook renders the remediation catalog as markdown.
+func writeKBRunbook(w http.ResponseWriter, catalog map[string][]string) {
+	fmt.Fprintf(w, "# Remediation catalog\n\n")
+	for issue, steps := range catalog {
+		fmt.Fprintf(w, "## %s\n\n", issue)
+		for _, step := range steps {
+			fmt.Fprintf(w, "- %s\n", step)
+		}
+		fmt.Fprintln(w)
+	}
+}
@@ -0,0 +1,114 @@
+package observability
+
+import (
+	"context"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+)
+
+// GRPCHealthServer implements the standard grpc.health.v1.Health service
+// (https://github.com/grpc/grpc/blob/master/doc/health-checking.md) on top
+// of a HealthChecker, so Kubernetes gRPC probes, Envoy health-check
+// clusters, and service meshes can consume component status without HTTP.
+type GRPCHealthServer struct {
+	grpc_health_v1.UnimplementedHealthServer
+
+	checker *HealthChecker
+}
+
+// NewGRPCHealthServer creates a gRPC health server backed by checker.
+func NewGRPCHealthServer(checker *HealthChecker) *GRPCHealthServer {
+	return &GRPCHealthServer{checker: checker}
+}
+
+// Check implements grpc.health.v1.Health. An empty service name reports
+// overall hub status; a specific name (e.g. "collector_crowdstrike") maps
+// to that registered check's status from a fresh Check run. An unknown service
+// name fails with codes.NotFound, per the health-checking protocol.
+func (s *GRPCHealthServer) Check(ctx context.Context, req *grpc_health_v1.HealthCheckRequest) (*grpc_health_v1.HealthCheckResponse, error) {
+	hs := s.checker.Check(ctx)
+
+	if req.Service == "" {
+		return &grpc_health_v1.HealthCheckResponse{Status: overallServingStatus(hs)}, nil
+	}
+
+	component, ok := hs.Components[req.Service]
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "unknown service: %s", req.Service)
+	}
+	return &grpc_health_v1.HealthCheckResponse{Status: componentServingStatus(component)}, nil
+}
+
+// Watch implements grpc.health.v1.Health, streaming a HealthCheckResponse
+// for req.Service whenever the checker's status changes, until the client
+// cancels. Per the protocol, an unknown service streams SERVICE_UNKNOWN
+// rather than failing the call - it may become known on a later Check.
+func (s *GRPCHealthServer) Watch(req *grpc_health_v1.HealthCheckRequest, stream grpc_health_v1.Health_WatchServer) error {
+	updates := s.checker.Subscribe()
+	defer s.checker.Unsubscribe(updates)
+
+	last, err := s.sendStatus(stream, req.Service, s.checker.Check(stream.Context()), nil)
+	if err != nil {
+		return err
+	}
+
+	for {
+		select {
+		case <-stream.Context().Done():
+			return stream.Context().Err()
+		case hs, ok := <-updates:
+			if !ok {
+				return nil
+			}
+			last, err = s.sendStatus(stream, req.Service, hs, &last)
+			if err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// sendStatus sends the current serving status for service on stream,
+// skipping the send if it's unchanged from prev (nil prev always sends).
+// It returns the status that was computed, sent or not, so the caller can
+// track it as the new prev.
+func (s *GRPCHealthServer) sendStatus(stream grpc_health_v1.Health_WatchServer, service string, hs *HealthStatus, prev *grpc_health_v1.HealthCheckResponse_ServingStatus) (grpc_health_v1.HealthCheckResponse_ServingStatus, error) {
+	current := watchServingStatus(hs, service)
+	if prev != nil && *prev == current {
+		return current, nil
+	}
+	if err := stream.Send(&grpc_health_v1.HealthCheckResponse{Status: current}); err != nil {
+		return current, err
+	}
+	return current, nil
+}
+
+func overallServingStatus(hs *HealthStatus) grpc_health_v1.HealthCheckResponse_ServingStatus {
+	if hs.Status == "unhealthy" {
+		return grpc_health_v1.HealthCheckResponse_NOT_SERVING
+	}
+	return grpc_health_v1.HealthCheckResponse_SERVING
+}
+
+func componentServingStatus(c ComponentHealth) grpc_health_v1.HealthCheckResponse_ServingStatus {
+	if c.Status != "healthy" {
+		return grpc_health_v1.HealthCheckResponse_NOT_SERVING
+	}
+	return grpc_health_v1.HealthCheckResponse_SERVING
+}
+
+// watchServingStatus is componentServingStatus/overallServingStatus, but
+// reports SERVICE_UNKNOWN for an unregistered service instead of erroring -
+// Watch keeps streaming for a service name that doesn't exist yet.
+func watchServingStatus(hs *HealthStatus, service string) grpc_health_v1.HealthCheckResponse_ServingStatus {
+	if service == "" {
+		return overallServingStatus(hs)
+	}
+	component, ok := hs.Components[service]
+	if !ok {
+		return grpc_health_v1.HealthCheckResponse_SERVICE_UNKNOWN
+	}
+	return componentServingStatus(component)
+}
@@ -3,9 +3,12 @@ package observability
 
 import (
 	"context"
+	"crypto/hmac"
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"path"
+	"strings"
 	"sync"
 	"time"
 
@@ -19,6 +22,15 @@ type HealthChecker struct {
 	logger     *zap.Logger
 	lastStatus *HealthStatus
 	telemetry  *Telemetry
+
+	subMu       sync.RWMutex
+	subscribers map[chan *HealthStatus]struct{}
+
+	aggregator *Aggregator
+	logRing    *LogRing
+
+	cluster      *ClusterAggregator
+	clusterToken string
 }
 
 // HealthCheck defines a health check function
@@ -26,7 +38,30 @@ type HealthCheck struct {
 	Name     string
 	Check    func(ctx context.Context) error
 	Timeout  time.Duration
-	Critical bool // If true, failure makes the app unhealthy
+	Critical bool      // If true, failure makes the app unhealthy
+	Kind     CheckKind // Which of /livez, /readyz (or both) this check gates
+}
+
+// CheckKind selects which probe endpoint(s) a HealthCheck gates. The zero
+// value is Readiness, so existing registrations that don't set Kind keep
+// running under /readyz exactly as before this field was added.
+type CheckKind int
+
+const (
+	// Readiness-only checks (e.g. collector connectivity) run under
+	// /readyz but are skipped by /livez.
+	Readiness CheckKind = iota
+	// Liveness checks (process alive, event loop responsive) are meant
+	// to be cheap and run under both /livez and /readyz.
+	Liveness
+	// Both marks a check as gating both probes explicitly.
+	Both
+)
+
+// gatesLiveness reports whether a check with this Kind should run under
+// /livez.
+func (k CheckKind) gatesLiveness() bool {
+	return k == Liveness || k == Both
 }
 
 // HealthStatus represents overall health status
@@ -57,10 +92,18 @@ type PipelineHealth struct {
 
 // NewHealthChecker creates a new health checker
 func NewHealthChecker(logger *zap.Logger, telemetry *Telemetry) *HealthChecker {
+	var logRing *LogRing
+	if telemetry != nil {
+		logRing = telemetry.LogRing()
+	}
+
 	return &HealthChecker{
-		checks:    make(map[string]HealthCheck),
-		logger:    logger,
-		telemetry: telemetry,
+		checks:      make(map[string]HealthCheck),
+		logger:      logger,
+		telemetry:   telemetry,
+		subscribers: make(map[chan *HealthStatus]struct{}),
+		aggregator:  NewAggregator(0, logger),
+		logRing:     logRing,
 	}
 }
 
@@ -99,7 +142,53 @@ func (h *HealthChecker) RegisterCollectorCheck(name, endpoint string) {
 	})
 }
 
-// Check performs all health checks
+// Aggregator returns the status aggregator backing this checker, so
+// components that know their own health (rather than being polled for it)
+// can push status events directly via ReportStatus.
+func (h *HealthChecker) Aggregator() *Aggregator {
+	return h.aggregator
+}
+
+// aggregatorPath maps a HealthCheck's flat name onto a dotted Aggregator
+// path, nesting collector checks (registered via RegisterCollectorCheck)
+// under the "ingestion" pipeline so /status/pipeline/ingestion rolls all
+// of them up together. Other checks are treated as their own top-level
+// pipeline.
+func aggregatorPath(checkName string) string {
+	if rest, ok := strings.CutPrefix(checkName, "collector_"); ok {
+		return "ingestion." + rest
+	}
+	return checkName
+}
+
+// PollLoop runs Check on a fixed interval until ctx is done, keeping the
+// Aggregator's snapshot fresh so ReadinessHandler/HealthHandler/
+// PipelineStatusHandler can read it in O(1) instead of each request
+// fanning out its own synchronous probes. It has the func(ctx
+// context.Context) shape safe.Loop expects, so it's wired the same way as
+// any other background loop: go safe.Loop(ctx, logger, "health.poll",
+// checker.PollLoop).
+func (h *HealthChecker) PollLoop(ctx context.Context) {
+	const interval = 15 * time.Second
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	h.Check(ctx)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			h.Check(ctx)
+		}
+	}
+}
+
+// Check performs all health checks. It remains the polling HealthCheck
+// API's synchronous entry point for backward compatibility, but also acts
+// as the adapter the Aggregator is built around: every per-check result is
+// translated into a ReportStatus event, so code that only knows the old
+// Check/RegisterCheck API still shows up in the event-driven status tree.
 func (h *HealthChecker) Check(ctx context.Context) *HealthStatus {
 	h.mu.RLock()
 	checks := make(map[string]HealthCheck, len(h.checks))
@@ -171,6 +260,16 @@ func (h *HealthChecker) Check(ctx context.Context) *HealthStatus {
 				}
 			}
 			mu.Unlock()
+
+			aggStatus := StatusOK
+			if err != nil {
+				if c.Critical {
+					aggStatus = StatusPermanentError
+				} else {
+					aggStatus = StatusRecoverableError
+				}
+			}
+			h.aggregator.ReportStatus(aggregatorPath(c.Name), aggStatus, err)
 		}(check)
 	}
 
@@ -182,67 +281,289 @@ func (h *HealthChecker) Check(ctx context.Context) *HealthStatus {
 	}
 
 	h.mu.Lock()
+	changed := statusChanged(h.lastStatus, status)
 	h.lastStatus = status
 	h.mu.Unlock()
 
+	if changed {
+		h.publish(status)
+	}
+
 	return status
 }
 
-// LivenessHandler returns an HTTP handler for liveness probes
-func (h *HealthChecker) LivenessHandler() http.HandlerFunc {
-	return func(w http.ResponseWriter, r *http.Request) {
-		w.Header().Set("Content-Type", "application/json")
-		w.WriteHeader(http.StatusOK)
-		json.NewEncoder(w).Encode(map[string]string{
-			"status": "alive",
-			"time":   time.Now().Format(time.RFC3339),
-		})
+// statusChanged reports whether overall or per-component status strings
+// differ between two snapshots. It ignores fields such as LastChecked and
+// Latency, which change on every Check regardless of health, so Subscribe
+// only sees real transitions.
+func statusChanged(prev, next *HealthStatus) bool {
+	if prev == nil {
+		return true
+	}
+	if prev.Status != next.Status {
+		return true
+	}
+	if len(prev.Components) != len(next.Components) {
+		return true
+	}
+	for name, c := range next.Components {
+		pc, ok := prev.Components[name]
+		if !ok || pc.Status != c.Status {
+			return true
+		}
 	}
+	return false
 }
 
-// ReadinessHandler returns an HTTP handler for readiness probes
+// Subscribe returns a channel that receives the latest HealthStatus every
+// time Check detects a status transition. The channel is buffered by one
+// and delivery is non-blocking, so a slow consumer (e.g. a gRPC Watch
+// stream) can't stall Check; it just misses intermediate updates. Call
+// Unsubscribe when done to stop deliveries and release the channel.
+func (h *HealthChecker) Subscribe() chan *HealthStatus {
+	ch := make(chan *HealthStatus, 1)
+	h.subMu.Lock()
+	defer h.subMu.Unlock()
+	h.subscribers[ch] = struct{}{}
+	return ch
+}
+
+// Unsubscribe stops deliveries to a channel returned by Subscribe.
+func (h *HealthChecker) Unsubscribe(ch chan *HealthStatus) {
+	h.subMu.Lock()
+	defer h.subMu.Unlock()
+	if _, ok := h.subscribers[ch]; ok {
+		delete(h.subscribers, ch)
+		close(ch)
+	}
+}
+
+// publish delivers status to every active Subscribe channel.
+func (h *HealthChecker) publish(status *HealthStatus) {
+	h.subMu.RLock()
+	defer h.subMu.RUnlock()
+	for ch := range h.subscribers {
+		select {
+		case ch <- status:
+		default:
+			// Replace the stale buffered status so the subscriber still
+			// gets the latest one.
+			select {
+			case <-ch:
+			default:
+			}
+			select {
+			case ch <- status:
+			default:
+				h.logger.Warn("Health status subscriber channel full, dropping update")
+			}
+		}
+	}
+}
+
+// LivenessHandler returns an etcd-style /livez handler: it evaluates only
+// checks tagged CheckKind Liveness or Both (process alive, event loop
+// responsive), not the full readiness set. See probeHandler for the
+// ?exclude= and ?verbose= query parameters it supports.
+func (h *HealthChecker) LivenessHandler() http.HandlerFunc {
+	return h.probeHandler("livez", true)
+}
+
+// ReadinessHandler returns an etcd-style /readyz handler: it evaluates the
+// full set of registered checks, including collector connectivity. See
+// probeHandler for the ?exclude= and ?verbose= query parameters it
+// supports.
 func (h *HealthChecker) ReadinessHandler() http.HandlerFunc {
+	return h.probeHandler("readyz", false)
+}
+
+// probeHandler builds the shared implementation behind LivenessHandler and
+// ReadinessHandler. Both read each check's last-reported status from the
+// Aggregator in O(1) rather than re-probing synchronously; PollLoop (or
+// components calling ReportStatus directly) is what keeps those snapshots
+// fresh.
+//
+// Two query parameters, mirroring etcd's /livez and /readyz:
+//   - exclude=<check_name>, repeatable, drops named checks from
+//     evaluation (e.g. during a planned maintenance window). An unknown
+//     name fails the request with 404 naming the check, so operators catch
+//     typos instead of silently excluding nothing.
+//   - verbose=true returns a plain-text per-check ledger
+//     ("[+] crowdstrike ok" / "[-] ai_provider failed: rate limited")
+//     followed by a trailing "<probeName> check passed"/"failed" line,
+//     instead of a JSON summary.
+func (h *HealthChecker) probeHandler(probeName string, livenessOnly bool) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		ctx, cancel := context.WithTimeout(r.Context(), 30*time.Second)
-		defer cancel()
+		excluded := make(map[string]bool)
+		for _, name := range r.URL.Query()["exclude"] {
+			excluded[name] = true
+		}
+		verbose := r.URL.Query().Get("verbose") == "true"
 
-		status := h.Check(ctx)
+		h.mu.RLock()
+		checks := make([]HealthCheck, 0, len(h.checks))
+		for _, c := range h.checks {
+			checks = append(checks, c)
+		}
+		h.mu.RUnlock()
+
+		known := make(map[string]bool, len(checks))
+		for _, c := range checks {
+			known[c.Name] = true
+		}
+		for name := range excluded {
+			if !known[name] {
+				w.Header().Set("Content-Type", "text/plain")
+				w.WriteHeader(http.StatusNotFound)
+				fmt.Fprintf(w, "unknown check: %s\n", name)
+				return
+			}
+		}
+
+		passed := true
+		var lines []string
+		for _, c := range checks {
+			if excluded[c.Name] || (livenessOnly && !c.Kind.gatesLiveness()) {
+				continue
+			}
+
+			snap, _ := h.aggregator.Snapshot(aggregatorPath(c.Name))
+			if snapshotUnhealthy(snap) && c.Critical {
+				passed = false
+			}
+
+			if !verbose {
+				continue
+			}
+			if snap.Status == StatusOK.String() || snap.Status == StatusStarting.String() {
+				lines = append(lines, fmt.Sprintf("[+] %s %s", c.Name, snap.Status))
+			} else {
+				detail := snap.Status
+				if snap.Error != "" {
+					detail = snap.Error
+				}
+				lines = append(lines, fmt.Sprintf("[-] %s failed: %s", c.Name, detail))
+			}
+		}
+
+		statusCode := http.StatusOK
+		verdict := "passed"
+		if !passed {
+			statusCode = http.StatusServiceUnavailable
+			verdict = "failed"
+		}
+
+		if verbose {
+			w.Header().Set("Content-Type", "text/plain")
+			w.WriteHeader(statusCode)
+			for _, line := range lines {
+				fmt.Fprintln(w, line)
+			}
+			fmt.Fprintf(w, "%s check %s\n", probeName, verdict)
+			return
+		}
 
 		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(statusCode)
+		json.NewEncoder(w).Encode(map[string]string{"status": verdict})
+	}
+}
+
+// HealthHandler returns an HTTP handler for detailed, pre-aggregated
+// health info - the same snapshot ReadinessHandler checks, including the
+// full per-component tree.
+func (h *HealthChecker) HealthHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		snap, _ := h.aggregator.Snapshot("")
 
-		if status.Status == "unhealthy" {
+		w.Header().Set("Content-Type", "application/json")
+		if snapshotUnhealthy(snap) {
 			w.WriteHeader(http.StatusServiceUnavailable)
 		} else {
 			w.WriteHeader(http.StatusOK)
 		}
 
-		json.NewEncoder(w).Encode(status)
+		json.NewEncoder(w).Encode(snap)
 	}
 }
 
-// HealthHandler returns an HTTP handler for detailed health info
-func (h *HealthChecker) HealthHandler() http.HandlerFunc {
+// PipelineStatusHandler returns an HTTP handler for a single pipeline's
+// aggregated status (e.g. GET /status/pipeline/ingestion rolls up every
+// collector_* check registered via RegisterCollectorCheck), read from the
+// Aggregator in O(1).
+func (h *HealthChecker) PipelineStatusHandler() http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		ctx, cancel := context.WithTimeout(r.Context(), 30*time.Second)
-		defer cancel()
-
-		status := h.Check(ctx)
+		name := path.Base(r.URL.Path)
 
+		snap, ok := h.aggregator.Snapshot(name)
 		w.Header().Set("Content-Type", "application/json")
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			json.NewEncoder(w).Encode(map[string]string{"error": "unknown pipeline: " + name})
+			return
+		}
 
-		switch status.Status {
-		case "healthy":
-			w.WriteHeader(http.StatusOK)
-		case "degraded":
-			w.WriteHeader(http.StatusOK)
-		default:
+		if snapshotUnhealthy(snap) {
 			w.WriteHeader(http.StatusServiceUnavailable)
+		} else {
+			w.WriteHeader(http.StatusOK)
 		}
+		json.NewEncoder(w).Encode(snap)
+	}
+}
+
+// EnableClusterAggregation wires a ClusterAggregator into the checker,
+// enabling ClusterHandler. Callers present token as an
+// "Authorization: Bearer <token>" header on every /health/cluster request.
+func (h *HealthChecker) EnableClusterAggregation(agg *ClusterAggregator, token string) {
+	h.cluster = agg
+	h.clusterToken = token
+}
 
+// ClusterHandler returns the /health/cluster handler: it discovers peer hub
+// instances and aggregates their /health responses into one document keyed
+// by peer address, analogous to Arvados's /_health/all. It requires a
+// bearer token since, unlike /health, a peer's response can reveal the
+// topology of the deployment. Returns 501 if EnableClusterAggregation was
+// never called.
+func (h *HealthChecker) ClusterHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if h.cluster == nil {
+			w.WriteHeader(http.StatusNotImplemented)
+			return
+		}
+
+		if !h.validBearerToken(r.Header.Get("Authorization")) {
+			h.logger.Warn("Rejected /health/cluster request with invalid bearer token")
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		status, err := h.cluster.Aggregate(r.Context())
+		if err != nil {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusInternalServerError)
+			json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
 		json.NewEncoder(w).Encode(status)
 	}
 }
 
+// validBearerToken compares an Authorization header against the configured
+// cluster token in constant time.
+func (h *HealthChecker) validBearerToken(header string) bool {
+	const prefix = "Bearer "
+	if !strings.HasPrefix(header, prefix) {
+		return false
+	}
+	presented := strings.TrimPrefix(header, prefix)
+	return hmac.Equal([]byte(presented), []byte(h.clusterToken))
+}
+
 // Troubleshooting provides common issue detection and remediation
 type Troubleshooting struct {
 	logger *zap.Logger
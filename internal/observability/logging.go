@@ -0,0 +1,315 @@
+// Package observability provides logging, metrics, and tracing capabilities
+package observability
+
+import (
+	"container/list"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"log/slog"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// Standardized structured-log and span-attribute keys shared by
+// collectors, EnrichmentSources, and AI providers. A single vocabulary
+// here is what lets a trace_id tie a log line in the ingestion package to
+// a span in the ai package, instead of every package inventing its own
+// field names for the same concept.
+const (
+	KeyEventID          = "event.id"
+	KeyEventSource      = "event.source"
+	KeyCollectorName    = "collector.name"
+	KeyEnrichmentSource = "enrichment.source"
+	KeyAIProvider       = "ai.provider"
+	KeyAITokensInput    = "ai.tokens.input"
+	KeyAITokensOutput   = "ai.tokens.output"
+)
+
+// NewSlogLogger adapts zl to log/slog so new code can log through the
+// standard library's structured logging API while every record still
+// flows through zl's own zapcore.Core - same encoder, same level, same
+// output sinks as everything still logging through *zap.Logger directly.
+// We hand-roll this instead of depending on zap's own slog bridge
+// (go.uber.org/zap/exp/zapslog) because that bridge ships as its own Go
+// module with independent versioning, which is more than this adapter
+// needs.
+func NewSlogLogger(zl *zap.Logger) *slog.Logger {
+	return slog.New(&zapSlogHandler{core: zl.Core()})
+}
+
+// zapSlogHandler implements slog.Handler on top of a zapcore.Core. It also
+// attaches the active span's trace ID to every record, so a log line and
+// the span it was emitted under can be found from each other.
+type zapSlogHandler struct {
+	core   zapcore.Core
+	fields []zapcore.Field
+}
+
+func (h *zapSlogHandler) Enabled(_ context.Context, level slog.Level) bool {
+	return h.core.Enabled(slogLevelToZap(level))
+}
+
+func (h *zapSlogHandler) Handle(ctx context.Context, record slog.Record) error {
+	fields := make([]zapcore.Field, 0, len(h.fields)+record.NumAttrs()+1)
+	fields = append(fields, h.fields...)
+	record.Attrs(func(a slog.Attr) bool {
+		fields = append(fields, slogAttrToZap(a))
+		return true
+	})
+	if span := trace.SpanFromContext(ctx); span.SpanContext().IsValid() {
+		fields = append(fields, zap.String("trace_id", span.SpanContext().TraceID().String()))
+	}
+
+	entry := zapcore.Entry{
+		Level:   slogLevelToZap(record.Level),
+		Time:    record.Time,
+		Message: record.Message,
+	}
+	if ce := h.core.Check(entry, nil); ce != nil {
+		ce.Write(fields...)
+	}
+	return nil
+}
+
+func (h *zapSlogHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	fields := make([]zapcore.Field, 0, len(attrs))
+	for _, a := range attrs {
+		fields = append(fields, slogAttrToZap(a))
+	}
+	return &zapSlogHandler{core: h.core, fields: append(append([]zapcore.Field{}, h.fields...), fields...)}
+}
+
+func (h *zapSlogHandler) WithGroup(name string) slog.Handler {
+	return &zapSlogHandler{core: h.core.With([]zapcore.Field{zap.Namespace(name)}), fields: h.fields}
+}
+
+func slogLevelToZap(level slog.Level) zapcore.Level {
+	switch {
+	case level >= slog.LevelError:
+		return zapcore.ErrorLevel
+	case level >= slog.LevelWarn:
+		return zapcore.WarnLevel
+	case level >= slog.LevelInfo:
+		return zapcore.InfoLevel
+	default:
+		return zapcore.DebugLevel
+	}
+}
+
+func slogAttrToZap(a slog.Attr) zapcore.Field {
+	return zap.Any(a.Key, a.Value.Resolve().Any())
+}
+
+// Deduping defaults: a key is allowed to fire dedupThreshold times within
+// dedupWindow before further occurrences are suppressed; dedupCapacity
+// bounds how many distinct keys are tracked at once, evicting the
+// least-recently-fired key first, same as ai.lruCache.
+const (
+	dedupThreshold = 5
+	dedupWindow    = 30 * time.Second
+	dedupCapacity  = 256
+)
+
+// NewDedupingSlogLogger wraps zl's slog adapter with a layer that
+// suppresses a log key (level, message, and sorted attribute keys - not
+// values, so "failed to poll collector X" and "... collector Y" still
+// dedupe together) once it's fired more than dedupThreshold times within
+// dedupWindow. Once a key stops firing, its next appearance (or, absent
+// one, the background flush) emits a "suppressed=N over WINDOW" summary
+// line instead of silently dropping the count. This protects against a
+// collector error loop flooding logs while CollectorErrors still counts
+// every occurrence.
+func NewDedupingSlogLogger(zl *zap.Logger) *slog.Logger {
+	return slog.New(newDedupSlogHandler(&zapSlogHandler{core: zl.Core()}))
+}
+
+type dedupSlogHandler struct {
+	next  slog.Handler
+	attrs []slog.Attr
+
+	mu      sync.Mutex
+	entries map[string]*list.Element
+	order   *list.List
+}
+
+type dedupEntry struct {
+	key         string
+	level       slog.Level
+	windowStart time.Time
+	count       int
+	suppressed  int
+}
+
+func newDedupSlogHandler(next slog.Handler) *dedupSlogHandler {
+	h := &dedupSlogHandler{
+		next:    next,
+		entries: make(map[string]*list.Element),
+		order:   list.New(),
+	}
+	go h.flushLoop()
+	return h
+}
+
+func (h *dedupSlogHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.next.Enabled(ctx, level)
+}
+
+func (h *dedupSlogHandler) Handle(ctx context.Context, record slog.Record) error {
+	attrNames := make([]string, 0, len(h.attrs)+record.NumAttrs())
+	for _, a := range h.attrs {
+		attrNames = append(attrNames, a.Key)
+	}
+	record.Attrs(func(a slog.Attr) bool {
+		attrNames = append(attrNames, a.Key)
+		return true
+	})
+	sort.Strings(attrNames)
+
+	key := dedupKey(record.Level, record.Message, attrNames)
+
+	h.mu.Lock()
+	result, summary := h.touch(key, record)
+	h.mu.Unlock()
+
+	if summary != nil {
+		_ = h.next.Handle(ctx, *summary)
+	}
+	if result.suppress {
+		return nil
+	}
+	return h.next.Handle(ctx, record)
+}
+
+// touchResult reports whether touch decided this occurrence should be
+// suppressed.
+type touchResult struct {
+	suppress bool
+}
+
+// touch records one occurrence of key, evicting the least-recently-fired
+// key if this is new and the cache is at capacity. It returns whether this
+// occurrence should be suppressed, and a completed summary record to emit
+// first if a previous window's suppressed count needs reporting. Callers
+// must hold h.mu.
+func (h *dedupSlogHandler) touch(key string, record slog.Record) (touchResult, *slog.Record) {
+	now := record.Time
+	if now.IsZero() {
+		now = time.Now()
+	}
+
+	el, ok := h.entries[key]
+	if !ok {
+		entry := &dedupEntry{key: key, level: record.Level, windowStart: now, count: 1}
+		h.entries[key] = h.order.PushFront(entry)
+		h.evictIfFull()
+		return touchResult{}, nil
+	}
+
+	h.order.MoveToFront(el)
+	entry := el.Value.(*dedupEntry)
+
+	var summary *slog.Record
+	if now.Sub(entry.windowStart) > dedupWindow {
+		if entry.suppressed > 0 {
+			r := newSummaryRecord(entry, now)
+			summary = &r
+		}
+		entry.windowStart = now
+		entry.count = 0
+		entry.suppressed = 0
+	}
+
+	entry.count++
+	if entry.count <= dedupThreshold {
+		return touchResult{}, summary
+	}
+	entry.suppressed++
+	return touchResult{suppress: true}, summary
+}
+
+func (h *dedupSlogHandler) evictIfFull() {
+	if h.order.Len() <= dedupCapacity {
+		return
+	}
+	oldest := h.order.Back()
+	if oldest == nil {
+		return
+	}
+	h.order.Remove(oldest)
+	delete(h.entries, oldest.Value.(*dedupEntry).key)
+}
+
+// flushLoop periodically emits a summary line for any key whose window has
+// elapsed with suppressed occurrences, so an operator finds out even if
+// the error stops recurring before the key is next touched.
+func (h *dedupSlogHandler) flushLoop() {
+	ticker := time.NewTicker(dedupWindow)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		h.flushStale()
+	}
+}
+
+func (h *dedupSlogHandler) flushStale() {
+	h.mu.Lock()
+	now := time.Now()
+	var summaries []slog.Record
+	for el := h.order.Front(); el != nil; el = el.Next() {
+		entry := el.Value.(*dedupEntry)
+		if now.Sub(entry.windowStart) <= dedupWindow || entry.suppressed == 0 {
+			continue
+		}
+		summaries = append(summaries, newSummaryRecord(entry, now))
+		entry.windowStart = now
+		entry.count = 0
+		entry.suppressed = 0
+	}
+	h.mu.Unlock()
+
+	for _, r := range summaries {
+		_ = h.next.Handle(context.Background(), r)
+	}
+}
+
+func newSummaryRecord(entry *dedupEntry, now time.Time) slog.Record {
+	r := slog.NewRecord(now, entry.level, fmt.Sprintf("suppressed=%d over %s", entry.suppressed, dedupWindow), 0)
+	r.AddAttrs(slog.String("dedup_key", entry.key))
+	return r
+}
+
+func (h *dedupSlogHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &dedupSlogHandler{
+		next:    h.next.WithAttrs(attrs),
+		attrs:   append(append([]slog.Attr{}, h.attrs...), attrs...),
+		entries: h.entries,
+		order:   h.order,
+	}
+}
+
+func (h *dedupSlogHandler) WithGroup(name string) slog.Handler {
+	return &dedupSlogHandler{
+		next:    h.next.WithGroup(name),
+		attrs:   h.attrs,
+		entries: h.entries,
+		order:   h.order,
+	}
+}
+
+func dedupKey(level slog.Level, message string, sortedAttrNames []string) string {
+	h := sha256.New()
+	h.Write([]byte(level.String()))
+	h.Write([]byte{0})
+	h.Write([]byte(message))
+	h.Write([]byte{0})
+	h.Write([]byte(strings.Join(sortedAttrNames, ",")))
+	return hex.EncodeToString(h.Sum(nil))[:16]
+}
@@ -0,0 +1,117 @@
+package observability
+
+import (
+	"fmt"
+	"regexp"
+	"sync"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// defaultLogRingSize bounds how many lines LogRing keeps per component, so
+// DiagnosticsHandler has recent context for an unhealthy component without
+// retaining logs indefinitely.
+const defaultLogRingSize = 25
+
+// LogRing keeps the last N log lines per component, tagged by the
+// "component" field the rest of this package already logs under (e.g.
+// Check's "Health check failed" warning). It's read by DiagnosticsHandler
+// so a /diagnostics response can include recent context for each unhealthy
+// component.
+type LogRing struct {
+	mu    sync.Mutex
+	size  int
+	lines map[string][]string
+}
+
+// NewLogRing creates a LogRing holding up to size lines per component; zero
+// uses defaultLogRingSize.
+func NewLogRing(size int) *LogRing {
+	if size == 0 {
+		size = defaultLogRingSize
+	}
+	return &LogRing{size: size, lines: make(map[string][]string)}
+}
+
+// add appends line to component's ring, dropping the oldest line once size
+// is exceeded.
+func (r *LogRing) add(component, line string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	lines := append(r.lines[component], line)
+	if len(lines) > r.size {
+		lines = lines[len(lines)-r.size:]
+	}
+	r.lines[component] = lines
+}
+
+// Lines returns a copy of the most recent lines logged for component, oldest
+// first.
+func (r *LogRing) Lines(component string) []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	lines := r.lines[component]
+	out := make([]string, len(lines))
+	copy(out, lines)
+	return out
+}
+
+// secretPattern matches common secret-bearing tokens (API keys, bearer
+// tokens, basic-auth credentials) that might otherwise end up in a log line
+// via an error message from an upstream call, so ring-buffered lines stay
+// safe to paste into an incident ticket.
+var secretPattern = regexp.MustCompile(`(?i)(api[_-]?key|token|secret|password|authorization)("?\s*[:=]\s*"?)([A-Za-z0-9\-_./+]{8,})`)
+
+// redactLine masks anything secretPattern matches in line.
+func redactLine(line string) string {
+	return secretPattern.ReplaceAllString(line, "$1$2[REDACTED]")
+}
+
+// ringCore is a zapcore.Core that tees every log entry tagged with a
+// "component" field into a LogRing, in addition to writing through the
+// wrapped core unchanged.
+type ringCore struct {
+	zapcore.Core
+	ring *LogRing
+}
+
+// newRingCore wraps core so every entry also lands in ring, keyed by its
+// "component" field (entries without one aren't tied to a specific
+// component and are only written through core).
+func newRingCore(core zapcore.Core, ring *LogRing) zapcore.Core {
+	return &ringCore{Core: core, ring: ring}
+}
+
+func (c *ringCore) Check(entry zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(entry.Level) {
+		return ce.AddCore(entry, c)
+	}
+	return ce
+}
+
+func (c *ringCore) With(fields []zapcore.Field) zapcore.Core {
+	return &ringCore{Core: c.Core.With(fields), ring: c.ring}
+}
+
+func (c *ringCore) Write(entry zapcore.Entry, fields []zapcore.Field) error {
+	enc := zapcore.NewMapObjectEncoder()
+	for _, f := range fields {
+		f.AddTo(enc)
+	}
+
+	component, _ := enc.Fields["component"].(string)
+	if component != "" {
+		line := fmt.Sprintf("%s [%s] %s", entry.Time.Format("15:04:05"), entry.Level, entry.Message)
+		for key, val := range enc.Fields {
+			if key == "component" {
+				continue
+			}
+			line += fmt.Sprintf(" %s=%v", key, val)
+		}
+		c.ring.add(component, redactLine(line))
+	}
+
+	return c.Core.Write(entry, fields)
+}
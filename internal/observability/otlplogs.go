@@ -0,0 +1,194 @@
+package observability
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// otlpLogBatchSize and otlpLogBatchInterval bound how long a record waits
+// in memory before being flushed - the same size-or-time tradeoff
+// sdktrace.WithBatcher makes for spans in initTracer.
+const (
+	otlpLogBatchSize     = 512
+	otlpLogBatchInterval = 5 * time.Second
+	otlpLogQueueCapacity = 4096
+)
+
+// otlpLogRecord is the subset of an OTLP LogRecord
+// (https://opentelemetry.io/docs/specs/otel/logs/data-model/) this package
+// builds from each zap Entry: severity from the zap level, timestamp and
+// body from the entry itself, attributes from its fields, and trace/span
+// IDs when the log was emitted through a context-aware helper (see
+// LoggerWithTrace).
+type otlpLogRecord struct {
+	Timestamp  time.Time
+	Severity   string
+	Body       string
+	Attributes map[string]interface{}
+	TraceID    string
+	SpanID     string
+}
+
+// otlpLogExporter sends a batch of records, tagged with the resource
+// attributes common to the whole process, to an OTLP logs collector.
+type otlpLogExporter interface {
+	ExportLogRecords(ctx context.Context, resource map[string]string, records []otlpLogRecord) error
+	Shutdown(ctx context.Context) error
+}
+
+// stubOTLPLogExporter stands in for a real otlploggrpc.Exporter: go.mod
+// pins OpenTelemetry v1.21.0, which predates the logs SDK and the
+// otlploggrpc exporter stabilizing into that release line, so there's
+// nothing to dial out with yet. This records nothing and sends nothing;
+// swapping it for the real exporter once that dependency is added is a
+// drop-in change, since ExportLogRecords' signature already matches the
+// shape that call needs.
+type stubOTLPLogExporter struct {
+	endpoint string
+}
+
+func newStubOTLPLogExporter(endpoint string) *stubOTLPLogExporter {
+	return &stubOTLPLogExporter{endpoint: endpoint}
+}
+
+func (e *stubOTLPLogExporter) ExportLogRecords(context.Context, map[string]string, []otlpLogRecord) error {
+	return nil
+}
+
+func (e *stubOTLPLogExporter) Shutdown(context.Context) error {
+	return nil
+}
+
+// otlpLogCore is a zapcore.Core that forwards every entry to the wrapped
+// core unchanged (so the existing console/json output is untouched) and
+// additionally queues it for batched export to an OTLP logs collector.
+type otlpLogCore struct {
+	zapcore.Core
+	resource map[string]string
+	queue    chan otlpLogRecord
+}
+
+// newOTLPLogCore wraps core so every entry is also queued for export via
+// exporter, tagged with resource. It starts a background goroutine that
+// batches queued records by size or otlpLogBatchInterval, whichever comes
+// first; the returned shutdown func stops that goroutine and flushes
+// whatever remains queued.
+func newOTLPLogCore(core zapcore.Core, resource map[string]string, exporter otlpLogExporter) (zapcore.Core, func(context.Context) error) {
+	c := &otlpLogCore{
+		Core:     core,
+		resource: resource,
+		queue:    make(chan otlpLogRecord, otlpLogQueueCapacity),
+	}
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		c.runBatcher(exporter)
+	}()
+
+	shutdown := func(ctx context.Context) error {
+		close(c.queue)
+		select {
+		case <-done:
+		case <-ctx.Done():
+		}
+		return exporter.Shutdown(ctx)
+	}
+
+	return c, shutdown
+}
+
+func (c *otlpLogCore) With(fields []zapcore.Field) zapcore.Core {
+	return &otlpLogCore{Core: c.Core.With(fields), resource: c.resource, queue: c.queue}
+}
+
+func (c *otlpLogCore) Check(entry zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(entry.Level) {
+		return ce.AddCore(entry, c)
+	}
+	return ce
+}
+
+func (c *otlpLogCore) Write(entry zapcore.Entry, fields []zapcore.Field) error {
+	enc := zapcore.NewMapObjectEncoder()
+	for _, f := range fields {
+		f.AddTo(enc)
+	}
+
+	record := otlpLogRecord{
+		Timestamp:  entry.Time,
+		Severity:   entry.Level.CapitalString(),
+		Body:       entry.Message,
+		Attributes: enc.Fields,
+	}
+	if traceID, ok := enc.Fields["trace_id"].(string); ok {
+		record.TraceID = traceID
+		delete(record.Attributes, "trace_id")
+	}
+	if spanID, ok := enc.Fields["span_id"].(string); ok {
+		record.SpanID = spanID
+		delete(record.Attributes, "span_id")
+	}
+
+	select {
+	case c.queue <- record:
+	default:
+		// Queue full; drop rather than block the caller's log call on a
+		// slow or unreachable collector.
+	}
+
+	return c.Core.Write(entry, fields)
+}
+
+// runBatcher drains c.queue until it's closed, flushing accumulated
+// records to exporter every otlpLogBatchInterval or once otlpLogBatchSize
+// is reached, whichever comes first. It flushes once more before
+// returning, so records queued right before shutdown aren't lost.
+func (c *otlpLogCore) runBatcher(exporter otlpLogExporter) {
+	ticker := time.NewTicker(otlpLogBatchInterval)
+	defer ticker.Stop()
+
+	var batch []otlpLogRecord
+	flush := func() {
+		if len(batch) == 0 {
+			return
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), otlpLogBatchInterval)
+		_ = exporter.ExportLogRecords(ctx, c.resource, batch)
+		cancel()
+		batch = nil
+	}
+
+	for {
+		select {
+		case record, ok := <-c.queue:
+			if !ok {
+				flush()
+				return
+			}
+			batch = append(batch, record)
+			if len(batch) >= otlpLogBatchSize {
+				flush()
+			}
+		case <-ticker.C:
+			flush()
+		}
+	}
+}
+
+// LoggerWithTrace returns logger enriched with the trace and span IDs of
+// the active span in ctx (if any), so every record it writes carries the
+// same trace_id/span_id otlpLogCore lifts into an OTLP LogRecord's
+// correlation fields - the "context-aware helper" callers should log
+// through wherever a context is available, in place of the bare logger.
+func LoggerWithTrace(ctx context.Context, logger *zap.Logger) *zap.Logger {
+	sc := trace.SpanFromContext(ctx).SpanContext()
+	if !sc.IsValid() {
+		return logger
+	}
+	return logger.With(zap.String("trace_id", sc.TraceID().String()), zap.String("span_id", sc.SpanID().String()))
+}
@@ -0,0 +1,187 @@
+package observability
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+)
+
+// otlpMetricsExportInterval is how often the bridge gathers and exports
+// every registered metric - the same cadence StartSystemMetricsCollector
+// already samples goroutine/memory stats at.
+const otlpMetricsExportInterval = 15 * time.Second
+
+// otlpMetricBucket is one cumulative bucket of a histogram, mirroring the
+// Prometheus exposition format's bucket semantics (each bucket's count
+// includes everything at or below UpperBound).
+type otlpMetricBucket struct {
+	UpperBound float64
+	Count      uint64
+}
+
+// otlpMetricRecord is one timeseries gathered from a Prometheus
+// registry: a counter or gauge's Value, or a histogram/summary's Sum,
+// Count, and Buckets.
+type otlpMetricRecord struct {
+	Name    string
+	Help    string
+	Kind    string // counter, gauge, histogram, summary, untyped
+	Labels  map[string]string
+	Value   float64
+	Sum     float64
+	Count   uint64
+	Buckets []otlpMetricBucket
+}
+
+// otlpMetricExporter sends a batch of gathered metrics, tagged with the
+// resource attributes common to the whole process, to an OTLP metrics
+// collector.
+type otlpMetricExporter interface {
+	ExportMetrics(ctx context.Context, resource map[string]string, records []otlpMetricRecord) error
+	Shutdown(ctx context.Context) error
+}
+
+// stubOTLPMetricExporter stands in for a real OTel metrics SDK exporter:
+// go.mod's OpenTelemetry v1.21.0 pin predates this repo vendoring
+// go.opentelemetry.io/otel/sdk/metric or an otlpmetricgrpc exporter, so
+// there's nothing to dial out with yet, mirroring stubOTLPLogExporter's
+// reasoning in otlplogs.go. Swapping it for the real exporter is a
+// drop-in change once that dependency is added.
+type stubOTLPMetricExporter struct {
+	endpoint string
+}
+
+func newStubOTLPMetricExporter(endpoint string) *stubOTLPMetricExporter {
+	return &stubOTLPMetricExporter{endpoint: endpoint}
+}
+
+func (e *stubOTLPMetricExporter) ExportMetrics(context.Context, map[string]string, []otlpMetricRecord) error {
+	return nil
+}
+
+func (e *stubOTLPMetricExporter) Shutdown(context.Context) error {
+	return nil
+}
+
+// otlpMetricsBridge periodically gathers every metric already registered
+// with gatherer - the same default registry MetricsHandler's promhttp
+// scrape endpoint reads from, so every counter/histogram/gauge already
+// defined on Metrics (and anything else registered later, like
+// correlation.Correlator) is mirrored automatically without redefining it
+// as a separate OTel instrument.
+type otlpMetricsBridge struct {
+	gatherer prometheus.Gatherer
+	exporter otlpMetricExporter
+	resource map[string]string
+}
+
+// newOTLPMetricsBridge starts a background goroutine that exports every
+// metric in gatherer to exporter every otlpMetricsExportInterval. The
+// returned shutdown func stops that goroutine and shuts down the exporter.
+func newOTLPMetricsBridge(gatherer prometheus.Gatherer, resource map[string]string, exporter otlpMetricExporter) (*otlpMetricsBridge, func(context.Context) error) {
+	b := &otlpMetricsBridge{gatherer: gatherer, exporter: exporter, resource: resource}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		b.run(ctx)
+	}()
+
+	shutdown := func(shutdownCtx context.Context) error {
+		cancel()
+		select {
+		case <-done:
+		case <-shutdownCtx.Done():
+		}
+		return exporter.Shutdown(shutdownCtx)
+	}
+
+	return b, shutdown
+}
+
+func (b *otlpMetricsBridge) run(ctx context.Context) {
+	ticker := time.NewTicker(otlpMetricsExportInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			b.export(ctx)
+		}
+	}
+}
+
+func (b *otlpMetricsBridge) export(ctx context.Context) {
+	families, err := b.gatherer.Gather()
+	if err != nil {
+		return
+	}
+
+	records := make([]otlpMetricRecord, 0, len(families))
+	for _, family := range families {
+		records = append(records, metricFamilyToRecords(family)...)
+	}
+
+	exportCtx, cancel := context.WithTimeout(ctx, otlpMetricsExportInterval)
+	defer cancel()
+	_ = b.exporter.ExportMetrics(exportCtx, b.resource, records)
+}
+
+// metricFamilyToRecords flattens one gathered MetricFamily (one name, one
+// or more label-distinguished timeseries) into otlpMetricRecords.
+func metricFamilyToRecords(family *dto.MetricFamily) []otlpMetricRecord {
+	name := family.GetName()
+	help := family.GetHelp()
+	kind := strings.ToLower(family.GetType().String())
+
+	records := make([]otlpMetricRecord, 0, len(family.Metric))
+	for _, m := range family.Metric {
+		record := otlpMetricRecord{
+			Name:   name,
+			Help:   help,
+			Kind:   kind,
+			Labels: labelPairsToMap(m.Label),
+		}
+
+		switch {
+		case m.Gauge != nil:
+			record.Value = m.Gauge.GetValue()
+		case m.Counter != nil:
+			record.Value = m.Counter.GetValue()
+		case m.Histogram != nil:
+			record.Sum = m.Histogram.GetSampleSum()
+			record.Count = m.Histogram.GetSampleCount()
+			for _, bucket := range m.Histogram.GetBucket() {
+				record.Buckets = append(record.Buckets, otlpMetricBucket{
+					UpperBound: bucket.GetUpperBound(),
+					Count:      bucket.GetCumulativeCount(),
+				})
+			}
+		case m.Summary != nil:
+			record.Sum = m.Summary.GetSampleSum()
+			record.Count = m.Summary.GetSampleCount()
+		case m.Untyped != nil:
+			record.Value = m.Untyped.GetValue()
+		}
+
+		records = append(records, record)
+	}
+	return records
+}
+
+func labelPairsToMap(pairs []*dto.LabelPair) map[string]string {
+	if len(pairs) == 0 {
+		return nil
+	}
+	labels := make(map[string]string, len(pairs))
+	for _, p := range pairs {
+		labels[p.GetName()] = p.GetValue()
+	}
+	return labels
+}
@@ -3,6 +3,7 @@ package observability
 
 import (
 	"context"
+	"log/slog"
 	"net/http"
 	"runtime"
 	"sync"
@@ -21,39 +22,20 @@ import (
 	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
+
+	"github.com/lvonguyen/threat-telemetry-hub/internal/config"
 )
 
 // Telemetry provides unified observability
 type Telemetry struct {
 	logger       *zap.Logger
+	slog         *slog.Logger
 	tracer       trace.Tracer
 	metrics      *Metrics
-	config       Config
+	config       config.ObservabilityConfig
 	shutdownOnce sync.Once
 	shutdownFns  []func(context.Context) error
-}
-
-// Config configures telemetry
-type Config struct {
-	ServiceName    string `yaml:"service_name"`
-	ServiceVersion string `yaml:"service_version"`
-	Environment    string `yaml:"environment"`
-
-	// Logging
-	LogLevel  string `yaml:"log_level"`
-	LogFormat string `yaml:"log_format"` // json, console
-
-	// Tracing
-	TracingEnabled bool    `yaml:"tracing_enabled"`
-	OTLPEndpoint   string  `yaml:"otlp_endpoint"`
-	SamplingRate   float64 `yaml:"sampling_rate"`
-
-	// Metrics
-	MetricsEnabled bool `yaml:"metrics_enabled"`
-	MetricsPort    int  `yaml:"metrics_port"`
-
-	// Health
-	HealthPort int `yaml:"health_port"`
+	logRing      *LogRing
 }
 
 // Metrics holds Prometheus metrics for threat-telemetry-hub
@@ -92,9 +74,10 @@ type Metrics struct {
 }
 
 // New creates a new Telemetry instance
-func New(cfg Config) (*Telemetry, error) {
+func New(cfg config.ObservabilityConfig) (*Telemetry, error) {
 	t := &Telemetry{
-		config: cfg,
+		config:  cfg,
+		logRing: NewLogRing(0),
 	}
 
 	// Initialize logger
@@ -103,23 +86,88 @@ func New(cfg Config) (*Telemetry, error) {
 		return nil, err
 	}
 	t.logger = logger
+	t.slog = NewDedupingSlogLogger(logger)
 
-	// Initialize tracer
-	if cfg.TracingEnabled {
+	// Initialize tracer. OTLP export is on by default; TracingDisabled
+	// opts a deployment out when it has no collector to send spans to.
+	if !cfg.TracingDisabled {
 		if err := t.initTracer(); err != nil {
 			logger.Warn("Failed to initialize tracer", zap.Error(err))
 		}
 	}
 	t.tracer = otel.Tracer(cfg.ServiceName)
 
+	// Initialize OTLP log export. Off by default: enabling it is an
+	// explicit opt-in rather than a behavior change for deployments with
+	// no collector to send logs to.
+	if cfg.LoggingOTLPEnabled {
+		t.initOTLPLogs()
+	}
+
 	// Initialize metrics
 	if cfg.MetricsEnabled {
 		t.metrics = t.initMetrics()
 	}
 
+	// Mirror every registered metric to an OTLP metrics collector on top
+	// of the Prometheus scrape endpoint, for deployments that only run an
+	// OTel Collector. Independent of MetricsEnabled: it bridges whatever
+	// is already registered with the default registry, which includes
+	// collectors like correlation.Correlator that don't go through
+	// Metrics at all.
+	if cfg.MetricsOTLPEnabled {
+		t.initOTLPMetrics()
+	}
+
 	return t, nil
 }
 
+// resourceAttributes returns the process-wide attributes OTLP log records
+// are tagged with, mirroring the resource initTracer attaches to every
+// span so a log line and a span from the same process carry the same
+// service.name/service.version/environment.
+func (t *Telemetry) resourceAttributes() map[string]string {
+	return map[string]string{
+		"service.name":    t.config.ServiceName,
+		"service.version": t.config.ServiceVersion,
+		"environment":     t.config.Environment,
+	}
+}
+
+// initOTLPLogs wraps the logger's core with otlpLogCore so every record it
+// writes is also queued for batched export to an OTLP logs collector. The
+// logger and its slog adapter are rebuilt on top of the wrapped core,
+// since NewDedupingSlogLogger captures zl.Core() at construction time.
+func (t *Telemetry) initOTLPLogs() {
+	exporter := newStubOTLPLogExporter(t.config.OTLPEndpoint)
+	res := t.resourceAttributes()
+
+	var shutdown func(context.Context) error
+	t.logger = t.logger.WithOptions(zap.WrapCore(func(core zapcore.Core) zapcore.Core {
+		var wrapped zapcore.Core
+		wrapped, shutdown = newOTLPLogCore(core, res, exporter)
+		return wrapped
+	}))
+	t.slog = NewDedupingSlogLogger(t.logger)
+	t.shutdownFns = append(t.shutdownFns, shutdown)
+
+	t.logger.Info("OTLP log export enabled (stub exporter, no otlploggrpc dependency vendored yet)",
+		zap.String("endpoint", t.config.OTLPEndpoint))
+}
+
+// initOTLPMetrics starts a background bridge that periodically gathers
+// every metric registered with the default Prometheus registry - the same
+// one MetricsHandler's promhttp.Handler() reads from - and exports it to
+// an OTLP metrics collector.
+func (t *Telemetry) initOTLPMetrics() {
+	exporter := newStubOTLPMetricExporter(t.config.OTLPEndpoint)
+	_, shutdown := newOTLPMetricsBridge(prometheus.DefaultGatherer, t.resourceAttributes(), exporter)
+	t.shutdownFns = append(t.shutdownFns, shutdown)
+
+	t.logger.Info("OTLP metrics export enabled (stub exporter, no OTel metrics SDK dependency vendored yet)",
+		zap.String("endpoint", t.config.OTLPEndpoint))
+}
+
 // initLogger initializes structured logging
 func (t *Telemetry) initLogger() (*zap.Logger, error) {
 	var config zap.Config
@@ -154,7 +202,9 @@ func (t *Telemetry) initLogger() (*zap.Logger, error) {
 		"environment": t.config.Environment,
 	}
 
-	return config.Build()
+	return config.Build(zap.WrapCore(func(core zapcore.Core) zapcore.Core {
+		return newRingCore(core, t.logRing)
+	}))
 }
 
 // initTracer initializes OpenTelemetry tracing
@@ -358,6 +408,13 @@ func (t *Telemetry) Logger() *zap.Logger {
 	return t.logger
 }
 
+// Slog returns a log/slog.Logger backed by the same zapcore.Core as
+// Logger, so records logged through it land in the same sinks (and, once
+// standardized keys are used, correlate with spans via trace_id).
+func (t *Telemetry) Slog() *slog.Logger {
+	return t.slog
+}
+
 // Tracer returns the tracer
 func (t *Telemetry) Tracer() trace.Tracer {
 	return t.tracer
@@ -368,6 +425,12 @@ func (t *Telemetry) Metrics() *Metrics {
 	return t.metrics
 }
 
+// LogRing returns the ring buffer of recent per-component log lines, used by
+// DiagnosticsHandler to attach context to unhealthy components.
+func (t *Telemetry) LogRing() *LogRing {
+	return t.logRing
+}
+
 // StartSpan starts a new trace span
 func (t *Telemetry) StartSpan(ctx context.Context, name string, opts ...trace.SpanStartOption) (context.Context, trace.Span) {
 	return t.tracer.Start(ctx, name, opts...)
@@ -0,0 +1,34 @@
+package ocsf
+
+import (
+	"github.com/lvonguyen/threat-telemetry-hub/internal/ingestion"
+)
+
+func init() {
+	Register("azure-activity", azureActivityMapper{})
+}
+
+// azureActivityMapper maps Azure Activity Log entries to OCSF API Activity
+// (6003).
+type azureActivityMapper struct{}
+
+func (azureActivityMapper) Map(raw *ingestion.RawEvent) (*Event, error) {
+	data := ingestion.DataMap(raw)
+
+	actor := &Actor{User: User{
+		Name:   getString(data, "caller"),
+		Domain: getString(data, "tenantId"),
+	}}
+
+	return &Event{
+		CategoryUID: CategoryApplication,
+		ClassUID:    ClassAPIActivity,
+		ActivityID:  1, // Create/Call
+		SeverityID:  1,
+		Metadata: Metadata{
+			Product: ProductInfo{Name: "Activity Log", VendorName: "Microsoft"},
+		},
+		Actor:    actor,
+		Unmapped: remaining(data, "caller", "tenantId"),
+	}, nil
+}
@@ -0,0 +1,39 @@
+package ocsf
+
+import (
+	"github.com/lvonguyen/threat-telemetry-hub/internal/ingestion"
+)
+
+func init() {
+	Register("aws-cloudtrail", cloudTrailMapper{})
+}
+
+// cloudTrailMapper maps AWS CloudTrail records to OCSF API Activity (6003).
+type cloudTrailMapper struct{}
+
+func (cloudTrailMapper) Map(raw *ingestion.RawEvent) (*Event, error) {
+	data := ingestion.DataMap(raw)
+
+	actor := &Actor{User: User{
+		Name: getString(data, "userName"),
+		UID:  getString(data, "principalId"),
+	}}
+
+	var observables []Observable
+	if ip := getString(data, "sourceIPAddress"); ip != "" {
+		observables = append(observables, Observable{Name: "src_endpoint.ip", Type: "IP Address", Value: ip})
+	}
+
+	return &Event{
+		CategoryUID: CategoryApplication,
+		ClassUID:    ClassAPIActivity,
+		ActivityID:  1, // Create/Call
+		SeverityID:  1, // Informational by default; AWS Config/GuardDuty findings layer severity separately
+		Metadata: Metadata{
+			Product: ProductInfo{Name: "CloudTrail", VendorName: "Amazon Web Services"},
+		},
+		Actor:       actor,
+		Observables: observables,
+		Unmapped:    remaining(data, "userName", "principalId", "sourceIPAddress"),
+	}, nil
+}
@@ -0,0 +1,60 @@
+package ocsf
+
+import (
+	"github.com/lvonguyen/threat-telemetry-hub/internal/ingestion"
+)
+
+func init() {
+	Register("crowdstrike", crowdStrikeMapper{})
+}
+
+// crowdStrikeMapper maps CrowdStrike Falcon detections to OCSF Detection
+// Finding (2004).
+type crowdStrikeMapper struct{}
+
+func (crowdStrikeMapper) Map(raw *ingestion.RawEvent) (*Event, error) {
+	data := ingestion.DataMap(raw)
+
+	device := &Device{
+		Hostname: getString(data, "device_hostname"),
+	}
+	actor := &Actor{User: User{Name: getString(data, "username")}}
+
+	var observables []Observable
+	if hash := getString(data, "sha256"); hash != "" {
+		observables = append(observables, Observable{Name: "file.hash.sha256", Type: "Hash", Value: hash})
+	}
+
+	return &Event{
+		CategoryUID: CategoryFindings,
+		ClassUID:    ClassDetectionFinding,
+		ActivityID:  1, // Create
+		SeverityID:  severityFromName(getString(data, "severity_name")),
+		Metadata: Metadata{
+			Product: ProductInfo{Name: "Falcon", VendorName: "CrowdStrike"},
+		},
+		Actor:       actor,
+		Device:      device,
+		Observables: observables,
+		Unmapped:    remaining(data, "device_hostname", "username", "sha256", "severity_name"),
+	}, nil
+}
+
+// severityFromName maps a vendor's named severity onto the OCSF severity_id
+// enum (0=Unknown, 1=Info, 2=Low, 3=Medium, 4=High, 5=Critical, 6=Fatal).
+func severityFromName(name string) int {
+	switch name {
+	case "critical":
+		return 5
+	case "high":
+		return 4
+	case "medium":
+		return 3
+	case "low":
+		return 2
+	case "informational", "info":
+		return 1
+	default:
+		return 0
+	}
+}
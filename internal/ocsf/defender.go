@@ -0,0 +1,33 @@
+package ocsf
+
+import (
+	"github.com/lvonguyen/threat-telemetry-hub/internal/ingestion"
+)
+
+func init() {
+	Register("defender", defenderMapper{})
+}
+
+// defenderMapper maps Microsoft Defender for Endpoint alerts to OCSF
+// Detection Finding (2004).
+type defenderMapper struct{}
+
+func (defenderMapper) Map(raw *ingestion.RawEvent) (*Event, error) {
+	data := ingestion.DataMap(raw)
+
+	device := &Device{Hostname: getString(data, "computerDnsName")}
+	actor := &Actor{User: User{Name: getString(data, "userPrincipalName")}}
+
+	return &Event{
+		CategoryUID: CategoryFindings,
+		ClassUID:    ClassDetectionFinding,
+		ActivityID:  1, // Create
+		SeverityID:  severityFromName(getString(data, "severity")),
+		Metadata: Metadata{
+			Product: ProductInfo{Name: "Defender for Endpoint", VendorName: "Microsoft"},
+		},
+		Actor:    actor,
+		Device:   device,
+		Unmapped: remaining(data, "computerDnsName", "userPrincipalName", "severity"),
+	}, nil
+}
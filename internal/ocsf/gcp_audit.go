@@ -0,0 +1,30 @@
+package ocsf
+
+import (
+	"github.com/lvonguyen/threat-telemetry-hub/internal/ingestion"
+)
+
+func init() {
+	Register("gcp-audit", gcpAuditMapper{})
+}
+
+// gcpAuditMapper maps GCP Audit Log entries to OCSF API Activity (6003).
+type gcpAuditMapper struct{}
+
+func (gcpAuditMapper) Map(raw *ingestion.RawEvent) (*Event, error) {
+	data := ingestion.DataMap(raw)
+
+	actor := &Actor{User: User{Name: getString(data, "principalEmail")}}
+
+	return &Event{
+		CategoryUID: CategoryApplication,
+		ClassUID:    ClassAPIActivity,
+		ActivityID:  1, // Create/Call
+		SeverityID:  1,
+		Metadata: Metadata{
+			Product: ProductInfo{Name: "Audit Logs", VendorName: "Google Cloud"},
+		},
+		Actor:    actor,
+		Unmapped: remaining(data, "principalEmail"),
+	}, nil
+}
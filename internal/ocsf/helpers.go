@@ -0,0 +1,27 @@
+package ocsf
+
+// getString returns m[key] as a string, or "" if absent or not a string.
+// Vendor payloads are untyped JSON, so every mapper leans on this rather
+// than repeating type assertions.
+func getString(m map[string]interface{}, key string) string {
+	if m == nil {
+		return ""
+	}
+	if v, ok := m[key].(string); ok {
+		return v
+	}
+	return ""
+}
+
+// remaining returns a shallow copy of m with the given keys removed, for use
+// as an Event's Unmapped field.
+func remaining(m map[string]interface{}, consumed ...string) map[string]interface{} {
+	out := make(map[string]interface{}, len(m))
+	for k, v := range m {
+		out[k] = v
+	}
+	for _, k := range consumed {
+		delete(out, k)
+	}
+	return out
+}
@@ -0,0 +1,37 @@
+package ocsf
+
+import (
+	"github.com/lvonguyen/threat-telemetry-hub/internal/ingestion"
+)
+
+func init() {
+	Register("netskope", netskopeMapper{})
+}
+
+// netskopeMapper maps Netskope cloud security events to OCSF Network
+// Activity (4001).
+type netskopeMapper struct{}
+
+func (netskopeMapper) Map(raw *ingestion.RawEvent) (*Event, error) {
+	data := ingestion.DataMap(raw)
+
+	actor := &Actor{User: User{Name: getString(data, "user")}}
+
+	var observables []Observable
+	if host := getString(data, "dst_host"); host != "" {
+		observables = append(observables, Observable{Name: "dst_endpoint.hostname", Type: "Hostname", Value: host})
+	}
+
+	return &Event{
+		CategoryUID: CategoryNetwork,
+		ClassUID:    ClassNetworkActivity,
+		ActivityID:  6, // Traffic
+		SeverityID:  1,
+		Metadata: Metadata{
+			Product: ProductInfo{Name: "Cloud Security", VendorName: "Netskope"},
+		},
+		Actor:       actor,
+		Observables: observables,
+		Unmapped:    remaining(data, "user", "dst_host"),
+	}, nil
+}
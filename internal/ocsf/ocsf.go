@@ -0,0 +1,121 @@
+// Package ocsf maps vendor-specific RawEvent payloads onto the Open
+// Cybersecurity Schema Framework (https://schema.ocsf.io/), giving the AI
+// analyzer and downstream consumers a single, compact, well-typed shape to
+// reason over instead of each vendor's raw JSON.
+package ocsf
+
+import (
+	"fmt"
+
+	"github.com/lvonguyen/threat-telemetry-hub/internal/ingestion"
+)
+
+// OCSF category and class UIDs for the classes this hub maps to. See
+// https://schema.ocsf.io/categories for the authoritative list.
+const (
+	CategoryFileSystem    = 1 // File System Activity
+	CategoryFindings      = 2 // Findings
+	CategoryNetwork       = 4 // Network Activity
+	CategoryIAM           = 3 // Identity & Access Management
+	CategoryApplication   = 6 // Application Activity
+
+	ClassFileSystemActivity = 1001
+	ClassAuthentication     = 3002
+	ClassNetworkActivity    = 4001
+	ClassDetectionFinding   = 2004
+	ClassAPIActivity        = 6003
+)
+
+// Event is a normalized OCSF event. Rather than a distinct Go type per OCSF
+// class, it carries the superset of fields the classes in this hub need;
+// ClassUID identifies which class the populated fields represent, and
+// Unmapped preserves whatever vendor-specific data doesn't fit the schema so
+// it isn't lost.
+type Event struct {
+	CategoryUID int                    `json:"category_uid"`
+	ClassUID    int                    `json:"class_uid"`
+	ActivityID  int                    `json:"activity_id"`
+	SeverityID  int                    `json:"severity_id"`
+	Metadata    Metadata               `json:"metadata"`
+	Actor       *Actor                 `json:"actor,omitempty"`
+	Device      *Device                `json:"device,omitempty"`
+	Observables []Observable           `json:"observables,omitempty"`
+	Unmapped    map[string]interface{} `json:"unmapped,omitempty"`
+}
+
+// Metadata identifies the product that produced the event, per the OCSF
+// "metadata" base object.
+type Metadata struct {
+	Product ProductInfo `json:"product"`
+	Version string      `json:"version"`
+}
+
+// ProductInfo is the OCSF "product" object.
+type ProductInfo struct {
+	Name       string `json:"name"`
+	VendorName string `json:"vendor_name"`
+}
+
+// Actor is the OCSF "actor" object, trimmed to the fields this hub
+// populates.
+type Actor struct {
+	User User `json:"user"`
+}
+
+// User is the OCSF "user" object.
+type User struct {
+	Name   string `json:"name"`
+	UID    string `json:"uid,omitempty"`
+	Domain string `json:"domain,omitempty"`
+}
+
+// Device is the OCSF "device" object.
+type Device struct {
+	Hostname string `json:"hostname,omitempty"`
+	IP       string `json:"ip,omitempty"`
+	OS       string `json:"os,omitempty"`
+}
+
+// Observable is the OCSF "observable" object: a typed indicator extracted
+// from the event (file hash, IP, URL, etc.).
+type Observable struct {
+	Name  string `json:"name"`
+	Type  string `json:"type"`
+	Value string `json:"value"`
+}
+
+// Mapper converts a collector's raw vendor payload into an OCSF Event.
+type Mapper interface {
+	// Map converts raw into an OCSF event. Fields with no vendor equivalent
+	// are left zero-valued; vendor-specific data that doesn't fit the
+	// schema belongs in Event.Unmapped rather than being dropped.
+	Map(raw *ingestion.RawEvent) (*Event, error)
+}
+
+// Validate checks that an Event carries the fields every OCSF class
+// requires. Events failing validation should be routed to a DLQ rather than
+// forwarded downstream.
+func Validate(e *Event) error {
+	if e == nil {
+		return fmt.Errorf("ocsf: nil event")
+	}
+	if e.ClassUID == 0 {
+		return fmt.Errorf("ocsf: missing class_uid")
+	}
+	if e.CategoryUID == 0 {
+		return fmt.Errorf("ocsf: missing category_uid")
+	}
+	if e.Metadata.Product.Name == "" {
+		return fmt.Errorf("ocsf: missing metadata.product.name")
+	}
+	return nil
+}
+
+// DLQEntry is a raw event that failed OCSF mapping or validation, paired
+// with the error that rejected it. Consumers (e.g. an operator dashboard or
+// a reprocessing job) can inspect Raw to recover the original vendor
+// payload.
+type DLQEntry struct {
+	Raw *ingestion.RawEvent
+	Err error
+}
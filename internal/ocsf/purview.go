@@ -0,0 +1,38 @@
+package ocsf
+
+import (
+	"github.com/lvonguyen/threat-telemetry-hub/internal/ingestion"
+)
+
+func init() {
+	Register("purview", purviewMapper{})
+}
+
+// purviewMapper maps Microsoft Purview DLP policy matches to OCSF File
+// System Activity (1001), the closest fit for a file-centric policy
+// violation.
+type purviewMapper struct{}
+
+func (purviewMapper) Map(raw *ingestion.RawEvent) (*Event, error) {
+	data := ingestion.DataMap(raw)
+
+	actor := &Actor{User: User{Name: getString(data, "userId")}}
+
+	var observables []Observable
+	if name := getString(data, "fileName"); name != "" {
+		observables = append(observables, Observable{Name: "file.name", Type: "File Name", Value: name})
+	}
+
+	return &Event{
+		CategoryUID: CategoryFileSystem,
+		ClassUID:    ClassFileSystemActivity,
+		ActivityID:  1, // Create/Access
+		SeverityID:  severityFromName(getString(data, "severity")),
+		Metadata: Metadata{
+			Product: ProductInfo{Name: "Purview DLP", VendorName: "Microsoft"},
+		},
+		Actor:       actor,
+		Observables: observables,
+		Unmapped:    remaining(data, "userId", "fileName", "severity"),
+	}, nil
+}
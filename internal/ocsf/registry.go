@@ -0,0 +1,44 @@
+package ocsf
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/lvonguyen/threat-telemetry-hub/internal/ingestion"
+)
+
+// registry holds the Mapper registered for each collector's short source
+// name (e.g. "crowdstrike"), mirroring the ingestion package's collector
+// registry.
+var registry = struct {
+	mu      sync.RWMutex
+	mappers map[string]Mapper
+}{mappers: make(map[string]Mapper)}
+
+// Register associates a Mapper with a collector's source name. Intended to
+// be called from an init() in each mapper's file, alongside that
+// collector's ingestion.Register call.
+func Register(source string, m Mapper) {
+	registry.mu.Lock()
+	defer registry.mu.Unlock()
+	if _, exists := registry.mappers[source]; exists {
+		panic(fmt.Sprintf("ocsf: mapper already registered for source %q", source))
+	}
+	registry.mappers[source] = m
+}
+
+// Map converts raw into an OCSF event using the mapper registered for its
+// source. If no mapper is registered, Map returns an error rather than
+// panicking; the normalizer then routes the raw event to the DLQ.
+func Map(raw *ingestion.RawEvent) (*Event, error) {
+	source := ingestion.SourceName(raw)
+
+	registry.mu.RLock()
+	m, ok := registry.mappers[source]
+	registry.mu.RUnlock()
+
+	if !ok {
+		return nil, fmt.Errorf("ocsf: no mapper registered for source %q", source)
+	}
+	return m.Map(raw)
+}
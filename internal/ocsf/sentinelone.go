@@ -0,0 +1,40 @@
+package ocsf
+
+import (
+	"github.com/lvonguyen/threat-telemetry-hub/internal/ingestion"
+)
+
+func init() {
+	Register("sentinelone", sentinelOneMapper{})
+}
+
+// sentinelOneMapper maps SentinelOne Singularity threats to OCSF Detection
+// Finding (2004).
+type sentinelOneMapper struct{}
+
+func (sentinelOneMapper) Map(raw *ingestion.RawEvent) (*Event, error) {
+	data := ingestion.DataMap(raw)
+
+	device := &Device{
+		Hostname: getString(data, "agentComputerName"),
+		OS:       getString(data, "agentOsType"),
+	}
+
+	var observables []Observable
+	if hash := getString(data, "fileSha256"); hash != "" {
+		observables = append(observables, Observable{Name: "file.hash.sha256", Type: "Hash", Value: hash})
+	}
+
+	return &Event{
+		CategoryUID: CategoryFindings,
+		ClassUID:    ClassDetectionFinding,
+		ActivityID:  1, // Create
+		SeverityID:  severityFromName(getString(data, "confidenceLevel")),
+		Metadata: Metadata{
+			Product: ProductInfo{Name: "Singularity", VendorName: "SentinelOne"},
+		},
+		Device:      device,
+		Observables: observables,
+		Unmapped:    remaining(data, "agentComputerName", "agentOsType", "fileSha256", "confidenceLevel"),
+	}, nil
+}
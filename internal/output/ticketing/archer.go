@@ -5,24 +5,66 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"net/http"
 	"os"
+	"sync"
 	"time"
 
 	"go.uber.org/zap"
+	"golang.org/x/time/rate"
 )
 
+// defaultArcherSessionTTL is how long ArcherProvider trusts a session token
+// before proactively re-authenticating, used when ArcherConfig.SessionTTLSeconds
+// is unset. Archer's own default session timeout is 20 minutes server-side.
+const defaultArcherSessionTTL = 15 * time.Minute
+
+const (
+	// defaultArcherBulkBatchSize is how many tickets BulkCreateTickets packs
+	// into a single /api/core/content/bulk request when ArcherConfig.BulkBatchSize
+	// is unset.
+	defaultArcherBulkBatchSize = 100
+
+	// minArcherRPS floors the AIMD backoff so a sustained run of 429/503s
+	// can't throttle the limiter down to a standstill.
+	minArcherRPS = 0.5
+
+	// archerRateRecoveryEvery is how many consecutive non-throttled
+	// responses the AIMD recovery waits for before nudging the rate back up.
+	archerRateRecoveryEvery = 20
+
+	// archerRateRecoveryStep is the fraction of ArcherConfig.RPS added back
+	// per recovery step.
+	archerRateRecoveryStep = 0.1
+)
+
+// errArcherBulkUnavailable signals that Archer's bulk content endpoint
+// isn't available on this instance, so BulkCreateTickets should fall back to
+// sequential creates.
+var errArcherBulkUnavailable = errors.New("archer: bulk content endpoint unavailable")
+
 // ArcherProvider implements the Provider interface for RSA Archer GRC
 type ArcherProvider struct {
 	instanceURL  string
 	instanceName string
 	username     string
 	password     string
-	sessionToken string
 	httpClient   *http.Client
 	logger       *zap.Logger
 	config       ArcherConfig
+	sessionTTL   time.Duration
+	baseRPS      float64
+
+	mu            sync.Mutex
+	sessionToken  string
+	tokenIssuedAt time.Time
+
+	rateMu        sync.Mutex
+	limiter       *rate.Limiter
+	consecutiveOK int
 }
 
 // ArcherConfig configures the Archer provider
@@ -33,6 +75,61 @@ type ArcherConfig struct {
 	PasswordEnv     string `yaml:"password_env"`
 	ApplicationName string `yaml:"application_name"` // e.g., "Security Incidents"
 	ApplicationID   int    `yaml:"application_id"`
+
+	// SessionTTLSeconds bounds how long a session token is trusted before
+	// ensureAuthenticated proactively re-authenticates, ahead of Archer's own
+	// server-side session timeout. Defaults to defaultArcherSessionTTL (15m).
+	SessionTTLSeconds int `yaml:"session_ttl_seconds"`
+
+	// RPS and Burst configure ArcherProvider's token-bucket rate limiter.
+	// Leave RPS unset (0) for no rate limiting. The effective rate is halved
+	// on a 429/503 response and additively recovered back toward RPS on
+	// sustained success (see archerRateRecoveryEvery/Step).
+	RPS   float64 `yaml:"rps"`
+	Burst int     `yaml:"burst"`
+
+	// BulkBatchSize is how many tickets BulkCreateTickets packs into each
+	// /api/core/content/bulk request. Defaults to defaultArcherBulkBatchSize.
+	BulkBatchSize int `yaml:"bulk_batch_size"`
+
+	// FieldMappings binds our canonical ticket fields (title, description,
+	// priority, status, source_event_id, risk_score, cves, mitre_techniques,
+	// remediation, plus category/source, or any key also present in
+	// Ticket.CustomFields) to the numeric field IDs and value lists of
+	// whichever Archer application this instance is pointed at. Without an
+	// entry for a given canonical name, that field is simply left
+	// unpopulated on create/update rather than erroring.
+	FieldMappings map[string]ArcherFieldSpec `yaml:"field_mappings"`
+}
+
+// ArcherFieldType identifies an Archer field's data type, as returned by the
+// application schema API. Archer defines many more than this, but these are
+// the ones ArcherProvider knows how to populate.
+type ArcherFieldType int
+
+const (
+	ArcherFieldTypeText       ArcherFieldType = 1
+	ArcherFieldTypeNumeric    ArcherFieldType = 2
+	ArcherFieldTypeDate       ArcherFieldType = 3
+	ArcherFieldTypeValuesList ArcherFieldType = 4
+	ArcherFieldTypeCrossRef   ArcherFieldType = 8
+)
+
+// ArcherFieldSpec describes how a single canonical ticket field maps onto a
+// field in the configured Archer application.
+type ArcherFieldSpec struct {
+	FieldID int             `yaml:"field_id"`
+	Type    ArcherFieldType `yaml:"type"`
+
+	// ValueMap translates our string values (e.g. ticket priorities,
+	// statuses, MITRE technique IDs) into the numeric value-list entry IDs
+	// Archer expects. Only meaningful when Type is ArcherFieldTypeValuesList.
+	ValueMap map[string]int `yaml:"value_map,omitempty"`
+
+	// Default is used when a value has no entry in ValueMap, instead of
+	// failing the request outright. Leave unset to require every value seen
+	// in practice to have an explicit mapping.
+	Default interface{} `yaml:"default,omitempty"`
 }
 
 // NewArcherProvider creates a new Archer provider
@@ -44,6 +141,23 @@ func NewArcherProvider(cfg ArcherConfig, logger *zap.Logger) (*ArcherProvider, e
 		return nil, fmt.Errorf("missing required Archer configuration")
 	}
 
+	sessionTTL := defaultArcherSessionTTL
+	if cfg.SessionTTLSeconds > 0 {
+		sessionTTL = time.Duration(cfg.SessionTTLSeconds) * time.Second
+	}
+
+	limit := rate.Inf
+	burst := cfg.Burst
+	if cfg.RPS > 0 {
+		limit = rate.Limit(cfg.RPS)
+		if burst <= 0 {
+			burst = int(cfg.RPS)
+		}
+	}
+	if burst <= 0 {
+		burst = 1
+	}
+
 	return &ArcherProvider{
 		instanceURL:  cfg.InstanceURL,
 		instanceName: cfg.InstanceName,
@@ -52,11 +166,30 @@ func NewArcherProvider(cfg ArcherConfig, logger *zap.Logger) (*ArcherProvider, e
 		httpClient:   &http.Client{Timeout: 30 * time.Second},
 		logger:       logger,
 		config:       cfg,
+		sessionTTL:   sessionTTL,
+		baseRPS:      cfg.RPS,
+		limiter:      rate.NewLimiter(limit, burst),
 	}, nil
 }
 
 func (p *ArcherProvider) Name() string { return "archer" }
 
+// Capabilities reports that ArcherProvider implements create/update/comment/
+// close against the real content API, with field mappings driven generically
+// by CustomFields. GetTicket and SearchTickets are not yet implemented
+// against Archer's real content and search APIs and return stub data, so
+// they're reported as unsupported.
+func (p *ArcherProvider) Capabilities() ProviderCapabilities {
+	return ProviderCapabilities{
+		CreateTicket: true,
+		UpdateTicket: true,
+		AddComment:   true,
+		CloseTicket:  true,
+		CustomFields: true,
+		BulkCreate:   true,
+	}
+}
+
 // authenticate obtains a session token from Archer
 func (p *ArcherProvider) authenticate(ctx context.Context) error {
 	url := fmt.Sprintf("%s/api/core/security/login", p.instanceURL)
@@ -101,61 +234,223 @@ func (p *ArcherProvider) authenticate(ctx context.Context) error {
 	}
 
 	p.sessionToken = result.RequestedObject.SessionToken
+	p.tokenIssuedAt = time.Now()
 	return nil
 }
 
-// ensureAuthenticated ensures we have a valid session
+// ensureAuthenticated ensures we have a valid, not-yet-expired session.
+// Callers must not hold p.mu.
 func (p *ArcherProvider) ensureAuthenticated(ctx context.Context) error {
-	if p.sessionToken == "" {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.sessionToken == "" || time.Since(p.tokenIssuedAt) >= p.sessionTTL {
 		return p.authenticate(ctx)
 	}
 	return nil
 }
 
-// CreateTicket creates a new record in Archer
-func (p *ArcherProvider) CreateTicket(ctx context.Context, ticket *Ticket) (*TicketResult, error) {
+// doAuthenticated sends an authenticated request built from method/url/body,
+// buffering body up front so it can be re-sent unmodified if the first
+// attempt comes back 401: Archer sessions expire server-side faster than
+// SessionTTL can always catch (e.g. an admin revokes the session), so every
+// authenticated API call routes through here rather than assuming
+// ensureAuthenticated's proactive check was enough. Only one re-auth runs at
+// a time; concurrent callers serialize on p.mu and the losers simply reuse
+// the session the winner obtained.
+func (p *ArcherProvider) doAuthenticated(ctx context.Context, method, url string, body []byte) (*http.Response, error) {
 	if err := p.ensureAuthenticated(ctx); err != nil {
 		return nil, fmt.Errorf("authenticating: %w", err)
 	}
 
-	url := fmt.Sprintf("%s/api/core/content", p.instanceURL)
+	p.mu.Lock()
+	sentToken := p.sessionToken
+	p.mu.Unlock()
 
-	// Build Archer record content
-	// Field IDs would be configured based on the specific Archer application
-	content := map[string]interface{}{
-		"Content": map[string]interface{}{
-			"LevelId": p.config.ApplicationID,
-			"FieldContents": map[string]interface{}{
-				// These field IDs are examples - would need to be configured
-				"1001": map[string]interface{}{"Type": 1, "Value": ticket.Title},           // Title
-				"1002": map[string]interface{}{"Type": 1, "Value": ticket.Description},     // Description
-				"1003": map[string]interface{}{"Type": 4, "Value": []int{p.mapPriorityToArcher(ticket.Priority)}}, // Priority (value list)
-				"1004": map[string]interface{}{"Type": 1, "Value": ticket.Category},        // Category
-				"1005": map[string]interface{}{"Type": 1, "Value": ticket.Source},          // Source
-				"1006": map[string]interface{}{"Type": 1, "Value": ticket.SourceEventID},   // Source Event ID
-				"1007": map[string]interface{}{"Type": 2, "Value": ticket.RiskScore},       // Risk Score (numeric)
-			},
-		},
+	resp, err := p.sendAuthenticated(ctx, method, url, body)
+	if err != nil {
+		return nil, err
 	}
 
-	body, err := json.Marshal(content)
-	if err != nil {
-		return nil, fmt.Errorf("marshaling content: %w", err)
+	if resp.StatusCode != http.StatusUnauthorized {
+		return resp, nil
 	}
+	resp.Body.Close()
 
-	req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewBuffer(body))
+	// Re-login only if no other caller has already replaced the session
+	// that was rejected.
+	var authErr error
+	p.mu.Lock()
+	if p.sessionToken == sentToken {
+		p.sessionToken = ""
+		authErr = p.authenticate(ctx)
+	}
+	p.mu.Unlock()
+	if authErr != nil {
+		return nil, fmt.Errorf("re-authenticating after session expiry: %w", authErr)
+	}
+
+	return p.sendAuthenticated(ctx, method, url, body)
+}
+
+// sendAuthenticated builds and sends a single request carrying the current
+// session token, subject to the rate limiter, and feeds the response status
+// back into the AIMD backoff and archer_requests_total. body may be nil for
+// GET requests.
+func (p *ArcherProvider) sendAuthenticated(ctx context.Context, method, url string, body []byte) (*http.Response, error) {
+	if err := p.limiter.Wait(ctx); err != nil {
+		return nil, fmt.Errorf("rate limiter: %w", err)
+	}
+
+	var reader io.Reader
+	if body != nil {
+		reader = bytes.NewReader(body)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, url, reader)
 	if err != nil {
 		return nil, fmt.Errorf("creating request: %w", err)
 	}
 
-	req.Header.Set("Content-Type", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
 	req.Header.Set("Accept", "application/json")
-	req.Header.Set("Authorization", "Archer session-id="+p.sessionToken)
+
+	p.mu.Lock()
+	token := p.sessionToken
+	p.mu.Unlock()
+	req.Header.Set("Authorization", "Archer session-id="+token)
 
 	resp, err := p.httpClient.Do(req)
 	if err != nil {
+		archerRequestsTotal.WithLabelValues("error").Inc()
 		return nil, fmt.Errorf("making request: %w", err)
 	}
+
+	archerRequestsTotal.WithLabelValues(fmt.Sprintf("%d", resp.StatusCode)).Inc()
+	p.recordRateSignal(resp.StatusCode)
+
+	return resp, nil
+}
+
+// recordRateSignal implements the AIMD half of the rate limiter: a 429/503
+// halves the effective rate (floored at minArcherRPS), and
+// archerRateRecoveryEvery consecutive non-throttled responses nudge it back
+// up by archerRateRecoveryStep of the configured RPS, capped at RPS. A no-op
+// when RPS wasn't configured, since there's no base rate to recover toward.
+func (p *ArcherProvider) recordRateSignal(statusCode int) {
+	if p.baseRPS <= 0 {
+		return
+	}
+
+	p.rateMu.Lock()
+	defer p.rateMu.Unlock()
+
+	if statusCode == http.StatusTooManyRequests || statusCode == http.StatusServiceUnavailable {
+		next := float64(p.limiter.Limit()) / 2
+		if next < minArcherRPS {
+			next = minArcherRPS
+		}
+		p.limiter.SetLimit(rate.Limit(next))
+		p.consecutiveOK = 0
+		p.logger.Warn("Archer rate limit backoff engaged",
+			zap.Int("status", statusCode),
+			zap.Float64("new_rps", next),
+		)
+		return
+	}
+
+	p.consecutiveOK++
+	if p.consecutiveOK < archerRateRecoveryEvery {
+		return
+	}
+	p.consecutiveOK = 0
+
+	next := float64(p.limiter.Limit()) + p.baseRPS*archerRateRecoveryStep
+	if next > p.baseRPS {
+		next = p.baseRPS
+	}
+	p.limiter.SetLimit(rate.Limit(next))
+}
+
+// ValidateFieldMappings fetches the configured application's field schema
+// and checks that every FieldID in ArcherConfig.FieldMappings exists and has
+// the expected ArcherFieldType. Operators should call this once at startup,
+// after constructing the provider, so a misconfigured field mapping (wrong
+// application, stale field ID after an Archer admin renumbers fields, typo'd
+// Type) surfaces immediately instead of as a create/update failure in
+// production.
+func (p *ArcherProvider) ValidateFieldMappings(ctx context.Context) error {
+	url := fmt.Sprintf("%s/api/core/system/application/%d/field", p.instanceURL, p.config.ApplicationID)
+
+	resp, err := p.doAuthenticated(ctx, "GET", url, nil)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("fetching application schema: API returned status %d", resp.StatusCode)
+	}
+
+	var schemaFields []struct {
+		RequestedObject struct {
+			Id   int `json:"Id"`
+			Type int `json:"Type"`
+		} `json:"RequestedObject"`
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(&schemaFields); err != nil {
+		return fmt.Errorf("decoding application schema: %w", err)
+	}
+
+	fieldTypes := make(map[int]int, len(schemaFields))
+	for _, field := range schemaFields {
+		fieldTypes[field.RequestedObject.Id] = field.RequestedObject.Type
+	}
+
+	for canonical, spec := range p.config.FieldMappings {
+		actualType, ok := fieldTypes[spec.FieldID]
+		if !ok {
+			return fmt.Errorf("archer: field mapping %q references field id %d, which does not exist in application %d",
+				canonical, spec.FieldID, p.config.ApplicationID)
+		}
+		if actualType != int(spec.Type) {
+			return fmt.Errorf("archer: field mapping %q expects field id %d to have type %d, but the application schema reports type %d",
+				canonical, spec.FieldID, spec.Type, actualType)
+		}
+	}
+
+	return nil
+}
+
+// CreateTicket creates a new record in Archer
+func (p *ArcherProvider) CreateTicket(ctx context.Context, ticket *Ticket) (*TicketResult, error) {
+	fieldContents, err := p.buildFieldContents(func(canonical string) (interface{}, bool) {
+		return ticketFieldValue(ticket, canonical)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	url := fmt.Sprintf("%s/api/core/content", p.instanceURL)
+	content := map[string]interface{}{
+		"Content": map[string]interface{}{
+			"LevelId":       p.config.ApplicationID,
+			"FieldContents": fieldContents,
+		},
+	}
+
+	body, err := json.Marshal(content)
+	if err != nil {
+		return nil, fmt.Errorf("marshaling content: %w", err)
+	}
+
+	resp, err := p.doAuthenticated(ctx, "POST", url, body)
+	if err != nil {
+		return nil, err
+	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusOK {
@@ -187,30 +482,126 @@ func (p *ArcherProvider) CreateTicket(ctx context.Context, ticket *Ticket) (*Tic
 	}, nil
 }
 
-// UpdateTicket updates an existing Archer record
-func (p *ArcherProvider) UpdateTicket(ctx context.Context, ticketID string, update *TicketUpdate) error {
-	if err := p.ensureAuthenticated(ctx); err != nil {
-		return fmt.Errorf("authenticating: %w", err)
+// BulkCreateTickets creates tickets in batches of ArcherConfig.BulkBatchSize
+// (default defaultArcherBulkBatchSize) via /api/core/content/bulk. If that
+// endpoint isn't available on this Archer instance (a 404, e.g. an older
+// version), it falls back to sequential CreateTicket calls for the remainder
+// of the batch rather than failing outright.
+func (p *ArcherProvider) BulkCreateTickets(ctx context.Context, tickets []*Ticket) ([]*TicketResult, error) {
+	if len(tickets) == 0 {
+		return nil, nil
 	}
 
-	url := fmt.Sprintf("%s/api/core/content/%s", p.instanceURL, ticketID)
+	batchSize := p.config.BulkBatchSize
+	if batchSize <= 0 {
+		batchSize = defaultArcherBulkBatchSize
+	}
 
-	fieldContents := make(map[string]interface{})
+	results := make([]*TicketResult, 0, len(tickets))
+	for start := 0; start < len(tickets); start += batchSize {
+		end := start + batchSize
+		if end > len(tickets) {
+			end = len(tickets)
+		}
+		batch := tickets[start:end]
+
+		batchResults, err := p.bulkCreateBatch(ctx, batch)
+		if errors.Is(err, errArcherBulkUnavailable) {
+			p.logger.Warn("Archer bulk content endpoint unavailable, falling back to sequential creates",
+				zap.Int("remaining", len(tickets)-start),
+			)
+			fallback, _ := sequentialBulkCreate(ctx, p, tickets[start:])
+			return append(results, fallback...), nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, batchResults...)
+	}
 
-	if update.Status != "" {
-		fieldContents["1008"] = map[string]interface{}{
-			"Type":  4,
-			"Value": []int{p.mapStatusToArcher(update.Status)},
+	return results, nil
+}
+
+// bulkCreateBatch sends a single /api/core/content/bulk request for batch and
+// maps the response positionally onto a TicketResult per input ticket.
+func (p *ArcherProvider) bulkCreateBatch(ctx context.Context, batch []*Ticket) ([]*TicketResult, error) {
+	archerBulkBatchSize.Observe(float64(len(batch)))
+
+	items := make([]map[string]interface{}, 0, len(batch))
+	for _, ticket := range batch {
+		fieldContents, err := p.buildFieldContents(func(canonical string) (interface{}, bool) {
+			return ticketFieldValue(ticket, canonical)
+		})
+		if err != nil {
+			return nil, err
 		}
+		items = append(items, map[string]interface{}{
+			"LevelId":       p.config.ApplicationID,
+			"FieldContents": fieldContents,
+		})
+	}
+
+	url := fmt.Sprintf("%s/api/core/content/bulk", p.instanceURL)
+	body, err := json.Marshal(map[string]interface{}{"Content": items})
+	if err != nil {
+		return nil, fmt.Errorf("marshaling bulk content: %w", err)
+	}
+
+	resp, err := p.doAuthenticated(ctx, "POST", url, body)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, errArcherBulkUnavailable
+	}
+	if resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("API returned status %d", resp.StatusCode)
+	}
+
+	var result struct {
+		RequestedObject []struct {
+			Id                int    `json:"Id"`
+			IsSuccessful      bool   `json:"IsSuccessful"`
+			ValidationMessage string `json:"ValidationMessage"`
+		} `json:"RequestedObject"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("decoding bulk response: %w", err)
+	}
+	if len(result.RequestedObject) != len(batch) {
+		return nil, fmt.Errorf("bulk response returned %d results for %d tickets", len(result.RequestedObject), len(batch))
 	}
 
-	if update.Priority != "" {
-		fieldContents["1003"] = map[string]interface{}{
-			"Type":  4,
-			"Value": []int{p.mapPriorityToArcher(update.Priority)},
+	results := make([]*TicketResult, len(batch))
+	for i, item := range result.RequestedObject {
+		if !item.IsSuccessful {
+			results[i] = &TicketResult{Error: item.ValidationMessage}
+			continue
+		}
+		recordID := fmt.Sprintf("%d", item.Id)
+		results[i] = &TicketResult{
+			TicketID:   recordID,
+			ExternalID: recordID,
+			TicketURL: fmt.Sprintf("%s/apps/ArcherApp/Default.aspx#record/%d/%s",
+				p.instanceURL, p.config.ApplicationID, recordID),
 		}
 	}
 
+	return results, nil
+}
+
+// UpdateTicket updates an existing Archer record
+func (p *ArcherProvider) UpdateTicket(ctx context.Context, ticketID string, update *TicketUpdate) error {
+	fieldContents, err := p.buildFieldContents(func(canonical string) (interface{}, bool) {
+		return ticketUpdateFieldValue(update, canonical)
+	})
+	if err != nil {
+		return err
+	}
+
+	url := fmt.Sprintf("%s/api/core/content/%s", p.instanceURL, ticketID)
 	content := map[string]interface{}{
 		"Content": map[string]interface{}{
 			"Id":            ticketID,
@@ -224,18 +615,9 @@ func (p *ArcherProvider) UpdateTicket(ctx context.Context, ticketID string, upda
 		return fmt.Errorf("marshaling content: %w", err)
 	}
 
-	req, err := http.NewRequestWithContext(ctx, "PUT", url, bytes.NewBuffer(body))
+	resp, err := p.doAuthenticated(ctx, "PUT", url, body)
 	if err != nil {
-		return fmt.Errorf("creating request: %w", err)
-	}
-
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("Accept", "application/json")
-	req.Header.Set("Authorization", "Archer session-id="+p.sessionToken)
-
-	resp, err := p.httpClient.Do(req)
-	if err != nil {
-		return fmt.Errorf("making request: %w", err)
+		return err
 	}
 	defer resp.Body.Close()
 
@@ -248,23 +630,11 @@ func (p *ArcherProvider) UpdateTicket(ctx context.Context, ticketID string, upda
 
 // GetTicket retrieves a record by ID
 func (p *ArcherProvider) GetTicket(ctx context.Context, ticketID string) (*Ticket, error) {
-	if err := p.ensureAuthenticated(ctx); err != nil {
-		return nil, fmt.Errorf("authenticating: %w", err)
-	}
-
 	url := fmt.Sprintf("%s/api/core/content/%s", p.instanceURL, ticketID)
 
-	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
-	if err != nil {
-		return nil, fmt.Errorf("creating request: %w", err)
-	}
-
-	req.Header.Set("Accept", "application/json")
-	req.Header.Set("Authorization", "Archer session-id="+p.sessionToken)
-
-	resp, err := p.httpClient.Do(req)
+	resp, err := p.doAuthenticated(ctx, "GET", url, nil)
 	if err != nil {
-		return nil, fmt.Errorf("making request: %w", err)
+		return nil, err
 	}
 	defer resp.Body.Close()
 
@@ -281,45 +651,36 @@ func (p *ArcherProvider) GetTicket(ctx context.Context, ticketID string) (*Ticke
 
 // SearchTickets searches for records
 func (p *ArcherProvider) SearchTickets(ctx context.Context, query TicketQuery) ([]*Ticket, error) {
-	if err := p.ensureAuthenticated(ctx); err != nil {
-		return nil, fmt.Errorf("authenticating: %w", err)
-	}
-
 	// Archer uses a different search API
 	url := fmt.Sprintf("%s/api/core/content/search", p.instanceURL)
 
-	searchCriteria := map[string]interface{}{
-		"LevelId": p.config.ApplicationID,
-		"Filters": []map[string]interface{}{},
-	}
+	filters := []map[string]interface{}{}
 
 	if query.SourceEventID != "" {
-		searchCriteria["Filters"] = append(searchCriteria["Filters"].([]map[string]interface{}),
-			map[string]interface{}{
-				"Operator":    "Equals",
-				"FieldId":     1006, // Source Event ID field
-				"FilterValue": query.SourceEventID,
-			},
-		)
+		spec, ok := p.config.FieldMappings["source_event_id"]
+		if !ok {
+			return nil, fmt.Errorf("archer: no field mapping configured for \"source_event_id\"")
+		}
+		filters = append(filters, map[string]interface{}{
+			"Operator":    "Equals",
+			"FieldId":     spec.FieldID,
+			"FilterValue": query.SourceEventID,
+		})
 	}
 
-	body, err := json.Marshal(searchCriteria)
-	if err != nil {
-		return nil, fmt.Errorf("marshaling search: %w", err)
+	searchCriteria := map[string]interface{}{
+		"LevelId": p.config.ApplicationID,
+		"Filters": filters,
 	}
 
-	req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewBuffer(body))
+	body, err := json.Marshal(searchCriteria)
 	if err != nil {
-		return nil, fmt.Errorf("creating request: %w", err)
+		return nil, fmt.Errorf("marshaling search: %w", err)
 	}
 
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("Accept", "application/json")
-	req.Header.Set("Authorization", "Archer session-id="+p.sessionToken)
-
-	resp, err := p.httpClient.Do(req)
+	resp, err := p.doAuthenticated(ctx, "POST", url, body)
 	if err != nil {
-		return nil, fmt.Errorf("making request: %w", err)
+		return nil, err
 	}
 	defer resp.Body.Close()
 
@@ -352,31 +713,157 @@ func (p *ArcherProvider) CloseTicket(ctx context.Context, ticketID string, resol
 	})
 }
 
-// Mapping functions - these would be configured based on actual Archer value lists
-func (p *ArcherProvider) mapPriorityToArcher(priority string) int {
-	mapping := map[string]int{
-		"critical": 1,
-		"high":     2,
-		"medium":   3,
-		"low":      4,
+// buildFieldContents walks ArcherConfig.FieldMappings and, for every
+// canonical name that valueOf resolves to a present value, coerces that
+// value into Archer's {"Type": N, "Value": ...} field envelope, keyed by the
+// field's numeric ID. Canonical names with no mapping configured, or no
+// value present on this request, are simply omitted.
+func (p *ArcherProvider) buildFieldContents(valueOf func(canonical string) (interface{}, bool)) (map[string]interface{}, error) {
+	fieldContents := make(map[string]interface{})
+
+	for canonical, spec := range p.config.FieldMappings {
+		value, ok := valueOf(canonical)
+		if !ok {
+			continue
+		}
+
+		envelope, err := archerFieldEnvelope(spec, value)
+		if err != nil {
+			return nil, fmt.Errorf("mapping field %q: %w", canonical, err)
+		}
+
+		fieldContents[fmt.Sprintf("%d", spec.FieldID)] = envelope
 	}
-	if v, ok := mapping[priority]; ok {
-		return v
+
+	return fieldContents, nil
+}
+
+// ticketFieldValue resolves canonical's value on ticket, falling back to
+// ticket.CustomFields for any canonical name that isn't a dedicated Ticket
+// field.
+func ticketFieldValue(ticket *Ticket, canonical string) (interface{}, bool) {
+	switch canonical {
+	case "title":
+		return ticket.Title, true
+	case "description":
+		return ticket.Description, true
+	case "priority":
+		return ticket.Priority, true
+	case "status":
+		return ticket.Status, true
+	case "category":
+		return ticket.Category, true
+	case "source":
+		return ticket.Source, true
+	case "source_event_id":
+		return ticket.SourceEventID, true
+	case "risk_score":
+		return ticket.RiskScore, true
+	case "cves":
+		value, ok := ticket.CustomFields["cves"]
+		return value, ok
+	case "mitre_techniques":
+		return ticket.MITRETechniques, true
+	case "remediation":
+		return ticket.Remediation, true
+	default:
+		value, ok := ticket.CustomFields[canonical]
+		return value, ok
 	}
-	return 3
 }
 
-func (p *ArcherProvider) mapStatusToArcher(status string) int {
-	mapping := map[string]int{
-		"new":         1,
-		"in_progress": 2,
-		"on_hold":     3,
-		"resolved":    4,
-		"closed":      5,
+// ticketUpdateFieldValue resolves canonical's value on update, falling back
+// to update.CustomFields for any canonical name that isn't a dedicated
+// TicketUpdate field. Empty strings are treated as "not set" so a partial
+// update doesn't clobber fields the caller didn't intend to touch.
+func ticketUpdateFieldValue(update *TicketUpdate, canonical string) (interface{}, bool) {
+	switch canonical {
+	case "status":
+		return update.Status, update.Status != ""
+	case "priority":
+		return update.Priority, update.Priority != ""
+	case "description":
+		return update.Description, update.Description != ""
+	default:
+		value, ok := update.CustomFields[canonical]
+		return value, ok
 	}
-	if v, ok := mapping[status]; ok {
-		return v
+}
+
+// archerFieldEnvelope coerces value into the {"Type": N, "Value": ...}
+// envelope Archer's content API expects for spec's field type.
+func archerFieldEnvelope(spec ArcherFieldSpec, value interface{}) (interface{}, error) {
+	switch spec.Type {
+	case ArcherFieldTypeValuesList:
+		ids, err := archerValuesListIDs(spec, value)
+		if err != nil {
+			return nil, err
+		}
+		return map[string]interface{}{"Type": int(spec.Type), "Value": ids}, nil
+	case ArcherFieldTypeNumeric:
+		n, ok := toFloat(value)
+		if !ok {
+			return nil, fmt.Errorf("expected a numeric value, got %T", value)
+		}
+		return map[string]interface{}{"Type": int(spec.Type), "Value": n}, nil
+	default:
+		return map[string]interface{}{"Type": int(spec.Type), "Value": fmt.Sprintf("%v", value)}, nil
 	}
-	return 1
 }
 
+// archerValuesListIDs maps a string or []string value through spec.ValueMap,
+// falling back to spec.Default for any entry ValueMap doesn't cover.
+func archerValuesListIDs(spec ArcherFieldSpec, value interface{}) ([]int, error) {
+	var keys []string
+	switch v := value.(type) {
+	case string:
+		keys = []string{v}
+	case []string:
+		keys = v
+	default:
+		return nil, fmt.Errorf("values-list field expects a string or []string, got %T", value)
+	}
+
+	ids := make([]int, 0, len(keys))
+	for _, key := range keys {
+		if id, ok := spec.ValueMap[key]; ok {
+			ids = append(ids, id)
+			continue
+		}
+		if spec.Default != nil {
+			if id, ok := toInt(spec.Default); ok {
+				ids = append(ids, id)
+				continue
+			}
+		}
+		return nil, fmt.Errorf("no value-list mapping for %q", key)
+	}
+
+	return ids, nil
+}
+
+func toFloat(v interface{}) (float64, bool) {
+	switch n := v.(type) {
+	case float64:
+		return n, true
+	case int:
+		return float64(n), true
+	case int64:
+		return float64(n), true
+	default:
+		return 0, false
+	}
+}
+
+func toInt(v interface{}) (int, bool) {
+	switch n := v.(type) {
+	case int:
+		return n, true
+	case int64:
+		return int(n), true
+	case float64:
+		return int(n), true
+	default:
+		return 0, false
+	}
+}
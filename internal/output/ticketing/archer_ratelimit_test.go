@@ -0,0 +1,142 @@
+package ticketing
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"go.uber.org/zap"
+	"golang.org/x/time/rate"
+)
+
+// newTestArcherProviderWithRPS is like newTestArcherProvider but configures
+// the AIMD rate limiter, since recordRateSignal is a no-op when baseRPS is 0.
+func newTestArcherProviderWithRPS(t *testing.T, rps float64) *ArcherProvider {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(server.Close)
+
+	usernameEnv := "ARCHER_TEST_USER_" + t.Name()
+	passwordEnv := "ARCHER_TEST_PASS_" + t.Name()
+	t.Setenv(usernameEnv, "tester")
+	t.Setenv(passwordEnv, "secret")
+
+	p, err := NewArcherProvider(ArcherConfig{
+		InstanceURL: server.URL,
+		UsernameEnv: usernameEnv,
+		PasswordEnv: passwordEnv,
+		RPS:         rps,
+	}, zap.NewNop())
+	if err != nil {
+		t.Fatalf("NewArcherProvider: %v", err)
+	}
+	return p
+}
+
+// TestRecordRateSignalHalvesRateOn429 exercises the multiplicative-decrease
+// half of the AIMD limiter.
+func TestRecordRateSignalHalvesRateOn429(t *testing.T) {
+	p := newTestArcherProviderWithRPS(t, 10)
+
+	p.recordRateSignal(http.StatusTooManyRequests)
+
+	if got := float64(p.limiter.Limit()); got != 5 {
+		t.Errorf("limit after one 429 = %v, want 5", got)
+	}
+}
+
+// TestRecordRateSignalFloorsAtMinArcherRPS confirms repeated throttling never
+// drives the rate below minArcherRPS, so a sustained run of 429/503s can't
+// throttle the limiter down to a standstill.
+func TestRecordRateSignalFloorsAtMinArcherRPS(t *testing.T) {
+	p := newTestArcherProviderWithRPS(t, 10)
+
+	for i := 0; i < 10; i++ {
+		p.recordRateSignal(http.StatusServiceUnavailable)
+	}
+
+	if got := float64(p.limiter.Limit()); got != minArcherRPS {
+		t.Errorf("limit after repeated 503s = %v, want floor %v", got, minArcherRPS)
+	}
+}
+
+// TestRecordRateSignalRecoversAfterConsecutiveOK confirms the additive-
+// increase half: after a 429 halves the rate, archerRateRecoveryEvery
+// consecutive non-throttled responses nudge it back up by
+// archerRateRecoveryStep of the base RPS, not all the way back at once.
+func TestRecordRateSignalRecoversAfterConsecutiveOK(t *testing.T) {
+	p := newTestArcherProviderWithRPS(t, 10)
+
+	p.recordRateSignal(http.StatusTooManyRequests)
+	if got := float64(p.limiter.Limit()); got != 5 {
+		t.Fatalf("limit after 429 = %v, want 5", got)
+	}
+
+	for i := 0; i < archerRateRecoveryEvery-1; i++ {
+		p.recordRateSignal(http.StatusOK)
+	}
+	if got := float64(p.limiter.Limit()); got != 5 {
+		t.Fatalf("limit after %d OKs = %v, want unchanged 5 until the %dth", archerRateRecoveryEvery-1, got, archerRateRecoveryEvery)
+	}
+
+	p.recordRateSignal(http.StatusOK)
+	if got := float64(p.limiter.Limit()); got != 6 {
+		t.Errorf("limit after %d consecutive OKs = %v, want 6 (5 + 10*0.1)", archerRateRecoveryEvery, got)
+	}
+}
+
+// TestRecordRateSignalRecoveryCapsAtBaseRPS confirms additive recovery never
+// overshoots the configured RPS.
+func TestRecordRateSignalRecoveryCapsAtBaseRPS(t *testing.T) {
+	p := newTestArcherProviderWithRPS(t, 10)
+	p.recordRateSignal(http.StatusTooManyRequests) // limit -> 5
+
+	// Recover well past the point a naive implementation would overshoot 10.
+	for i := 0; i < archerRateRecoveryEvery*20; i++ {
+		p.recordRateSignal(http.StatusOK)
+	}
+
+	if got := float64(p.limiter.Limit()); got != 10 {
+		t.Errorf("limit after extensive recovery = %v, want capped at base RPS 10", got)
+	}
+}
+
+// TestRecordRateSignalResetsRecoveryCounterOnThrottle confirms a 429/503
+// mid-recovery resets the consecutive-OK counter, so a handful of OKs right
+// before a fresh throttle don't count toward the next recovery step.
+func TestRecordRateSignalResetsRecoveryCounterOnThrottle(t *testing.T) {
+	p := newTestArcherProviderWithRPS(t, 10)
+	p.recordRateSignal(http.StatusTooManyRequests) // limit -> 5
+
+	for i := 0; i < archerRateRecoveryEvery-1; i++ {
+		p.recordRateSignal(http.StatusOK)
+	}
+	p.recordRateSignal(http.StatusTooManyRequests) // limit -> 2.5, resets counter
+
+	if got := float64(p.limiter.Limit()); got != 2.5 {
+		t.Fatalf("limit after second 429 = %v, want 2.5", got)
+	}
+
+	for i := 0; i < archerRateRecoveryEvery-1; i++ {
+		p.recordRateSignal(http.StatusOK)
+	}
+	if got := float64(p.limiter.Limit()); got != 2.5 {
+		t.Errorf("limit after %d OKs post-reset = %v, want still 2.5", archerRateRecoveryEvery-1, got)
+	}
+}
+
+// TestRecordRateSignalNoopWithoutConfiguredRPS confirms recordRateSignal
+// leaves an rate.Inf limiter untouched when RPS wasn't configured, since
+// there's no base rate to back off from or recover toward.
+func TestRecordRateSignalNoopWithoutConfiguredRPS(t *testing.T) {
+	p := newTestArcherProviderWithRPS(t, 0)
+
+	p.recordRateSignal(http.StatusTooManyRequests)
+
+	if got := p.limiter.Limit(); got != rate.Inf {
+		t.Errorf("limit after 429 with no configured RPS = %v, want unchanged Inf", got)
+	}
+}
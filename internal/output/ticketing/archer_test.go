@@ -0,0 +1,126 @@
+package ticketing
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"go.uber.org/zap"
+)
+
+// newTestArcherProvider builds an ArcherProvider pointed at server, with
+// username/password sourced from env vars unique to this test so parallel
+// tests don't clobber each other's os.Setenv calls.
+func newTestArcherProvider(t *testing.T, server *httptest.Server) *ArcherProvider {
+	t.Helper()
+
+	usernameEnv := fmt.Sprintf("ARCHER_TEST_USER_%s", t.Name())
+	passwordEnv := fmt.Sprintf("ARCHER_TEST_PASS_%s", t.Name())
+	t.Setenv(usernameEnv, "tester")
+	t.Setenv(passwordEnv, "secret")
+
+	p, err := NewArcherProvider(ArcherConfig{
+		InstanceURL: server.URL,
+		UsernameEnv: usernameEnv,
+		PasswordEnv: passwordEnv,
+	}, zap.NewNop())
+	if err != nil {
+		t.Fatalf("NewArcherProvider: %v", err)
+	}
+	return p
+}
+
+func loginResponse(token string) string {
+	return fmt.Sprintf(`{"RequestedObject":{"SessionToken":%q}}`, token)
+}
+
+// TestArcherDoAuthenticatedRetriesAfter401 exercises doAuthenticated's
+// session-expiry path: the first API call comes back 401 (e.g. Archer
+// revoked the session server-side, ahead of our own SessionTTL), and
+// doAuthenticated must transparently re-login and retry once before
+// returning to the caller.
+func TestArcherDoAuthenticatedRetriesAfter401(t *testing.T) {
+	var logins int32
+	var apiCalls int32
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/core/security/login", func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&logins, 1)
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprint(w, loginResponse(fmt.Sprintf("token-%d", n)))
+	})
+	mux.HandleFunc("/api/core/content", func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&apiCalls, 1) == 1 {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	p := newTestArcherProvider(t, server)
+
+	resp, err := p.doAuthenticated(context.Background(), "GET", server.URL+"/api/core/content", nil)
+	if err != nil {
+		t.Fatalf("doAuthenticated: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("final response status = %d, want %d", resp.StatusCode, http.StatusOK)
+	}
+	if got := atomic.LoadInt32(&logins); got != 2 {
+		t.Errorf("logins = %d, want 2 (initial + re-auth after 401)", got)
+	}
+	if got := atomic.LoadInt32(&apiCalls); got != 2 {
+		t.Errorf("apiCalls = %d, want 2 (401 then retry)", got)
+	}
+}
+
+// TestArcherDoAuthenticatedConcurrentCallersShareSession confirms that
+// concurrent callers serialize on ensureAuthenticated's lock instead of
+// each independently logging in: only the first should ever hit
+// /api/core/security/login, and every other goroutine reuses the session
+// it obtained.
+func TestArcherDoAuthenticatedConcurrentCallersShareSession(t *testing.T) {
+	var logins int32
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/core/security/login", func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&logins, 1)
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprint(w, loginResponse("shared-token"))
+	})
+	mux.HandleFunc("/api/core/content", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	p := newTestArcherProvider(t, server)
+
+	const callers = 20
+	var wg sync.WaitGroup
+	wg.Add(callers)
+	for i := 0; i < callers; i++ {
+		go func() {
+			defer wg.Done()
+			resp, err := p.doAuthenticated(context.Background(), "GET", server.URL+"/api/core/content", nil)
+			if err != nil {
+				t.Errorf("doAuthenticated: %v", err)
+				return
+			}
+			resp.Body.Close()
+		}()
+	}
+	wg.Wait()
+
+	if got := atomic.LoadInt32(&logins); got != 1 {
+		t.Errorf("logins = %d, want exactly 1 across %d concurrent callers", got, callers)
+	}
+}
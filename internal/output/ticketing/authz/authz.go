@@ -0,0 +1,53 @@
+package authz
+
+import (
+	"fmt"
+
+	"go.uber.org/zap"
+)
+
+// Authorizer gates ticket creation behind JWT authentication followed by
+// intention-rule evaluation, logging every decision for audit.
+type Authorizer struct {
+	verifier   *Verifier
+	intentions *IntentionSet
+	logger     *zap.Logger
+}
+
+// NewAuthorizer creates an Authorizer. verifier validates the inbound JWT;
+// intentions decides whether the authenticated caller may create this
+// particular ticket.
+func NewAuthorizer(verifier *Verifier, intentions *IntentionSet, logger *zap.Logger) *Authorizer {
+	return &Authorizer{verifier: verifier, intentions: intentions, logger: logger}
+}
+
+// Authorize verifies token, then evaluates req's tenant (taken from the
+// token's claims, not req.Tenant, so a caller can't forge a tenant it
+// wasn't issued) against the intention ruleset. It returns the verified
+// claims and the resulting Decision, and always logs the outcome - allow
+// or deny - as a structured audit entry.
+func (a *Authorizer) Authorize(token string, req Request) (*Claims, Decision, error) {
+	claims, err := a.verifier.Verify(token)
+	if err != nil {
+		a.logger.Warn("ticket authorization denied: invalid token",
+			zap.Error(err),
+		)
+		return nil, Decision{Allow: false, MatchedRule: -1}, fmt.Errorf("authz: %w", err)
+	}
+
+	req.Tenant = claims.Tenant
+	decision := a.intentions.Evaluate(req)
+
+	a.logger.Info("ticket authorization decision",
+		zap.Bool("allow", decision.Allow),
+		zap.String("sub", claims.Subject),
+		zap.Strings("groups", claims.Groups),
+		zap.String("tenant", claims.Tenant),
+		zap.String("source_type", req.SourceType),
+		zap.String("mitre_tactic", req.MITRETactic),
+		zap.String("risk_level", req.RiskLevel),
+		zap.Int("matched_rule", decision.MatchedRule),
+	)
+
+	return claims, decision, nil
+}
@@ -0,0 +1,103 @@
+package authz
+
+import (
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+func TestIntentionSetFirstMatchWins(t *testing.T) {
+	rules := []Intention{
+		{SourceType: "crowdsec", RiskLevel: "low", Effect: "deny"},
+		{SourceType: "crowdsec", Effect: "allow", AssignmentGroup: "Network Security"},
+		{SourceType: "*", Effect: "allow", AssignmentGroup: "Security Operations"},
+	}
+	set := NewIntentionSet(rules)
+
+	decision := set.Evaluate(Request{SourceType: "crowdsec", RiskLevel: "high"})
+	if !decision.Allow || decision.MatchedRule != 1 || decision.AssignmentGroup != "Network Security" {
+		t.Errorf("decision = %+v, want rule 1 (allow, Network Security)", decision)
+	}
+}
+
+func TestIntentionSetDenyByDefault(t *testing.T) {
+	set := NewIntentionSet([]Intention{
+		{SourceType: "crowdsec", Effect: "allow"},
+	})
+
+	decision := set.Evaluate(Request{SourceType: "servicenow"})
+	if decision.Allow || decision.MatchedRule != -1 {
+		t.Errorf("decision = %+v, want deny-by-default", decision)
+	}
+}
+
+func TestIntentionSetWildcardFields(t *testing.T) {
+	set := NewIntentionSet([]Intention{
+		{SourceType: "*", MITRETactic: "*", RiskLevel: "*", Tenant: "*", Effect: "allow"},
+	})
+
+	decision := set.Evaluate(Request{SourceType: "anything", MITRETactic: "exfiltration", RiskLevel: "critical", Tenant: "acme"})
+	if !decision.Allow || decision.MatchedRule != 0 {
+		t.Errorf("decision = %+v, want rule 0 to match every field via wildcard", decision)
+	}
+}
+
+func TestIntentionSetAssetCIDRMatch(t *testing.T) {
+	set := NewIntentionSet([]Intention{
+		{SourceType: "*", AssetCIDRs: []string{"10.0.0.0/8"}, Effect: "deny"},
+		{SourceType: "*", Effect: "allow"},
+	})
+
+	denied := set.Evaluate(Request{AffectedAssets: []string{"10.1.2.3"}})
+	if denied.Allow || denied.MatchedRule != 0 {
+		t.Errorf("decision for an in-CIDR asset = %+v, want rule 0 (deny)", denied)
+	}
+
+	allowed := set.Evaluate(Request{AffectedAssets: []string{"192.168.1.1"}})
+	if !allowed.Allow || allowed.MatchedRule != 1 {
+		t.Errorf("decision for an out-of-CIDR asset = %+v, want rule 1 (allow)", allowed)
+	}
+}
+
+func TestAuthorizerUsesTenantFromTokenNotRequest(t *testing.T) {
+	issuer := newTestIssuer(t)
+	verifier := NewVerifier(issuer.server.URL, 0)
+	intentions := NewIntentionSet([]Intention{
+		{Tenant: "acme", Effect: "allow"},
+		{Tenant: "*", Effect: "deny"},
+	})
+	authorizer := NewAuthorizer(verifier, intentions, zap.NewNop())
+
+	token := issuer.sign(t, "RS256", issuer.kid, jwtClaims{
+		Sub:    "alice",
+		Tenant: "acme",
+		Exp:    time.Now().Add(time.Hour).Unix(),
+	})
+
+	// req.Tenant claims a tenant the caller wasn't issued a token for;
+	// Authorize must use the token's own tenant claim instead, so a caller
+	// can't forge its way into a different tenant's intention rules.
+	claims, decision, err := authorizer.Authorize(token, Request{Tenant: "forged-tenant"})
+	if err != nil {
+		t.Fatalf("Authorize: %v", err)
+	}
+	if claims.Tenant != "acme" {
+		t.Errorf("claims.Tenant = %q, want acme", claims.Tenant)
+	}
+	if !decision.Allow || decision.MatchedRule != 0 {
+		t.Errorf("decision = %+v, want rule 0 to match on the token's real tenant", decision)
+	}
+}
+
+func TestAuthorizerDeniesInvalidToken(t *testing.T) {
+	issuer := newTestIssuer(t)
+	verifier := NewVerifier(issuer.server.URL, 0)
+	intentions := NewIntentionSet([]Intention{{Effect: "allow"}})
+	authorizer := NewAuthorizer(verifier, intentions, zap.NewNop())
+
+	_, _, err := authorizer.Authorize("not-a-jwt", Request{})
+	if err == nil {
+		t.Fatal("Authorize accepted a malformed token")
+	}
+}
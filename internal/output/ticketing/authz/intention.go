@@ -0,0 +1,107 @@
+package authz
+
+import "net"
+
+// Intention is one ordered allow/deny rule in an IntentionSet, modeled on
+// Consul's service intentions: a match on {source_type, mitre_tactic,
+// risk_level, tenant} (each field "*" wildcards) plus an optional CIDR
+// match against the event's affected assets, yielding either a flat
+// allow/deny or an allow with overrides.
+type Intention struct {
+	SourceType  string   `yaml:"source_type"`
+	MITRETactic string   `yaml:"mitre_tactic"`
+	RiskLevel   string   `yaml:"risk_level"`
+	Tenant      string   `yaml:"tenant"`
+	AssetCIDRs  []string `yaml:"asset_cidrs"` // matches if ANY affected asset falls in ANY of these CIDRs; empty means "don't care"
+
+	Effect           string `yaml:"effect"` // "allow" or "deny"
+	AssignmentGroup  string `yaml:"assignment_group,omitempty"`
+	PriorityOverride string `yaml:"priority_override,omitempty"`
+}
+
+// Request is the event context an IntentionSet evaluates a decision
+// against.
+type Request struct {
+	SourceType     string
+	MITRETactic    string
+	RiskLevel      string
+	Tenant         string
+	AffectedAssets []string
+}
+
+// Decision is the outcome of evaluating a Request against an IntentionSet.
+type Decision struct {
+	Allow            bool
+	AssignmentGroup  string
+	PriorityOverride string
+	MatchedRule      int // index into the IntentionSet's rules, -1 if nothing matched (deny-by-default)
+}
+
+// IntentionSet evaluates an ordered list of Intentions, first match wins,
+// deny-by-default when nothing matches.
+type IntentionSet struct {
+	rules []Intention
+}
+
+// NewIntentionSet creates an IntentionSet from rules, preserving their
+// order for first-match evaluation.
+func NewIntentionSet(rules []Intention) *IntentionSet {
+	return &IntentionSet{rules: rules}
+}
+
+// Evaluate returns the first rule in order that matches req, or a
+// deny-by-default Decision if none do.
+func (s *IntentionSet) Evaluate(req Request) Decision {
+	for i, rule := range s.rules {
+		if !matchesWildcard(rule.SourceType, req.SourceType) {
+			continue
+		}
+		if !matchesWildcard(rule.MITRETactic, req.MITRETactic) {
+			continue
+		}
+		if !matchesWildcard(rule.RiskLevel, req.RiskLevel) {
+			continue
+		}
+		if !matchesWildcard(rule.Tenant, req.Tenant) {
+			continue
+		}
+		if !matchesCIDRs(rule.AssetCIDRs, req.AffectedAssets) {
+			continue
+		}
+
+		return Decision{
+			Allow:            rule.Effect == "allow",
+			AssignmentGroup:  rule.AssignmentGroup,
+			PriorityOverride: rule.PriorityOverride,
+			MatchedRule:      i,
+		}
+	}
+
+	return Decision{Allow: false, MatchedRule: -1}
+}
+
+func matchesWildcard(pattern, value string) bool {
+	return pattern == "" || pattern == "*" || pattern == value
+}
+
+// matchesCIDRs reports whether cidrs is empty (don't care) or at least one
+// asset falls inside at least one of cidrs. Assets that aren't valid IPs
+// (e.g. hostnames) never match a CIDR and are simply skipped.
+func matchesCIDRs(cidrs []string, assets []string) bool {
+	if len(cidrs) == 0 {
+		return true
+	}
+	for _, cidr := range cidrs {
+		_, network, err := net.ParseCIDR(cidr)
+		if err != nil {
+			continue
+		}
+		for _, asset := range assets {
+			ip := net.ParseIP(asset)
+			if ip != nil && network.Contains(ip) {
+				return true
+			}
+		}
+	}
+	return false
+}
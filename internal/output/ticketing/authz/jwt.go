@@ -0,0 +1,233 @@
+// Package authz gates ticket creation with JWT authentication and a
+// Consul-intention-style allow/deny ruleset, evaluated after the caller's
+// identity is established but before a ticket is actually opened.
+package authz
+
+import (
+	"crypto"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Claims holds the subset of a verified JWT's claims ticket authorization
+// cares about.
+type Claims struct {
+	Subject   string
+	Groups    []string
+	Tenant    string
+	ExpiresAt time.Time
+}
+
+// jwksKey is the subset of a JWKS key entry this package understands. Only
+// RSA signing keys are supported, matching every JWKS-issuing IdP in
+// practice (Okta, Auth0, Entra ID, Keycloak).
+type jwksKey struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+}
+
+type jwksDocument struct {
+	Keys []jwksKey `json:"keys"`
+}
+
+// JWKSCache fetches signing keys from a JWKS endpoint and caches them for
+// ttl, so Verify doesn't make a round-trip per request. A cache miss on an
+// unknown kid (e.g. after the IdP rotates keys) forces one refresh before
+// giving up, so key rotation doesn't require a process restart.
+type JWKSCache struct {
+	url        string
+	ttl        time.Duration
+	httpClient *http.Client
+
+	mu        sync.Mutex
+	keys      map[string]*rsa.PublicKey
+	fetchedAt time.Time
+}
+
+// NewJWKSCache creates a JWKSCache that refreshes from url at most once per
+// ttl.
+func NewJWKSCache(url string, ttl time.Duration) *JWKSCache {
+	return &JWKSCache{
+		url:        url,
+		ttl:        ttl,
+		httpClient: &http.Client{Timeout: 10 * time.Second},
+		keys:       make(map[string]*rsa.PublicKey),
+	}
+}
+
+// Key returns the RSA public key for kid, refreshing the cache if it's
+// stale or the kid isn't known yet.
+func (c *JWKSCache) Key(kid string) (*rsa.PublicKey, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if key, ok := c.keys[kid]; ok && time.Since(c.fetchedAt) < c.ttl {
+		return key, nil
+	}
+	if err := c.refresh(); err != nil {
+		return nil, err
+	}
+	key, ok := c.keys[kid]
+	if !ok {
+		return nil, fmt.Errorf("authz: no JWKS key for kid %q", kid)
+	}
+	return key, nil
+}
+
+// refresh must be called with c.mu held.
+func (c *JWKSCache) refresh() error {
+	resp, err := c.httpClient.Get(c.url)
+	if err != nil {
+		return fmt.Errorf("fetching JWKS: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("reading JWKS response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("JWKS endpoint returned status %d", resp.StatusCode)
+	}
+
+	var doc jwksDocument
+	if err := json.Unmarshal(body, &doc); err != nil {
+		return fmt.Errorf("parsing JWKS: %w", err)
+	}
+
+	keys := make(map[string]*rsa.PublicKey, len(doc.Keys))
+	for _, k := range doc.Keys {
+		if k.Kty != "RSA" || k.Kid == "" {
+			continue
+		}
+		pub, err := rsaPublicKeyFromJWK(k)
+		if err != nil {
+			continue
+		}
+		keys[k.Kid] = pub
+	}
+
+	c.keys = keys
+	c.fetchedAt = time.Now()
+	return nil
+}
+
+func rsaPublicKeyFromJWK(k jwksKey) (*rsa.PublicKey, error) {
+	nBytes, err := base64.RawURLEncoding.DecodeString(k.N)
+	if err != nil {
+		return nil, fmt.Errorf("decoding modulus: %w", err)
+	}
+	eBytes, err := base64.RawURLEncoding.DecodeString(k.E)
+	if err != nil {
+		return nil, fmt.Errorf("decoding exponent: %w", err)
+	}
+
+	return &rsa.PublicKey{
+		N: new(big.Int).SetBytes(nBytes),
+		E: int(new(big.Int).SetBytes(eBytes).Int64()),
+	}, nil
+}
+
+// Verifier validates RS256-signed JWTs against a JWKS endpoint, tolerating
+// clockSkew of clock drift between this process and the token issuer when
+// checking exp/nbf.
+type Verifier struct {
+	jwks      *JWKSCache
+	clockSkew time.Duration
+}
+
+// NewVerifier creates a Verifier backed by a JWKS endpoint discovered at
+// jwksURL.
+func NewVerifier(jwksURL string, clockSkew time.Duration) *Verifier {
+	return &Verifier{
+		jwks:      NewJWKSCache(jwksURL, 10*time.Minute),
+		clockSkew: clockSkew,
+	}
+}
+
+type jwtHeader struct {
+	Alg string `json:"alg"`
+	Kid string `json:"kid"`
+}
+
+type jwtClaims struct {
+	Sub    string   `json:"sub"`
+	Groups []string `json:"groups"`
+	Tenant string   `json:"tenant"`
+	Exp    int64    `json:"exp"`
+	Nbf    int64    `json:"nbf"`
+}
+
+// Verify checks token's signature, issuer-declared validity window, and
+// returns the claims ticket authorization needs. Only RS256 is supported.
+func (v *Verifier) Verify(token string) (*Claims, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("authz: malformed JWT")
+	}
+
+	headerJSON, err := base64.RawURLEncoding.DecodeString(parts[0])
+	if err != nil {
+		return nil, fmt.Errorf("decoding JWT header: %w", err)
+	}
+	var header jwtHeader
+	if err := json.Unmarshal(headerJSON, &header); err != nil {
+		return nil, fmt.Errorf("parsing JWT header: %w", err)
+	}
+	if header.Alg != "RS256" {
+		return nil, fmt.Errorf("authz: unsupported JWT algorithm %q", header.Alg)
+	}
+
+	key, err := v.jwks.Key(header.Kid)
+	if err != nil {
+		return nil, err
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, fmt.Errorf("decoding JWT signature: %w", err)
+	}
+	signedInput := parts[0] + "." + parts[1]
+	hashed := sha256.Sum256([]byte(signedInput))
+	if err := rsa.VerifyPKCS1v15(key, crypto.SHA256, hashed[:], signature); err != nil {
+		return nil, fmt.Errorf("authz: invalid JWT signature: %w", err)
+	}
+
+	claimsJSON, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return nil, fmt.Errorf("decoding JWT claims: %w", err)
+	}
+	var claims jwtClaims
+	if err := json.Unmarshal(claimsJSON, &claims); err != nil {
+		return nil, fmt.Errorf("parsing JWT claims: %w", err)
+	}
+
+	now := time.Now()
+	if claims.Exp != 0 && now.After(time.Unix(claims.Exp, 0).Add(v.clockSkew)) {
+		return nil, fmt.Errorf("authz: JWT expired")
+	}
+	if claims.Nbf != 0 && now.Before(time.Unix(claims.Nbf, 0).Add(-v.clockSkew)) {
+		return nil, fmt.Errorf("authz: JWT not yet valid")
+	}
+	if claims.Sub == "" {
+		return nil, fmt.Errorf("authz: JWT missing sub claim")
+	}
+
+	return &Claims{
+		Subject:   claims.Sub,
+		Groups:    claims.Groups,
+		Tenant:    claims.Tenant,
+		ExpiresAt: time.Unix(claims.Exp, 0),
+	}, nil
+}
@@ -0,0 +1,189 @@
+package authz
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// testIssuer bundles an RSA key pair with a JWKS httptest.Server serving
+// its public half, so tests can sign tokens with testIssuer.sign and have
+// Verifier resolve them against testIssuer.server.URL like a real IdP.
+type testIssuer struct {
+	key    *rsa.PrivateKey
+	kid    string
+	server *httptest.Server
+}
+
+func newTestIssuer(t *testing.T) *testIssuer {
+	t.Helper()
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("generating RSA key: %v", err)
+	}
+
+	issuer := &testIssuer{key: key, kid: "test-key-1"}
+	issuer.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		doc := jwksDocument{Keys: []jwksKey{{
+			Kty: "RSA",
+			Kid: issuer.kid,
+			N:   base64.RawURLEncoding.EncodeToString(key.PublicKey.N.Bytes()),
+			E:   base64.RawURLEncoding.EncodeToString(bigIntBytes(key.PublicKey.E)),
+		}}}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(doc)
+	}))
+	t.Cleanup(issuer.server.Close)
+	return issuer
+}
+
+func bigIntBytes(n int) []byte {
+	if n == 0 {
+		return []byte{0}
+	}
+	var b []byte
+	for n > 0 {
+		b = append([]byte{byte(n & 0xff)}, b...)
+		n >>= 8
+	}
+	return b
+}
+
+// sign builds an RS256 JWT for claims, optionally under a header alg other
+// than RS256 and/or a kid other than the issuer's own, so tests can
+// exercise Verify's rejection paths.
+func (i *testIssuer) sign(t *testing.T, alg, kid string, claims jwtClaims) string {
+	t.Helper()
+
+	header := jwtHeader{Alg: alg, Kid: kid}
+	headerJSON, err := json.Marshal(header)
+	if err != nil {
+		t.Fatalf("marshaling header: %v", err)
+	}
+	claimsJSON, err := json.Marshal(claims)
+	if err != nil {
+		t.Fatalf("marshaling claims: %v", err)
+	}
+
+	signedInput := base64.RawURLEncoding.EncodeToString(headerJSON) + "." + base64.RawURLEncoding.EncodeToString(claimsJSON)
+	hashed := sha256.Sum256([]byte(signedInput))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, i.key, crypto.SHA256, hashed[:])
+	if err != nil {
+		t.Fatalf("signing token: %v", err)
+	}
+
+	return signedInput + "." + base64.RawURLEncoding.EncodeToString(signature)
+}
+
+func TestVerifierAcceptsValidToken(t *testing.T) {
+	issuer := newTestIssuer(t)
+	v := NewVerifier(issuer.server.URL, 0)
+
+	token := issuer.sign(t, "RS256", issuer.kid, jwtClaims{
+		Sub:    "alice",
+		Groups: []string{"soc-analysts"},
+		Tenant: "acme",
+		Exp:    time.Now().Add(time.Hour).Unix(),
+	})
+
+	claims, err := v.Verify(token)
+	if err != nil {
+		t.Fatalf("Verify: %v", err)
+	}
+	if claims.Subject != "alice" || claims.Tenant != "acme" {
+		t.Errorf("claims = %+v, want sub=alice tenant=acme", claims)
+	}
+}
+
+func TestVerifierRejectsExpiredToken(t *testing.T) {
+	issuer := newTestIssuer(t)
+	v := NewVerifier(issuer.server.URL, 0)
+
+	token := issuer.sign(t, "RS256", issuer.kid, jwtClaims{
+		Sub: "alice",
+		Exp: time.Now().Add(-time.Hour).Unix(),
+	})
+
+	if _, err := v.Verify(token); err == nil {
+		t.Fatal("Verify accepted an expired token")
+	}
+}
+
+func TestVerifierToleratesConfiguredClockSkew(t *testing.T) {
+	issuer := newTestIssuer(t)
+	v := NewVerifier(issuer.server.URL, 5*time.Minute)
+
+	token := issuer.sign(t, "RS256", issuer.kid, jwtClaims{
+		Sub: "alice",
+		Exp: time.Now().Add(-time.Minute).Unix(),
+	})
+
+	if _, err := v.Verify(token); err != nil {
+		t.Fatalf("Verify rejected a token expired within clock skew: %v", err)
+	}
+}
+
+func TestVerifierRejectsUnsupportedAlgorithm(t *testing.T) {
+	issuer := newTestIssuer(t)
+	v := NewVerifier(issuer.server.URL, 0)
+
+	token := issuer.sign(t, "HS256", issuer.kid, jwtClaims{
+		Sub: "alice",
+		Exp: time.Now().Add(time.Hour).Unix(),
+	})
+
+	if _, err := v.Verify(token); err == nil {
+		t.Fatal("Verify accepted a non-RS256 token")
+	}
+}
+
+func TestVerifierRejectsUnknownKid(t *testing.T) {
+	issuer := newTestIssuer(t)
+	v := NewVerifier(issuer.server.URL, 0)
+
+	token := issuer.sign(t, "RS256", "some-other-kid", jwtClaims{
+		Sub: "alice",
+		Exp: time.Now().Add(time.Hour).Unix(),
+	})
+
+	if _, err := v.Verify(token); err == nil {
+		t.Fatal("Verify accepted a token signed under an unknown kid")
+	}
+}
+
+func TestVerifierRejectsTamperedSignature(t *testing.T) {
+	issuer := newTestIssuer(t)
+	v := NewVerifier(issuer.server.URL, 0)
+
+	token := issuer.sign(t, "RS256", issuer.kid, jwtClaims{
+		Sub: "alice",
+		Exp: time.Now().Add(time.Hour).Unix(),
+	})
+	tampered := token[:len(token)-1] + fmt.Sprintf("%c", token[len(token)-1]^1)
+
+	if _, err := v.Verify(tampered); err == nil {
+		t.Fatal("Verify accepted a token with a tampered signature")
+	}
+}
+
+func TestVerifierRejectsMissingSubject(t *testing.T) {
+	issuer := newTestIssuer(t)
+	v := NewVerifier(issuer.server.URL, 0)
+
+	token := issuer.sign(t, "RS256", issuer.kid, jwtClaims{
+		Exp: time.Now().Add(time.Hour).Unix(),
+	})
+
+	if _, err := v.Verify(token); err == nil {
+		t.Fatal("Verify accepted a token with no sub claim")
+	}
+}
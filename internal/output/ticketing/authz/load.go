@@ -0,0 +1,27 @@
+package authz
+
+import (
+	"fmt"
+	"os"
+
+	"gopkg.in/yaml.v3"
+)
+
+// LoadIntentionsFile reads an ordered list of Intention rules from the YAML
+// file at path and returns them as an IntentionSet. A missing path is not
+// treated specially - callers that want an empty, deny-by-default
+// IntentionSet when no rules file is configured should check
+// IntentionRulesFile == "" themselves before calling this.
+func LoadIntentionsFile(path string) (*IntentionSet, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading intention rules file %s: %w", path, err)
+	}
+
+	var rules []Intention
+	if err := yaml.Unmarshal(data, &rules); err != nil {
+		return nil, fmt.Errorf("parsing intention rules file %s: %w", path, err)
+	}
+
+	return NewIntentionSet(rules), nil
+}
@@ -0,0 +1,68 @@
+package ticketing
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/lvonguyen/threat-telemetry-hub/internal/crowdsec"
+)
+
+// CrowdSecRemediationSink implements RemediationSink by pushing a CrowdSec
+// alert (POST /v1/alerts) carrying a single ban decision for the attacker
+// IP, with origin "threat-telemetry-hub" so it's distinguishable from
+// decisions CrowdSec's own scenarios produced.
+type CrowdSecRemediationSink struct {
+	client *crowdsec.Client
+	logger *zap.Logger
+}
+
+// crowdSecRemediationOrigin identifies decisions this sink pushes, in both
+// the alert source and its attached decision.
+const crowdSecRemediationOrigin = "threat-telemetry-hub"
+
+// NewCrowdSecRemediationSink creates a new CrowdSec remediation sink.
+func NewCrowdSecRemediationSink(client *crowdsec.Client, logger *zap.Logger) *CrowdSecRemediationSink {
+	return &CrowdSecRemediationSink{client: client, logger: logger}
+}
+
+func (s *CrowdSecRemediationSink) Name() string { return "crowdsec" }
+
+// PushRemediation pushes req as a CrowdSec ban decision scoped to the
+// attacker's IP.
+func (s *CrowdSecRemediationSink) PushRemediation(ctx context.Context, req RemediationRequest) error {
+	now := time.Now().UTC()
+	decision := crowdsec.Decision{
+		Origin:   crowdSecRemediationOrigin,
+		Type:     "ban",
+		Scope:    "Ip",
+		Value:    req.AttackerIP,
+		Duration: req.Duration.String(),
+		Scenario: fmt.Sprintf("%s/%s", crowdSecRemediationOrigin, req.Reason),
+	}
+
+	alert := crowdsec.Alert{
+		Scenario: decision.Scenario,
+		Message:  fmt.Sprintf("Critical-risk detection %s: %s", req.SourceEventID, req.Reason),
+		StartAt:  now.Format(time.RFC3339),
+		StopAt:   now.Add(req.Duration).Format(time.RFC3339),
+		Source: crowdsec.AlertSource{
+			Scope: decision.Scope,
+			Value: decision.Value,
+		},
+		Decisions: []crowdsec.Decision{decision},
+	}
+
+	if err := s.client.PushAlert(ctx, alert); err != nil {
+		return fmt.Errorf("pushing crowdsec remediation: %w", err)
+	}
+
+	s.logger.Info("Pushed remediation decision to CrowdSec",
+		zap.String("ip", req.AttackerIP),
+		zap.String("event_id", req.SourceEventID),
+		zap.Duration("duration", req.Duration),
+	)
+	return nil
+}
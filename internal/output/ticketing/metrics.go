@@ -0,0 +1,32 @@
+package ticketing
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// archerRequestsTotal counts every Archer API response by status, so
+// sustained 429/503 throttling (and the AIMD backoff it triggers) is visible
+// without digging through logs.
+var archerRequestsTotal = promauto.NewCounterVec(
+	prometheus.CounterOpts{
+		Namespace: "threat_telemetry_hub",
+		Subsystem: "ticketing",
+		Name:      "archer_requests_total",
+		Help:      "Total Archer API responses, by status (an HTTP status code, or \"error\" for a transport failure).",
+	},
+	[]string{"status"},
+)
+
+// archerBulkBatchSize records how many tickets went into each
+// /api/core/content/bulk request, so batch-size tuning has real data behind
+// it instead of guessing from BulkBatchSize alone.
+var archerBulkBatchSize = promauto.NewHistogram(
+	prometheus.HistogramOpts{
+		Namespace: "threat_telemetry_hub",
+		Subsystem: "ticketing",
+		Name:      "archer_bulk_batch_size",
+		Help:      "Number of tickets sent per Archer bulk-create batch.",
+		Buckets:   []float64{1, 5, 10, 25, 50, 100, 250, 500},
+	},
+)
@@ -3,9 +3,17 @@ package ticketing
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
 	"time"
 
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	"github.com/google/cel-go/cel"
 	"go.uber.org/zap"
+
+	"github.com/lvonguyen/threat-telemetry-hub/internal/events"
+	"github.com/lvonguyen/threat-telemetry-hub/internal/output/ticketing/authz"
 )
 
 // Provider defines the interface for ticketing systems
@@ -30,6 +38,38 @@ type Provider interface {
 
 	// Close closes a ticket
 	CloseTicket(ctx context.Context, ticketID string, resolution string) error
+
+	// BulkCreateTickets creates many tickets in as few round-trips as the
+	// backing system allows. Implementations that have no real bulk API
+	// should fall back to sequential CreateTicket calls rather than
+	// reporting BulkCreate as a capability; per-item failures are carried in
+	// the corresponding TicketResult.Error rather than failing the batch.
+	BulkCreateTickets(ctx context.Context, tickets []*Ticket) ([]*TicketResult, error)
+
+	// Capabilities declares which of the operations above (and a few this
+	// interface doesn't expose yet) are genuinely implemented against the
+	// backing system, as opposed to stubbed out. Callers should check this
+	// before relying on an operation rather than discovering the hard way
+	// that e.g. SearchTickets always returns an empty slice.
+	Capabilities() ProviderCapabilities
+}
+
+// ProviderCapabilities declares which ticketing operations a Provider
+// actually implements. The finding pipeline's dispatcher reads this to skip
+// or fall back on an unsupported operation instead of firing a call that
+// silently no-ops, and to warn at startup if a configured routing rule
+// requires a capability the chosen provider lacks.
+type ProviderCapabilities struct {
+	CreateTicket  bool
+	UpdateTicket  bool
+	GetTicket     bool
+	SearchTickets bool
+	AddComment    bool
+	CloseTicket   bool
+	BulkCreate    bool
+	Attachments   bool
+	Watchers      bool
+	CustomFields  bool
 }
 
 // Ticket represents a ticket/incident
@@ -65,6 +105,11 @@ type TicketResult struct {
 	TicketID   string `json:"ticket_id"`
 	TicketURL  string `json:"ticket_url"`
 	ExternalID string `json:"external_id"`
+
+	// Error is set instead of the fields above when this particular ticket
+	// failed as part of a BulkCreateTickets batch, so one bad item doesn't
+	// lose the results of the rest of the batch.
+	Error string `json:"error,omitempty"`
 }
 
 // TicketUpdate represents updates to a ticket
@@ -83,14 +128,17 @@ type TicketQuery struct {
 	Category      string    `json:"category,omitempty"`
 	SourceEventID string    `json:"source_event_id,omitempty"`
 	CreatedAfter  time.Time `json:"created_after,omitempty"`
+	UpdatedAfter  time.Time `json:"updated_after,omitempty"`
 	Limit         int       `json:"limit,omitempty"`
 }
 
 // Manager manages ticketing providers
 type Manager struct {
-	providers map[string]Provider
-	logger    *zap.Logger
-	config    ManagerConfig
+	providers     map[string]Provider
+	logger        *zap.Logger
+	config        ManagerConfig
+	authorizer    *authz.Authorizer
+	compiledRules []compiledAssignmentRule
 }
 
 // ManagerConfig configures the ticketing manager
@@ -102,27 +150,110 @@ type ManagerConfig struct {
 	AssignmentRules  []AssignmentRule  `yaml:"assignment_rules"`
 }
 
-// AssignmentRule defines rules for ticket assignment
+// AssignmentRule defines rules for ticket assignment. Condition is a CEL
+// expression evaluated against the event's category, risk_score,
+// risk_level, source, and mitre_tactics, e.g. "category == 'malware' &&
+// risk_score > 80". The first rule whose condition matches wins.
 type AssignmentRule struct {
-	Condition       string `yaml:"condition"`        // e.g., "category == 'malware'"
+	Condition       string `yaml:"condition"`
 	AssignmentGroup string `yaml:"assignment_group"`
 }
 
+// compiledAssignmentRule pairs an AssignmentRule with its compiled CEL
+// program so eventToTicket doesn't recompile a condition on every event.
+type compiledAssignmentRule struct {
+	rule    AssignmentRule
+	program cel.Program
+}
+
+// compileAssignmentRules compiles each rule's condition once at manager
+// construction time. A rule with an invalid condition is skipped (logged
+// as a warning) rather than blocking ticket creation for every event.
+func compileAssignmentRules(rules []AssignmentRule, logger *zap.Logger) []compiledAssignmentRule {
+	env, err := cel.NewEnv(
+		cel.Variable("category", cel.StringType),
+		cel.Variable("risk_score", cel.DoubleType),
+		cel.Variable("risk_level", cel.StringType),
+		cel.Variable("source", cel.StringType),
+		cel.Variable("mitre_tactics", cel.ListType(cel.StringType)),
+	)
+	if err != nil {
+		logger.Error("creating assignment rule CEL environment", zap.Error(err))
+		return nil
+	}
+
+	compiled := make([]compiledAssignmentRule, 0, len(rules))
+	for _, rule := range rules {
+		ast, iss := env.Compile(rule.Condition)
+		if iss.Err() != nil {
+			logger.Warn("skipping assignment rule with invalid condition",
+				zap.String("condition", rule.Condition),
+				zap.Error(iss.Err()),
+			)
+			continue
+		}
+		program, err := env.Program(ast)
+		if err != nil {
+			logger.Warn("skipping assignment rule, failed to build CEL program",
+				zap.String("condition", rule.Condition),
+				zap.Error(err),
+			)
+			continue
+		}
+		compiled = append(compiled, compiledAssignmentRule{rule: rule, program: program})
+	}
+	return compiled
+}
+
+// sequentialBulkCreate is the fallback BulkCreateTickets implementation for
+// providers with no real bulk-create API: it calls CreateTicket once per
+// ticket and carries per-item failures in TicketResult.Error instead of
+// aborting the whole batch.
+func sequentialBulkCreate(ctx context.Context, provider Provider, tickets []*Ticket) ([]*TicketResult, error) {
+	results := make([]*TicketResult, len(tickets))
+	for i, ticket := range tickets {
+		result, err := provider.CreateTicket(ctx, ticket)
+		if err != nil {
+			results[i] = &TicketResult{Error: err.Error()}
+			continue
+		}
+		results[i] = result
+	}
+	return results, nil
+}
+
 // NewManager creates a new ticketing manager
 func NewManager(cfg ManagerConfig, logger *zap.Logger) *Manager {
 	return &Manager{
-		providers: make(map[string]Provider),
-		logger:    logger,
-		config:    cfg,
+		providers:     make(map[string]Provider),
+		logger:        logger,
+		config:        cfg,
+		compiledRules: compileAssignmentRules(cfg.AssignmentRules, logger),
 	}
 }
 
-// RegisterProvider registers a ticketing provider
+// SetAuthorizer enables JWT-and-intention-gated ticket creation:
+// CreateTicketForEvent will require and verify a caller's token, and
+// consult the intention ruleset, before opening a ticket. With no
+// authorizer set (the default), CreateTicketForEvent behaves as before.
+func (m *Manager) SetAuthorizer(a *authz.Authorizer) {
+	m.authorizer = a
+}
+
+// RegisterProvider registers a ticketing provider, warning immediately if
+// it's about to become the default provider but doesn't support ticket
+// creation, which auto-create rules depend on.
 func (m *Manager) RegisterProvider(provider Provider) {
 	m.providers[provider.Name()] = provider
 	m.logger.Info("Registered ticketing provider",
 		zap.String("provider", provider.Name()),
 	)
+
+	if m.config.AutoCreateTicket && provider.Name() == m.config.DefaultProvider && !provider.Capabilities().CreateTicket {
+		m.logger.Warn("Default ticketing provider does not support ticket creation, but auto_create_ticket is enabled",
+			zap.String("provider", provider.Name()),
+		)
+	}
 }
 
 // GetProvider returns a provider by name
@@ -131,8 +262,12 @@ func (m *Manager) GetProvider(name string) (Provider, bool) {
 	return p, ok
 }
 
-// CreateTicketForEvent creates a ticket for a security event
-func (m *Manager) CreateTicketForEvent(ctx context.Context, event *SecurityEvent) (*TicketResult, error) {
+// CreateTicketForEvent creates a ticket for a security event. token is the
+// caller's JWT and is required whenever an Authorizer has been set via
+// SetAuthorizer (callers with no authorizer configured may pass ""); an
+// intention rule may also override the assignment group or priority an
+// AssignmentRule would otherwise have picked.
+func (m *Manager) CreateTicketForEvent(ctx context.Context, token string, event *SecurityEvent) (*TicketResult, error) {
 	// Check if auto-create is enabled and risk score meets threshold
 	if !m.config.AutoCreateTicket {
 		return nil, nil
@@ -147,6 +282,28 @@ func (m *Manager) CreateTicketForEvent(ctx context.Context, event *SecurityEvent
 		return nil, nil
 	}
 
+	var assignmentOverride, priorityOverride string
+	if m.authorizer != nil {
+		tactic := ""
+		if len(event.MITRETactics) > 0 {
+			tactic = event.MITRETactics[0]
+		}
+		_, decision, err := m.authorizer.Authorize(token, authz.Request{
+			SourceType:     event.Source,
+			MITRETactic:    tactic,
+			RiskLevel:      event.RiskLevel,
+			AffectedAssets: event.AffectedAssets,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("authorizing ticket creation: %w", err)
+		}
+		if !decision.Allow {
+			return nil, fmt.Errorf("ticket creation denied by intention policy for event %s", event.ID)
+		}
+		assignmentOverride = decision.AssignmentGroup
+		priorityOverride = decision.PriorityOverride
+	}
+
 	// Get default provider
 	provider, ok := m.providers[m.config.DefaultProvider]
 	if !ok {
@@ -156,8 +313,22 @@ func (m *Manager) CreateTicketForEvent(ctx context.Context, event *SecurityEvent
 		return nil, nil
 	}
 
+	if !provider.Capabilities().CreateTicket {
+		m.logger.Warn("Ticketing provider does not support ticket creation, skipping",
+			zap.String("provider", provider.Name()),
+			zap.String("event_id", event.ID),
+		)
+		return nil, nil
+	}
+
 	// Map event to ticket
 	ticket := m.eventToTicket(event)
+	if assignmentOverride != "" {
+		ticket.AssignmentGroup = assignmentOverride
+	}
+	if priorityOverride != "" {
+		ticket.Priority = priorityOverride
+	}
 
 	// Create ticket
 	result, err := provider.CreateTicket(ctx, ticket)
@@ -174,11 +345,25 @@ func (m *Manager) CreateTicketForEvent(ctx context.Context, event *SecurityEvent
 	return result, nil
 }
 
+// CreateTicketForCloudEvent is CreateTicketForEvent's CloudEvents-native
+// entry point: ce must be a SecurityEvent wrapped by
+// SecurityEvent.ToCloudEvent (or an equivalent producer), letting a caller
+// fed by internal/events.Transport.Subscribe hand events straight to the
+// ticketing manager without knowing about SecurityEvent directly.
+func (m *Manager) CreateTicketForCloudEvent(ctx context.Context, token string, ce *events.CloudEvent) (*TicketResult, error) {
+	event, err := SecurityEventFromCloudEvent(ce)
+	if err != nil {
+		return nil, err
+	}
+	return m.CreateTicketForEvent(ctx, token, event)
+}
+
 // SecurityEvent represents a security event that may need a ticket
 type SecurityEvent struct {
 	ID              string                 `json:"id"`
 	Type            string                 `json:"type"`
 	Source          string                 `json:"source"`
+	Tenant          string                 `json:"tenant,omitempty"`
 	Timestamp       time.Time              `json:"timestamp"`
 	RiskScore       float64                `json:"risk_score"`
 	RiskLevel       string                 `json:"risk_level"`
@@ -191,6 +376,43 @@ type SecurityEvent struct {
 	RawData         map[string]interface{} `json:"raw_data"`
 }
 
+// ToCloudEvent wraps event as a CloudEvents v1.0 envelope, carrying risk
+// score, MITRE tactics, and tenant as extension attributes so a
+// downstream SOAR/GRC system can filter on them without parsing the JSON
+// body, per events.ExtRiskScore/ExtMITRETactics/ExtTenant.
+func (event *SecurityEvent) ToCloudEvent() (*events.CloudEvent, error) {
+	ce := cloudevents.NewEvent()
+	ce.SetID(event.ID)
+	ce.SetSource(fmt.Sprintf("//threat-telemetry-hub/ticketing/%s", event.Source))
+	ce.SetType("io.threat-telemetry-hub.ticketing.security_event")
+	ce.SetTime(event.Timestamp)
+	ce.SetSubject(event.Summary)
+	ce.SetExtension(events.ExtRiskScore, event.RiskScore)
+	if len(event.MITRETactics) > 0 {
+		ce.SetExtension(events.ExtMITRETactics, strings.Join(event.MITRETactics, ","))
+	}
+	if event.Tenant != "" {
+		ce.SetExtension(events.ExtTenant, event.Tenant)
+	}
+	if err := ce.SetData(cloudevents.ApplicationJSON, event); err != nil {
+		return nil, fmt.Errorf("setting CloudEvent data: %w", err)
+	}
+	return &ce, nil
+}
+
+// SecurityEventFromCloudEvent unwraps a CloudEvent previously built by
+// ToCloudEvent back into a SecurityEvent. Extension attributes are
+// ignored on the way in - the JSON data payload is the source of truth -
+// since ToCloudEvent mirrors every field it sets as an extension into the
+// body already.
+func SecurityEventFromCloudEvent(ce *events.CloudEvent) (*SecurityEvent, error) {
+	var event SecurityEvent
+	if err := json.Unmarshal(ce.Data(), &event); err != nil {
+		return nil, fmt.Errorf("unmarshaling SecurityEvent from CloudEvent: %w", err)
+	}
+	return &event, nil
+}
+
 func (m *Manager) eventToTicket(event *SecurityEvent) *Ticket {
 	// Map risk level to priority
 	priority := "medium"
@@ -198,12 +420,23 @@ func (m *Manager) eventToTicket(event *SecurityEvent) *Ticket {
 		priority = p
 	}
 
-	// Determine assignment group based on rules
+	// Determine assignment group: first compiled rule whose CEL condition
+	// matches the event wins.
 	assignmentGroup := "Security Operations"
-	for _, rule := range m.config.AssignmentRules {
-		// Simplified rule matching - would need proper expression evaluation
-		if rule.Condition == "default" {
-			assignmentGroup = rule.AssignmentGroup
+	for _, cr := range m.compiledRules {
+		out, _, err := cr.program.Eval(map[string]interface{}{
+			"category":      event.Type,
+			"risk_score":    event.RiskScore,
+			"risk_level":    event.RiskLevel,
+			"source":        event.Source,
+			"mitre_tactics": event.MITRETactics,
+		})
+		if err != nil {
+			continue
+		}
+		if matched, ok := out.Value().(bool); ok && matched {
+			assignmentGroup = cr.rule.AssignmentGroup
+			break
 		}
 	}
 
@@ -0,0 +1,111 @@
+package ticketing
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"go.uber.org/zap"
+	"gopkg.in/yaml.v3"
+)
+
+// ProviderFactory constructs a Provider from its provider-specific config,
+// decoded from the same generic map shape the hub config file carries under
+// output.ticketing.providers.<name>.
+type ProviderFactory func(cfg map[string]interface{}, logger *zap.Logger) (Provider, error)
+
+// Registry maps provider type names (e.g. "archer", "servicenow") to the
+// factories that construct them, so the hub can instantiate whichever
+// ticketing providers are configured without every caller needing a
+// compile-time dependency on every provider package. Third-party providers
+// register themselves the same way the built-ins below do: call Register
+// from an init() in a side-package the operator links in, whether that's a
+// blank import compiled into the binary or a Go plugin loaded at startup.
+type Registry struct {
+	mu        sync.RWMutex
+	factories map[string]ProviderFactory
+}
+
+// DefaultRegistry is the process-wide registry built-in providers register
+// themselves into at init time, and the one cmd/hub constructs configured
+// providers against.
+var DefaultRegistry = NewRegistry()
+
+// NewRegistry creates an empty Registry.
+func NewRegistry() *Registry {
+	return &Registry{factories: make(map[string]ProviderFactory)}
+}
+
+// Register adds a provider factory under name, overwriting any existing
+// registration for that name.
+func (r *Registry) Register(name string, factory ProviderFactory) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.factories[name] = factory
+}
+
+// New constructs the named provider from cfg. It returns an error if no
+// factory was ever registered under name.
+func (r *Registry) New(name string, cfg map[string]interface{}, logger *zap.Logger) (Provider, error) {
+	r.mu.RLock()
+	factory, ok := r.factories[name]
+	r.mu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("ticketing: no provider registered for %q", name)
+	}
+	return factory(cfg, logger)
+}
+
+// Names returns the currently registered provider type names.
+func (r *Registry) Names() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	names := make([]string, 0, len(r.factories))
+	for name := range r.factories {
+		names = append(names, name)
+	}
+	return names
+}
+
+func init() {
+	DefaultRegistry.Register("archer", func(cfg map[string]interface{}, logger *zap.Logger) (Provider, error) {
+		var archerCfg ArcherConfig
+		if err := decodeProviderConfig(cfg, &archerCfg); err != nil {
+			return nil, fmt.Errorf("decoding archer config: %w", err)
+		}
+		provider, err := NewArcherProvider(archerCfg, logger)
+		if err != nil {
+			return nil, err
+		}
+		// Validated here, once, at the same point the provider is
+		// otherwise constructed with no I/O - a stale field ID or wrong
+		// Type in FieldMappings fails startup instead of surfacing as a
+		// create/update failure against a live event.
+		if err := provider.ValidateFieldMappings(context.Background()); err != nil {
+			return nil, fmt.Errorf("validating archer field mappings: %w", err)
+		}
+		return provider, nil
+	})
+
+	DefaultRegistry.Register("servicenow", func(cfg map[string]interface{}, logger *zap.Logger) (Provider, error) {
+		var snCfg ServiceNowConfig
+		if err := decodeProviderConfig(cfg, &snCfg); err != nil {
+			return nil, fmt.Errorf("decoding servicenow config: %w", err)
+		}
+		return NewServiceNowProvider(snCfg, logger)
+	})
+
+	// No Jira provider exists in this package yet, so there's nothing to
+	// register under "jira" until one is implemented.
+}
+
+// decodeProviderConfig round-trips cfg through YAML so a generic
+// map[string]interface{} (as loaded from viper/yaml config) can populate a
+// provider's typed, yaml-tagged config struct.
+func decodeProviderConfig(cfg map[string]interface{}, out interface{}) error {
+	data, err := yaml.Marshal(cfg)
+	if err != nil {
+		return err
+	}
+	return yaml.Unmarshal(data, out)
+}
@@ -0,0 +1,27 @@
+package ticketing
+
+import (
+	"context"
+	"time"
+)
+
+// RemediationSink pushes an edge-enforcement decision for a critical-risk
+// detection with an identified attacker IP, turning the hub into a
+// bidirectional bridge between EDR/SIEM detections and edge blocking
+// rather than a one-way feed into a ticketing/GRC system.
+type RemediationSink interface {
+	// Name returns the sink name
+	Name() string
+	// PushRemediation pushes req as a new remediation decision.
+	PushRemediation(ctx context.Context, req RemediationRequest) error
+}
+
+// RemediationRequest describes the decision to push: block AttackerIP for
+// Duration, attributed to the detection that triggered it.
+type RemediationRequest struct {
+	AttackerIP      string
+	Duration        time.Duration
+	Reason          string
+	SourceEventID   string
+	MITRETechniques []string
+}
@@ -58,6 +58,26 @@ func NewServiceNowProvider(cfg ServiceNowConfig, logger *zap.Logger) (*ServiceNo
 
 func (p *ServiceNowProvider) Name() string { return "servicenow" }
 
+// Capabilities reports that ServiceNowProvider implements every core
+// ticketing operation against real table API calls.
+func (p *ServiceNowProvider) Capabilities() ProviderCapabilities {
+	return ProviderCapabilities{
+		CreateTicket:  true,
+		UpdateTicket:  true,
+		GetTicket:     true,
+		SearchTickets: true,
+		AddComment:    true,
+		CloseTicket:   true,
+		CustomFields:  true,
+	}
+}
+
+// BulkCreateTickets has no real bulk equivalent in the ServiceNow table API,
+// so it falls back to one CreateTicket call per ticket.
+func (p *ServiceNowProvider) BulkCreateTickets(ctx context.Context, tickets []*Ticket) ([]*TicketResult, error) {
+	return sequentialBulkCreate(ctx, p, tickets)
+}
+
 // CreateTicket creates a new incident in ServiceNow
 func (p *ServiceNowProvider) CreateTicket(ctx context.Context, ticket *Ticket) (*TicketResult, error) {
 	url := fmt.Sprintf("%s/api/now/table/%s", p.instanceURL, p.config.DefaultTable)
@@ -246,6 +266,12 @@ func (p *ServiceNowProvider) SearchTickets(ctx context.Context, query TicketQuer
 		}
 		sysparm += fmt.Sprintf("state=%s", p.mapStatusToState(query.Status))
 	}
+	if !query.UpdatedAfter.IsZero() {
+		if sysparm != "" {
+			sysparm += "^"
+		}
+		sysparm += fmt.Sprintf("sys_updated_on>=%s", query.UpdatedAfter.Format("2006-01-02 15:04:05"))
+	}
 
 	if sysparm != "" {
 		url += "?sysparm_query=" + sysparm
@@ -284,6 +310,8 @@ func (p *ServiceNowProvider) SearchTickets(ctx context.Context, query TicketQuer
 			ShortDescription string `json:"short_description"`
 			State            string `json:"state"`
 			Urgency          string `json:"urgency"`
+			AssignedTo       string `json:"assigned_to"`
+			SourceEventID    string `json:"u_source_event_id"`
 		} `json:"result"`
 	}
 
@@ -294,11 +322,13 @@ func (p *ServiceNowProvider) SearchTickets(ctx context.Context, query TicketQuer
 	tickets := make([]*Ticket, 0, len(result.Result))
 	for _, r := range result.Result {
 		tickets = append(tickets, &Ticket{
-			ID:         r.SysID,
-			ExternalID: r.Number,
-			Title:      r.ShortDescription,
-			Status:     p.mapStateToStatus(r.State),
-			Priority:   p.mapUrgencyToPriority(r.Urgency),
+			ID:            r.SysID,
+			ExternalID:    r.Number,
+			Title:         r.ShortDescription,
+			Status:        p.mapStateToStatus(r.State),
+			Priority:      p.mapUrgencyToPriority(r.Urgency),
+			AssignedTo:    r.AssignedTo,
+			SourceEventID: r.SourceEventID,
 		})
 	}
 
@@ -368,6 +398,13 @@ func (p *ServiceNowProvider) mapStatusToState(status string) string {
 }
 
 func (p *ServiceNowProvider) mapStateToStatus(state string) string {
+	return mapStateToStatus(state)
+}
+
+// mapStateToStatus is a package-level function, not a method, because
+// WebhookReceiver needs to apply the same state mapping to an inbound
+// payload without an instance of ServiceNowProvider to hand.
+func mapStateToStatus(state string) string {
 	mapping := map[string]string{
 		"1": "new",
 		"2": "in_progress",
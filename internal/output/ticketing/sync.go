@@ -0,0 +1,101 @@
+package ticketing
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// SyncManager periodically reconciles hub-owned tickets by searching for
+// anything updated since its last cursor, so a ticket change whose webhook
+// delivery was dropped or never sent still reaches correlation.Correlator
+// instead of silently going stale.
+type SyncManager struct {
+	provider Provider
+	interval time.Duration
+	output   chan<- TicketEvent
+	logger   *zap.Logger
+
+	mu       sync.Mutex
+	lastSync time.Time
+}
+
+// NewSyncManager creates a SyncManager that searches provider on interval,
+// starting from time.Now() so the first reconciliation only covers tickets
+// updated after startup.
+func NewSyncManager(provider Provider, interval time.Duration, output chan<- TicketEvent, logger *zap.Logger) *SyncManager {
+	if !provider.Capabilities().SearchTickets {
+		logger.Warn("Ticket sync: provider does not support SearchTickets, reconciliation will never find anything",
+			zap.String("provider", provider.Name()),
+		)
+	}
+
+	return &SyncManager{
+		provider: provider,
+		interval: interval,
+		output:   output,
+		logger:   logger,
+		lastSync: time.Now(),
+	}
+}
+
+// Start runs the reconciliation loop until ctx is done.
+func (s *SyncManager) Start(ctx context.Context) {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.reconcile(ctx)
+		}
+	}
+}
+
+func (s *SyncManager) reconcile(ctx context.Context) {
+	s.mu.Lock()
+	since := s.lastSync
+	s.mu.Unlock()
+
+	now := time.Now()
+	tickets, err := s.provider.SearchTickets(ctx, TicketQuery{UpdatedAfter: since})
+	if err != nil {
+		s.logger.Warn("Ticket sync: search failed", zap.Error(err))
+		return
+	}
+
+	for _, t := range tickets {
+		if t.SourceEventID == "" {
+			continue
+		}
+
+		event := TicketEvent{
+			SourceEventID: t.SourceEventID,
+			TicketID:      t.ID,
+			Status:        t.Status,
+			AssignedTo:    t.AssignedTo,
+			ReceivedAt:    now,
+		}
+
+		select {
+		case s.output <- event:
+		default:
+			s.logger.Warn("Ticket event channel full, dropping reconciled ticket",
+				zap.String("ticket_id", event.TicketID),
+			)
+		}
+	}
+
+	s.mu.Lock()
+	s.lastSync = now
+	s.mu.Unlock()
+
+	s.logger.Debug("Ticket sync: reconciliation complete",
+		zap.Int("tickets", len(tickets)),
+		zap.Time("since", since),
+	)
+}
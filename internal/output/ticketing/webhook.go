@@ -0,0 +1,123 @@
+package ticketing
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// TicketEvent is emitted whenever a ticket's state, assignment, or notes
+// change on the provider side - either pushed by a webhook in near
+// real-time, or discovered by SyncManager's periodic reconciliation. It
+// lets a correlation.Correlator close the loop on the originating
+// detection instead of ticket updates staying write-only.
+type TicketEvent struct {
+	SourceEventID string
+	TicketID      string
+	Status        string
+	AssignedTo    string
+	WorkNotes     string
+	ReceivedAt    time.Time
+}
+
+// serviceNowWebhookPayload is the body a ServiceNow Business Rule POSTs on
+// ticket update, addressed by the fields CreateTicket populates (see
+// ServiceNowProvider.CreateTicket's u_source_event_id).
+type serviceNowWebhookPayload struct {
+	SysID         string `json:"sys_id"`
+	SourceEventID string `json:"u_source_event_id"`
+	State         string `json:"state"`
+	AssignedTo    string `json:"assigned_to"`
+	WorkNotes     string `json:"work_notes"`
+}
+
+// WebhookReceiver accepts ServiceNow Business Rule POSTs reporting a ticket
+// change and turns each one into a TicketEvent on its output channel, so
+// analyst actions taken inside ServiceNow (assignment, state change,
+// work notes) flow back into the hub instead of being lost.
+type WebhookReceiver struct {
+	secret string
+	output chan<- TicketEvent
+	logger *zap.Logger
+}
+
+// NewWebhookReceiver creates a WebhookReceiver, reading the HMAC shared
+// secret ServiceNow signs requests with from the environment variable
+// named by secretEnv.
+func NewWebhookReceiver(secretEnv string, output chan<- TicketEvent, logger *zap.Logger) (*WebhookReceiver, error) {
+	secret := os.Getenv(secretEnv)
+	if secret == "" {
+		return nil, fmt.Errorf("missing required webhook secret configuration")
+	}
+
+	return &WebhookReceiver{
+		secret: secret,
+		output: output,
+		logger: logger,
+	}, nil
+}
+
+// Handler returns the HTTP handler to mount under the webhook route. It
+// validates the X-SN-Signature header (hex-encoded HMAC-SHA256 over the raw
+// body) before mapping the payload to a TicketEvent and emitting it.
+func (r *WebhookReceiver) Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		body, err := io.ReadAll(req.Body)
+		if err != nil {
+			http.Error(w, "reading body", http.StatusBadRequest)
+			return
+		}
+
+		if !r.validSignature(req.Header.Get("X-SN-Signature"), body) {
+			r.logger.Warn("Rejected ServiceNow webhook with invalid signature")
+			http.Error(w, "invalid signature", http.StatusUnauthorized)
+			return
+		}
+
+		var payload serviceNowWebhookPayload
+		if err := json.Unmarshal(body, &payload); err != nil {
+			http.Error(w, "invalid payload", http.StatusBadRequest)
+			return
+		}
+
+		event := TicketEvent{
+			SourceEventID: payload.SourceEventID,
+			TicketID:      payload.SysID,
+			Status:        mapStateToStatus(payload.State),
+			AssignedTo:    payload.AssignedTo,
+			WorkNotes:     payload.WorkNotes,
+			ReceivedAt:    time.Now(),
+		}
+
+		select {
+		case r.output <- event:
+		default:
+			r.logger.Warn("Ticket event channel full, dropping webhook delivery",
+				zap.String("ticket_id", event.TicketID),
+				zap.String("source_event_id", event.SourceEventID),
+			)
+		}
+
+		w.WriteHeader(http.StatusOK)
+	})
+}
+
+func (r *WebhookReceiver) validSignature(header string, body []byte) bool {
+	if header == "" {
+		return false
+	}
+
+	mac := hmac.New(sha256.New, []byte(r.secret))
+	mac.Write(body)
+	expected := hex.EncodeToString(mac.Sum(nil))
+
+	return hmac.Equal([]byte(expected), []byte(header))
+}
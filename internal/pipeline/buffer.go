@@ -0,0 +1,94 @@
+package pipeline
+
+import (
+	"fmt"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+// PersistentBuffer durably holds events a DAG has accepted from its Source
+// but not yet finished processing, so a restart mid-flight replays them
+// instead of losing them - the property DRBCConfig's RPO/RTO targets
+// assume but the original in-memory-only flow couldn't provide. It's
+// built on BoltDB, the same single-node embedded store
+// internal/checkpoint.BoltCheckpointer already uses for collector cursors;
+// a shared (Postgres/Redis-backed) buffer for HA deployments running more
+// than one instance isn't implemented yet.
+type PersistentBuffer struct {
+	db     *bolt.DB
+	bucket []byte
+}
+
+// NewPersistentBuffer opens (creating if necessary) a BoltDB file at path
+// with a bucket scoped to pipelineName, so more than one DAG can share a
+// file without their backlogs colliding.
+func NewPersistentBuffer(path, pipelineName string) (*PersistentBuffer, error) {
+	db, err := bolt.Open(path, 0600, nil)
+	if err != nil {
+		return nil, fmt.Errorf("pipeline: opening buffer db %s: %w", path, err)
+	}
+
+	bucket := []byte("pipeline-buffer-" + pipelineName)
+	if err := db.Update(func(tx *bolt.Tx) error {
+		_, err := tx.CreateBucketIfNotExists(bucket)
+		return err
+	}); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("pipeline: creating buffer bucket for %s: %w", pipelineName, err)
+	}
+
+	return &PersistentBuffer{db: db, bucket: bucket}, nil
+}
+
+// Enqueue persists event, keyed by its CloudEvents ID, and returns that ID.
+func (b *PersistentBuffer) Enqueue(event *Event) (string, error) {
+	data, err := event.MarshalJSON()
+	if err != nil {
+		return "", fmt.Errorf("pipeline: marshaling buffered event: %w", err)
+	}
+	id := event.ID()
+	err = b.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(b.bucket).Put([]byte(id), data)
+	})
+	return id, err
+}
+
+// Remove deletes the buffered event with the given ID, once every
+// downstream stage has finished with it.
+func (b *PersistentBuffer) Remove(id string) error {
+	return b.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(b.bucket).Delete([]byte(id))
+	})
+}
+
+// All returns every event currently buffered, in no particular order, for
+// replay after a restart.
+func (b *PersistentBuffer) All() ([]*Event, error) {
+	var events []*Event
+	err := b.db.View(func(tx *bolt.Tx) error {
+		return tx.Bucket(b.bucket).ForEach(func(_, data []byte) error {
+			var ce Event
+			if err := ce.UnmarshalJSON(data); err != nil {
+				return fmt.Errorf("pipeline: unmarshaling buffered event: %w", err)
+			}
+			events = append(events, &ce)
+			return nil
+		})
+	})
+	return events, err
+}
+
+// Len returns the number of events currently buffered.
+func (b *PersistentBuffer) Len() int {
+	var n int
+	_ = b.db.View(func(tx *bolt.Tx) error {
+		n = tx.Bucket(b.bucket).Stats().KeyN
+		return nil
+	})
+	return n
+}
+
+// Close releases the underlying BoltDB file handle.
+func (b *PersistentBuffer) Close() error {
+	return b.db.Close()
+}
@@ -0,0 +1,36 @@
+package pipeline
+
+import (
+	"github.com/lvonguyen/threat-telemetry-hub/internal/config"
+)
+
+// deadLetterPath picks the BoltDB file a pipeline's dead-letter sink
+// persists to: alongside its persistent buffer if one is configured
+// (def.Buffer.Path), or a name derived from the pipeline itself if not -
+// either way every pipeline gets its own file rather than sharing one.
+func deadLetterPath(def config.PipelineDefinition) string {
+	if def.Buffer.Path != "" {
+		return def.Buffer.Path
+	}
+	return "data/" + def.Name + "-dead-letter.db"
+}
+
+// init registers the two Sinks every pipeline is likely to want: routing
+// finished events to ticketing, and catching anything a processor
+// couldn't handle.
+func init() {
+	RegisterSink("ticketing", func(_ config.PipelineDefinition, deps Deps) (Sink, bool, error) {
+		if deps.TicketingManager == nil {
+			return nil, false, nil
+		}
+		return NewTicketingSink(deps.TicketingManager, deps.TicketingToken), true, nil
+	})
+
+	RegisterSink("dead-letter", func(def config.PipelineDefinition, deps Deps) (Sink, bool, error) {
+		sink, err := NewDeadLetterSink(deadLetterPath(def), def.Name, deps.Logger)
+		if err != nil {
+			return nil, false, err
+		}
+		return sink, true, nil
+	})
+}
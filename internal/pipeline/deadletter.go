@@ -0,0 +1,85 @@
+package pipeline
+
+import (
+	"context"
+	"fmt"
+	"runtime/debug"
+
+	"go.uber.org/zap"
+)
+
+// Dead-letter extension attributes, attached to an event before it's
+// handed to a DeadLetterSink so the cause of the failure travels with it
+// instead of living only in a log line.
+const (
+	extDLQStage = "dlqstage"
+	extDLQError = "dlqerror"
+	extDLQStack = "dlqstack"
+)
+
+// withProcessingError returns a shallow copy of event with dead-letter
+// extensions recording which stage failed, the error, and a stack trace
+// captured at the point of failure - enough for an operator to diagnose
+// and replay it without needing to reproduce the failure live.
+func withProcessingError(event *Event, stage string, procErr error) *Event {
+	tagged := *event
+	tagged.SetExtension(extDLQStage, stage)
+	tagged.SetExtension(extDLQError, procErr.Error())
+	tagged.SetExtension(extDLQStack, string(debug.Stack()))
+	return &tagged
+}
+
+// DeadLetterSink persists events that failed normalization, enrichment, or
+// any other processor stage, so an operator can inspect and replay them
+// instead of the failure only being visible as a log line and a dropped
+// counter increment.
+type DeadLetterSink struct {
+	buffer *PersistentBuffer
+	logger *zap.Logger
+}
+
+// NewDeadLetterSink opens (creating if necessary) a BoltDB file at path to
+// hold failed events for pipelineName.
+func NewDeadLetterSink(path, pipelineName string, logger *zap.Logger) (*DeadLetterSink, error) {
+	buf, err := NewPersistentBuffer(path, pipelineName+"-dead-letter")
+	if err != nil {
+		return nil, err
+	}
+	return &DeadLetterSink{buffer: buf, logger: logger}, nil
+}
+
+// Name implements Sink.
+func (s *DeadLetterSink) Name() string { return "dead-letter" }
+
+// Send persists event, logging the stage and error it was tagged with by
+// withProcessingError.
+func (s *DeadLetterSink) Send(_ context.Context, event *Event) error {
+	if _, err := s.buffer.Enqueue(event); err != nil {
+		return fmt.Errorf("pipeline: persisting dead-lettered event: %w", err)
+	}
+	ext := event.Extensions()
+	s.logger.Warn("Event sent to dead letter",
+		zap.String("event_id", event.ID()),
+		zap.Any("stage", ext[extDLQStage]),
+		zap.Any("error", ext[extDLQError]),
+	)
+	return nil
+}
+
+// Replay returns every dead-lettered event for manual inspection or
+// resubmission, and Remove lets the caller clear an entry once it's been
+// successfully reprocessed.
+func (s *DeadLetterSink) Replay() ([]*Event, error) {
+	return s.buffer.All()
+}
+
+// Remove deletes the dead-lettered event with the given ID, typically
+// after Replay has successfully reprocessed it.
+func (s *DeadLetterSink) Remove(id string) error {
+	return s.buffer.Remove(id)
+}
+
+// Close releases the underlying BoltDB file handle.
+func (s *DeadLetterSink) Close() error {
+	return s.buffer.Close()
+}
@@ -0,0 +1,68 @@
+package pipeline
+
+import (
+	"context"
+
+	"github.com/lvonguyen/threat-telemetry-hub/internal/config"
+	"github.com/lvonguyen/threat-telemetry-hub/internal/events"
+)
+
+// TransportSource adapts an events.Transport's Subscribe method into a
+// Source, so a DAG declared in config.PipelineDefinition can pull events
+// from the same CloudEvents transports internal/events already offers
+// Publish on, instead of this package duplicating that wiring.
+type TransportSource struct {
+	transport events.Transport
+}
+
+// NewTransportSource wraps transport as a Source.
+func NewTransportSource(transport events.Transport) *TransportSource {
+	return &TransportSource{transport: transport}
+}
+
+// Name implements Source.
+func (s *TransportSource) Name() string { return s.transport.Name() }
+
+// Start implements Source by subscribing to the transport and forwarding
+// every received CloudEvent to output until ctx is canceled.
+func (s *TransportSource) Start(ctx context.Context, output chan<- *Event) error {
+	return s.transport.Subscribe(ctx, func(ce *Event) error {
+		select {
+		case output <- ce:
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	})
+}
+
+// init registers the three internal/events transports as DAG sources,
+// named the same way EventingConfig's own sections are.
+func init() {
+	RegisterSource("http", func(_ config.PipelineDefinition, deps Deps) (Source, bool, error) {
+		if !deps.Eventing.HTTP.Enabled {
+			return nil, false, nil
+		}
+		t, err := events.NewHTTPTransport(deps.Eventing.HTTP.TargetURL, deps.Eventing.HTTP.Addr, deps.Logger)
+		if err != nil {
+			return nil, false, err
+		}
+		return NewTransportSource(t), true, nil
+	})
+
+	RegisterSource("kafka", func(_ config.PipelineDefinition, deps Deps) (Source, bool, error) {
+		if !deps.Eventing.Kafka.Enabled {
+			return nil, false, nil
+		}
+		t := events.NewKafkaTransport(deps.Eventing.Kafka.Brokers, deps.Eventing.Kafka.Topic, deps.Eventing.Kafka.GroupID, deps.Logger)
+		return NewTransportSource(t), true, nil
+	})
+
+	RegisterSource("mqtt", func(_ config.PipelineDefinition, deps Deps) (Source, bool, error) {
+		if !deps.Eventing.MQTT.Enabled {
+			return nil, false, nil
+		}
+		t := events.NewMQTTTransport(deps.Eventing.MQTT.BrokerURL, deps.Eventing.MQTT.ClientID, deps.Eventing.MQTT.Topic, deps.Logger)
+		return NewTransportSource(t), true, nil
+	})
+}
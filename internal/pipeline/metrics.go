@@ -0,0 +1,46 @@
+package pipeline
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// These live under Subsystem "pipeline_dag" rather than cmd/hub's
+// "pipeline" subsystem, even though the request asked for plain
+// "pipeline_*" names - cmd/hub.Pipeline already registers queue_depth and
+// events_dropped_total under that subsystem, and a DAG built here runs
+// alongside it, not instead of it, so reusing the subsystem would panic
+// on duplicate registration the first time both are linked into the same
+// binary.
+var (
+	pipelineStageDuration = promauto.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: "threat_telemetry_hub",
+			Subsystem: "pipeline_dag",
+			Name:      "stage_duration_seconds",
+			Help:      "Time spent in each DAG stage, by pipeline and stage name.",
+			Buckets:   prometheus.DefBuckets,
+		},
+		[]string{"pipeline", "stage"},
+	)
+
+	pipelineQueueDepth = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "threat_telemetry_hub",
+			Subsystem: "pipeline_dag",
+			Name:      "queue_depth",
+			Help:      "Number of events currently held in a pipeline's persistent buffer.",
+		},
+		[]string{"pipeline"},
+	)
+
+	pipelineDroppedTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "threat_telemetry_hub",
+			Subsystem: "pipeline_dag",
+			Name:      "dropped_total",
+			Help:      "Total events a pipeline failed to buffer, process, or deliver, by stage or sink and reason.",
+		},
+		[]string{"pipeline", "stage", "reason"},
+	)
+)
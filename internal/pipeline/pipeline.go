@@ -0,0 +1,255 @@
+// Package pipeline models the telemetry flow (ingestion -> normalization
+// -> enrichment -> AI -> ticketing -> output) as an explicit DAG, the way
+// an OpenSearch Ingestion "Pipeline" resource does: a named Source feeds
+// a chain of Processors, whose output fans out to one or more Sinks. This
+// is independent of cmd/hub.Pipeline, which still runs the hub's original
+// fixed worker-pool flow; a DAG built here is a second, YAML-declared flow
+// (see config.PipelineDefinition) for cases that want a different or
+// narrower chain of stages - a compliance-only export feed, for instance,
+// that skips AI analysis entirely.
+package pipeline
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/lvonguyen/threat-telemetry-hub/internal/config"
+	"github.com/lvonguyen/threat-telemetry-hub/internal/events"
+)
+
+// Event is the unit every Source produces, every Processor transforms,
+// and every Sink consumes. It's the same CloudEvents envelope
+// internal/events and internal/ingestion already use, so a DAG can sit in
+// front of or behind either without a conversion step.
+type Event = events.CloudEvent
+
+// Source produces events into a DAG, e.g. by polling a vendor API or
+// listening for webhook deliveries.
+type Source interface {
+	Name() string
+	Start(ctx context.Context, output chan<- *Event) error
+}
+
+// Processor transforms or filters one event. Returning a nil event with a
+// nil error drops it silently (e.g. a filter stage); returning a non-nil
+// error routes it to the DAG's dead-letter sink, if one is configured.
+type Processor interface {
+	Name() string
+	Process(ctx context.Context, event *Event) (*Event, error)
+}
+
+// Sink delivers a finished event out of the DAG - to a ticketing system,
+// an export destination, or a dead-letter store for later replay.
+type Sink interface {
+	Name() string
+	Send(ctx context.Context, event *Event) error
+}
+
+// DAG wires one Source through a chain of Processors and fans the result
+// out to every Sink, via bounded channels between each stage.
+type DAG struct {
+	name       string
+	source     Source
+	processors []Processor
+	sinks      []Sink
+	deadLetter Sink
+	buffer     *PersistentBuffer // nil unless cfg.Buffer.PersistentEnabled
+	queueSize  int
+	logger     *zap.Logger
+}
+
+// New builds a DAG from cfg, resolving source/processors/sinks from the
+// caller-supplied maps (typically populated from each component's own
+// registry - see internal/pipeline's callers for how Source/Processor/Sink
+// implementations are looked up by name).
+func New(
+	cfg config.PipelineDefinition,
+	source Source,
+	processors []Processor,
+	sinks []Sink,
+	deadLetter Sink,
+	logger *zap.Logger,
+) (*DAG, error) {
+	queueSize := cfg.Buffer.MaxSize
+	if queueSize <= 0 {
+		queueSize = 1000
+	}
+
+	d := &DAG{
+		name:       cfg.Name,
+		source:     source,
+		processors: processors,
+		sinks:      sinks,
+		deadLetter: deadLetter,
+		queueSize:  queueSize,
+		logger:     logger.With(zap.String("pipeline", cfg.Name)),
+	}
+
+	if cfg.Buffer.PersistentEnabled {
+		buf, err := NewPersistentBuffer(cfg.Buffer.Path, cfg.Name)
+		if err != nil {
+			return nil, err
+		}
+		d.buffer = buf
+	}
+
+	return d, nil
+}
+
+// Start runs the DAG until ctx is canceled: the Source feeds either the
+// persistent buffer (if configured) or an in-memory channel directly,
+// and one goroutine drains that into the processor chain and out to every
+// sink.
+func (d *DAG) Start(ctx context.Context) error {
+	d.logger.Info("Starting pipeline",
+		zap.Int("processors", len(d.processors)),
+		zap.Int("sinks", len(d.sinks)),
+		zap.Bool("persistent_buffer", d.buffer != nil),
+	)
+
+	sourceOut := make(chan *Event, d.queueSize)
+	go func() {
+		if err := d.source.Start(ctx, sourceOut); err != nil && ctx.Err() == nil {
+			d.logger.Error("Pipeline source stopped with error", zap.Error(err))
+		}
+	}()
+
+	drain := sourceOut
+	if d.buffer != nil {
+		// Persist every event before it's acknowledged processed, so a
+		// restart resumes from the buffer instead of losing whatever the
+		// source had already delivered.
+		bufferedOut := make(chan *Event, d.queueSize)
+		go d.pumpThroughBuffer(ctx, sourceOut, bufferedOut)
+		drain = bufferedOut
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case event := <-drain:
+			d.process(ctx, event)
+		}
+	}
+}
+
+// pumpThroughBuffer persists every event from in before handing it to out,
+// and removes it from the buffer once every downstream stage has had a
+// chance to run (see DAG.process). If the process restarts between the
+// two, whatever is still in the buffer is replayed by loadBacklog.
+func (d *DAG) pumpThroughBuffer(ctx context.Context, in <-chan *Event, out chan<- *Event) {
+	d.loadBacklog(ctx, out)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case event := <-in:
+			id, err := d.buffer.Enqueue(event)
+			if err != nil {
+				d.logger.Error("Failed to persist event to buffer", zap.Error(err), zap.String("event_id", event.ID()))
+				pipelineDroppedTotal.WithLabelValues(d.name, "source", "buffer_write_failed").Inc()
+				continue
+			}
+			select {
+			case out <- event:
+			case <-ctx.Done():
+			}
+			// The buffer entry is only removed once downstream processing
+			// finishes - see process - so a crash between Enqueue and that
+			// point replays the event on the next start instead of losing it.
+			_ = id
+		}
+	}
+}
+
+// loadBacklog replays every event still in the persistent buffer from a
+// previous run, before the DAG starts taking new ones from the source.
+func (d *DAG) loadBacklog(ctx context.Context, out chan<- *Event) {
+	entries, err := d.buffer.All()
+	if err != nil {
+		d.logger.Error("Failed to read pipeline buffer backlog", zap.Error(err))
+		return
+	}
+	if len(entries) == 0 {
+		return
+	}
+	d.logger.Info("Replaying buffered events from a previous run", zap.Int("count", len(entries)))
+	for _, event := range entries {
+		select {
+		case out <- event:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// process runs event through every processor in order, then fans the
+// result out to every sink. A processor error (or a normalization/
+// enrichment failure surfaced the same way) sends event to the dead-letter
+// sink instead of the regular ones.
+func (d *DAG) process(ctx context.Context, event *Event) {
+	start := time.Now()
+	defer func() {
+		pipelineStageDuration.WithLabelValues(d.name, "total").Observe(time.Since(start).Seconds())
+	}()
+
+	current := event
+	for _, proc := range d.processors {
+		stageStart := time.Now()
+		next, err := proc.Process(ctx, current)
+		pipelineStageDuration.WithLabelValues(d.name, proc.Name()).Observe(time.Since(stageStart).Seconds())
+		if err != nil {
+			d.sendToDeadLetter(ctx, current, proc.Name(), err)
+			d.ack(current)
+			return
+		}
+		if next == nil {
+			// Processor filtered the event out; nothing downstream to do.
+			d.ack(current)
+			return
+		}
+		current = next
+	}
+
+	for _, sink := range d.sinks {
+		if err := sink.Send(ctx, current); err != nil {
+			d.logger.Warn("Pipeline sink failed",
+				zap.String("sink", sink.Name()),
+				zap.String("event_id", current.ID()),
+				zap.Error(err),
+			)
+			pipelineDroppedTotal.WithLabelValues(d.name, sink.Name(), "send_failed").Inc()
+		}
+	}
+
+	d.ack(current)
+}
+
+// ack removes event from the persistent buffer, if one is configured, now
+// that every stage has run.
+func (d *DAG) ack(event *Event) {
+	if d.buffer == nil {
+		return
+	}
+	if err := d.buffer.Remove(event.ID()); err != nil {
+		d.logger.Warn("Failed to remove acknowledged event from pipeline buffer", zap.String("event_id", event.ID()), zap.Error(err))
+	}
+	pipelineQueueDepth.WithLabelValues(d.name).Set(float64(d.buffer.Len()))
+}
+
+func (d *DAG) sendToDeadLetter(ctx context.Context, event *Event, stage string, procErr error) {
+	pipelineDroppedTotal.WithLabelValues(d.name, stage, "processor_error").Inc()
+	if d.deadLetter == nil {
+		d.logger.Warn("Pipeline stage failed with no dead-letter sink configured; event dropped",
+			zap.String("stage", stage), zap.String("event_id", event.ID()), zap.Error(procErr))
+		return
+	}
+	tagged := withProcessingError(event, stage, procErr)
+	if err := d.deadLetter.Send(ctx, tagged); err != nil {
+		d.logger.Error("Failed to send event to dead-letter sink",
+			zap.String("stage", stage), zap.String("event_id", event.ID()), zap.Error(err))
+	}
+}
@@ -0,0 +1,179 @@
+package pipeline
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	"go.uber.org/zap"
+
+	"github.com/lvonguyen/threat-telemetry-hub/internal/config"
+	"github.com/lvonguyen/threat-telemetry-hub/internal/output/ticketing"
+)
+
+// Deps bundles everything a registered Source/Processor/Sink factory might
+// need to build itself. Passing one struct instead of a long parameter
+// list means adding a new dependency (e.g. a future enrichment Processor
+// needing an EnrichmentConfig) doesn't change every existing factory's
+// signature.
+type Deps struct {
+	Logger           *zap.Logger
+	Eventing         config.EventingConfig
+	TicketingManager *ticketing.Manager
+	TicketingToken   string
+}
+
+// SourceFactory builds a Source for def from deps, returning (source,
+// enabled). It returns enabled=false without constructing anything when
+// its section of deps is disabled, mirroring internal/ingestion.Factory.
+type SourceFactory func(def config.PipelineDefinition, deps Deps) (source Source, enabled bool, err error)
+
+// ProcessorFactory builds a Processor for def from deps.
+type ProcessorFactory func(def config.PipelineDefinition, deps Deps) (processor Processor, enabled bool, err error)
+
+// SinkFactory builds a Sink for def from deps.
+type SinkFactory func(def config.PipelineDefinition, deps Deps) (sink Sink, enabled bool, err error)
+
+// registry is the global catalog of Source/Processor/Sink factories,
+// keyed by the name a config.PipelineDefinition refers to them by.
+// Built-in factories register themselves via init() in this package;
+// see internal/ingestion/registry.go for the precedent this mirrors.
+var registry = struct {
+	mu         sync.RWMutex
+	sources    map[string]SourceFactory
+	processors map[string]ProcessorFactory
+	sinks      map[string]SinkFactory
+}{
+	sources:    make(map[string]SourceFactory),
+	processors: make(map[string]ProcessorFactory),
+	sinks:      make(map[string]SinkFactory),
+}
+
+// RegisterSource adds a Source factory under name. Panics on duplicate
+// registration since that indicates two files claiming the same name.
+func RegisterSource(name string, factory SourceFactory) {
+	registry.mu.Lock()
+	defer registry.mu.Unlock()
+	if _, exists := registry.sources[name]; exists {
+		panic(fmt.Sprintf("pipeline: source %q already registered", name))
+	}
+	registry.sources[name] = factory
+}
+
+// RegisterProcessor adds a Processor factory under name.
+func RegisterProcessor(name string, factory ProcessorFactory) {
+	registry.mu.Lock()
+	defer registry.mu.Unlock()
+	if _, exists := registry.processors[name]; exists {
+		panic(fmt.Sprintf("pipeline: processor %q already registered", name))
+	}
+	registry.processors[name] = factory
+}
+
+// RegisterSink adds a Sink factory under name.
+func RegisterSink(name string, factory SinkFactory) {
+	registry.mu.Lock()
+	defer registry.mu.Unlock()
+	if _, exists := registry.sinks[name]; exists {
+		panic(fmt.Sprintf("pipeline: sink %q already registered", name))
+	}
+	registry.sinks[name] = factory
+}
+
+// RegisteredSourceNames returns the sorted names of every Source factory
+// compiled into this binary.
+func RegisteredSourceNames() []string {
+	registry.mu.RLock()
+	defer registry.mu.RUnlock()
+	names := make([]string, 0, len(registry.sources))
+	for name := range registry.sources {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Resolve looks up def's Source, Processors, and Sinks in the registry
+// populated by RegisterSource/RegisterProcessor/RegisterSink, the way
+// internal/ingestion.NewCollectorsFromConfig resolves IngestionConfig's
+// collector names against its own registry. It fails closed: an
+// unregistered or disabled name is an error here rather than a silently
+// skipped stage, since a DAG missing a stage it was configured to have
+// would otherwise drop events without anyone noticing.
+func Resolve(def config.PipelineDefinition, deps Deps) (Source, []Processor, []Sink, error) {
+	source, err := resolveSource(def, deps)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	processors := make([]Processor, 0, len(def.Processors))
+	for _, name := range def.Processors {
+		proc, err := resolveProcessor(name, def, deps)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		processors = append(processors, proc)
+	}
+
+	sinks := make([]Sink, 0, len(def.Sinks))
+	for _, name := range def.Sinks {
+		sink, err := resolveSink(name, def, deps)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		sinks = append(sinks, sink)
+	}
+
+	return source, processors, sinks, nil
+}
+
+func resolveSource(def config.PipelineDefinition, deps Deps) (Source, error) {
+	registry.mu.RLock()
+	factory, ok := registry.sources[def.Source]
+	registry.mu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("pipeline %q: no source registered under name %q (have %v)", def.Name, def.Source, RegisteredSourceNames())
+	}
+	source, enabled, err := factory(def, deps)
+	if err != nil {
+		return nil, fmt.Errorf("pipeline %q: constructing source %q: %w", def.Name, def.Source, err)
+	}
+	if !enabled {
+		return nil, fmt.Errorf("pipeline %q: source %q is registered but disabled in its own config section", def.Name, def.Source)
+	}
+	return source, nil
+}
+
+func resolveProcessor(name string, def config.PipelineDefinition, deps Deps) (Processor, error) {
+	registry.mu.RLock()
+	factory, ok := registry.processors[name]
+	registry.mu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("pipeline %q: no processor registered under name %q", def.Name, name)
+	}
+	proc, enabled, err := factory(def, deps)
+	if err != nil {
+		return nil, fmt.Errorf("pipeline %q: constructing processor %q: %w", def.Name, name, err)
+	}
+	if !enabled {
+		return nil, fmt.Errorf("pipeline %q: processor %q is registered but disabled in its own config section", def.Name, name)
+	}
+	return proc, nil
+}
+
+func resolveSink(name string, def config.PipelineDefinition, deps Deps) (Sink, error) {
+	registry.mu.RLock()
+	factory, ok := registry.sinks[name]
+	registry.mu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("pipeline %q: no sink registered under name %q", def.Name, name)
+	}
+	sink, enabled, err := factory(def, deps)
+	if err != nil {
+		return nil, fmt.Errorf("pipeline %q: constructing sink %q: %w", def.Name, name, err)
+	}
+	if !enabled {
+		return nil, fmt.Errorf("pipeline %q: sink %q is registered but disabled in its own config section", def.Name, name)
+	}
+	return sink, nil
+}
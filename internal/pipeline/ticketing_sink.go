@@ -0,0 +1,40 @@
+package pipeline
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/lvonguyen/threat-telemetry-hub/internal/output/ticketing"
+)
+
+// TicketingSink adapts a ticketing.Manager into a Sink, so a DAG declared
+// in config.PipelineDefinition can route its events straight to ticketing
+// the same way it would to any other sink, instead of ticketing being a
+// special case wired outside the DAG.
+type TicketingSink struct {
+	manager *ticketing.Manager
+	token   string
+}
+
+// NewTicketingSink wraps manager as a Sink. token is passed through to
+// every CreateTicketForCloudEvent call, the same caller-identity token
+// CreateTicketForEvent already requires when the manager has an
+// authorizer configured.
+func NewTicketingSink(manager *ticketing.Manager, token string) *TicketingSink {
+	return &TicketingSink{manager: manager, token: token}
+}
+
+// Name implements Sink.
+func (s *TicketingSink) Name() string { return "ticketing" }
+
+// Send hands event to the ticketing manager. A nil result (the manager
+// declining to create a ticket, e.g. no default provider configured) is
+// not an error - it just means this event didn't warrant one.
+func (s *TicketingSink) Send(ctx context.Context, event *Event) error {
+	result, err := s.manager.CreateTicketForCloudEvent(ctx, s.token, event)
+	if err != nil {
+		return fmt.Errorf("pipeline: creating ticket for event %s: %w", event.ID(), err)
+	}
+	_ = result
+	return nil
+}
@@ -0,0 +1,19 @@
+package safe
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// panicsTotal counts panics recovered by Go and Loop, by component, so a
+// collector or pipeline stage that's panicking repeatedly shows up in
+// metrics instead of just scrolling past in logs.
+var panicsTotal = promauto.NewCounterVec(
+	prometheus.CounterOpts{
+		Namespace: "threat_telemetry_hub",
+		Subsystem: "safe",
+		Name:      "panics_total",
+		Help:      "Total panics recovered from wrapped goroutines, by component.",
+	},
+	[]string{"component"},
+)
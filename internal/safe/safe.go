@@ -0,0 +1,91 @@
+// Package safe wraps goroutines whose panics would otherwise crash the
+// whole process - a single malformed vendor payload panicking inside a
+// collector or the AI analyzer shouldn't take down every other collector
+// and every in-flight event with it.
+package safe
+
+import (
+	"context"
+	"runtime/debug"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+const (
+	initialBackoff = 1 * time.Second
+	maxBackoff     = 30 * time.Second
+)
+
+// Go runs fn in a new goroutine, recovering any panic it raises, logging it
+// via logger (with component and the stack trace as fields) and
+// incrementing panics_total{component}. Unlike a bare `go fn()`, a panic in
+// fn is therefore contained to component instead of crashing the process.
+// Use this for fire-and-forget goroutines; use Loop for ones that should
+// keep running after a panic.
+func Go(logger *zap.Logger, component string, fn func()) {
+	go func() {
+		defer recoverPanic(logger, component)
+		fn()
+	}()
+}
+
+// Loop calls fn repeatedly, in the calling goroutine, until ctx is done,
+// recovering any panic fn raises and retrying after an exponential
+// backoff - mirroring how a gRPC recovery interceptor turns a handler
+// panic into a single failed call rather than a dead server. The backoff
+// resets to its initial value once fn has run for longer than maxBackoff
+// without panicking, so one bad event doesn't permanently slow down an
+// otherwise healthy collector or worker. fn itself is expected to run
+// until ctx is done (e.g. a select loop on ctx.Done()); Loop returns as
+// soon as ctx is done, whether fn returned on its own or via a recovered
+// panic.
+func Loop(ctx context.Context, logger *zap.Logger, component string, fn func(ctx context.Context)) {
+	backoff := initialBackoff
+	for ctx.Err() == nil {
+		started := time.Now()
+		run(ctx, logger, component, fn)
+
+		if ctx.Err() != nil {
+			return
+		}
+		if time.Since(started) > maxBackoff {
+			backoff = initialBackoff
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+		if backoff > maxBackoff {
+			backoff = maxBackoff
+		}
+	}
+}
+
+func run(ctx context.Context, logger *zap.Logger, component string, fn func(ctx context.Context)) {
+	defer recoverPanic(logger, component)
+	fn(ctx)
+}
+
+func recoverPanic(logger *zap.Logger, component string) {
+	if r := recover(); r != nil {
+		RecordPanic(logger, component, r)
+	}
+}
+
+// RecordPanic logs a recovered panic (with its stack trace) and increments
+// panics_total{component}. Go and Loop call this internally; it's exported
+// for callers that recover a panic themselves instead of going through
+// them - e.g. Gin middleware, which needs to turn the panic into an HTTP
+// response rather than retry or give up.
+func RecordPanic(logger *zap.Logger, component string, recovered interface{}) {
+	panicsTotal.WithLabelValues(component).Inc()
+	logger.Error("Recovered from panic",
+		zap.String("component", component),
+		zap.Any("panic", recovered),
+		zap.String("stack", string(debug.Stack())),
+	)
+}
@@ -0,0 +1,142 @@
+package safe
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestGoRecoversPanicAndLogsIt(t *testing.T) {
+	core, logs := observer.New(zapcore.DebugLevel)
+	logger := zap.New(core)
+
+	done := make(chan struct{})
+	Go(logger, "test-component", func() {
+		defer close(done)
+		panic("boom")
+	})
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Go's goroutine never ran")
+	}
+
+	// Go's defer runs recoverPanic after fn returns from its own deferred
+	// close(done), so give the recover a moment to land before asserting.
+	deadline := time.Now().Add(time.Second)
+	for logs.Len() == 0 && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+
+	entries := logs.All()
+	if len(entries) != 1 {
+		t.Fatalf("log entries = %d, want 1 recovered-panic entry", len(entries))
+	}
+	if entries[0].ContextMap()["component"] != "test-component" {
+		t.Errorf("logged component = %v, want test-component", entries[0].ContextMap()["component"])
+	}
+}
+
+func TestGoDoesNotRecoverWhenFnDoesNotPanic(t *testing.T) {
+	core, logs := observer.New(zapcore.DebugLevel)
+	logger := zap.New(core)
+
+	done := make(chan struct{})
+	Go(logger, "test-component", func() { close(done) })
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Go's goroutine never ran")
+	}
+
+	if logs.Len() != 0 {
+		t.Errorf("log entries = %d, want 0 when fn never panics", logs.Len())
+	}
+}
+
+func TestLoopRecoversPanicAndRetries(t *testing.T) {
+	core, logs := observer.New(zapcore.DebugLevel)
+	logger := zap.New(core)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var calls int32
+	fn := func(ctx context.Context) {
+		n := atomic.AddInt32(&calls, 1)
+		if n < 3 {
+			panic("transient failure")
+		}
+		cancel()
+		<-ctx.Done()
+	}
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		Loop(ctx, logger, "test-loop", fn)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(8 * time.Second):
+		t.Fatal("Loop never returned after ctx was canceled")
+	}
+
+	if got := atomic.LoadInt32(&calls); got != 3 {
+		t.Errorf("calls = %d, want 3 (two panics recovered, then a clean run)", got)
+	}
+	if logs.Len() != 2 {
+		t.Errorf("log entries = %d, want 2 recovered panics", logs.Len())
+	}
+}
+
+func TestLoopReturnsPromptlyOnContextCancellation(t *testing.T) {
+	logger := zap.NewNop()
+	ctx, cancel := context.WithCancel(context.Background())
+
+	started := make(chan struct{})
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		Loop(ctx, logger, "test-loop", func(ctx context.Context) {
+			close(started)
+			<-ctx.Done()
+		})
+	}()
+
+	<-started
+	cancel()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Loop did not return promptly after context cancellation")
+	}
+}
+
+func TestRecordPanicLogsComponentAndStack(t *testing.T) {
+	core, logs := observer.New(zapcore.DebugLevel)
+	logger := zap.New(core)
+
+	RecordPanic(logger, "manual-component", "manual panic value")
+
+	entries := logs.All()
+	if len(entries) != 1 {
+		t.Fatalf("log entries = %d, want 1", len(entries))
+	}
+	fields := entries[0].ContextMap()
+	if fields["component"] != "manual-component" {
+		t.Errorf("component = %v, want manual-component", fields["component"])
+	}
+	if _, ok := fields["stack"]; !ok {
+		t.Error("log entry has no stack field")
+	}
+}